@@ -2,59 +2,356 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
-	"log"
-	"runtime"
+	"io"
+	"os"
+	"os/signal"
 	"strings"
-	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/vineetjain1712/auction-simulator/config"
+	"github.com/vineetjain1712/auction-simulator/internal/ascii"
 	"github.com/vineetjain1712/auction-simulator/internal/auction"
-	"github.com/vineetjain1712/auction-simulator/internal/bidder"
 	"github.com/vineetjain1712/auction-simulator/internal/export"
+	"github.com/vineetjain1712/auction-simulator/internal/logging"
 	"github.com/vineetjain1712/auction-simulator/internal/models"
 	"github.com/vineetjain1712/auction-simulator/internal/monitor"
+	"github.com/vineetjain1712/auction-simulator/internal/simulation"
 	"github.com/vineetjain1712/auction-simulator/internal/stats"
 )
 
+// cliOptions holds the command-line options
+type cliOptions struct {
+	warmup    int    // Discarded warmup simulations before the measured run
+	itemsOnly int    // Generate and export this many items, then exit
+	items     string // JSON item file to auction instead of generated items ("" = generate)
+
+	seed          int64  // Seed of the run (0 = time-based)
+	seedString    string // Label the seed is derived from ("" = use seed)
+	deterministic bool   // Collect bids sequentially so a seeded run is exactly repeatable
+	bidderSeeds   bool   // Record each bidder's seed and export per-bidder statistics
+	compact       bool   // Keep only compact per-auction results and report statistics, without exports
+
+	progress time.Duration // Interval of progress and ETA reports (0 = none)
+
+	remoteBids string // Address serving bids from external bidders ("" = none)
+
+	output         string            // Output directory, or export.Stdout for the result on stdout
+	format         export.Format     // Format of the result on stdout
+	exportAttempts int               // Attempts per export file write before giving up
+	csv            export.CSVOptions // CSV export dialect
+	minifyJSON     bool              // Write JSON exports without indentation
+	archive        bool              // Also bundle the exports into one zip file
+	dedup          bool              // Reuse identical earlier exports instead of writing copies
+	keepOutputs    int               // Output sets kept in the output directory (0 = all)
+	summaryLog     string            // File each run appends a summary line to ("" = none)
+
+	thresholds stats.Thresholds // Minimum results; the run fails below them
+
+	jsonErrors bool // Report fatal errors as JSON on stderr
+	ascii      bool // Render the console output and reports in plain ASCII
+}
+
+// console receives the console output: os.Stdout, rendered in ASCII with
+// -ascii
+var console io.Writer = os.Stdout
+
+// parseFlags parses command-line arguments into options
+func parseFlags(args []string) (cliOptions, error) {
+	var opts cliOptions
+	var delimiter, format string
+
+	fs := flag.NewFlagSet("simulator", flag.ContinueOnError)
+	fs.IntVar(&opts.warmup, "warmup", 0, "number of discarded warmup simulations before the measured run")
+	fs.IntVar(&opts.itemsOnly, "items-only", 0, "generate and export N items without running auctions")
+	fs.StringVar(&opts.items, "items", "", "auction the items of this JSON file, e.g. an exported items_<timestamp>.json, instead of generated ones")
+	fs.Int64Var(&opts.seed, "seed", 0, "seed of the run (0 = time-based); the seed used is printed and exported")
+	fs.StringVar(&opts.seedString, "seed-string", "", "derive the seed from a label, e.g. \"experiment-42\", instead of -seed")
+	fs.BoolVar(&opts.deterministic, "deterministic", false, "collect bids sequentially so a seeded run is exactly repeatable")
+	fs.BoolVar(&opts.bidderSeeds, "bidder-seeds", false, "record each bidder's seed and export per-bidder statistics with it")
+	fs.BoolVar(&opts.compact, "compact", false, "keep only compact per-auction results to bound memory on very large runs; reports statistics without exporting")
+	fs.DurationVar(&opts.progress, "progress", 0, "report completed auctions and an ETA at this interval, e.g. 1s (0 = never)")
+	fs.StringVar(&opts.remoteBids, "remote-bids", "", "serve JSON-RPC Auction.PlaceBid calls from external bidders on this address while auctions run, e.g. :7070")
+	fs.StringVar(&opts.output, "output", "./output", "directory for exported files, or \"-\" to write only the result to stdout")
+	fs.StringVar(&format, "format", string(export.FormatJSON), "format of the result with -output -: json or csv")
+	fs.IntVar(&opts.exportAttempts, "export-attempts", 3, "attempts per export file write, retried with backoff")
+	fs.StringVar(&delimiter, "csv-delimiter", ",", "field separator of CSV exports, e.g. \";\"")
+	fs.BoolVar(&opts.csv.BOM, "csv-bom", false, "start CSV exports with a UTF-8 BOM for Excel")
+	fs.BoolVar(&opts.minifyJSON, "minify-json", false, "write JSON exports without indentation")
+	fs.BoolVar(&opts.archive, "archive", false, "also bundle the exports into a single archive_<timestamp>.zip")
+	fs.BoolVar(&opts.dedup, "dedup", false, "reuse an identical earlier export instead of writing a copy")
+	fs.IntVar(&opts.keepOutputs, "keep-outputs", 0, "keep only the N most recent output sets (0 = keep all)")
+	fs.StringVar(&opts.summaryLog, "summary-log", "", "append a tab-separated summary line of the run to this file, e.g. sweep.tsv")
+	fs.Float64Var(&opts.thresholds.MinSuccessRate, "min-success-rate", 0, "fail if the success rate (%) is below this")
+	fs.Float64Var(&opts.thresholds.MinBidsPerSecond, "min-bids-per-sec", 0, "fail if bids/second is below this")
+	fs.Float64Var(&opts.thresholds.MinRevenue, "min-revenue", 0, "fail if total revenue is below this")
+	fs.BoolVar(&opts.ascii, "ascii", false, "render the console output and reports in plain ASCII, without box drawing or emoji")
+	fs.BoolVar(&opts.jsonErrors, "json-errors", false, "report fatal errors to stderr as JSON, e.g. {\"error\":\"...\",\"stage\":\"validate\"}")
+
+	err := fs.Parse(args)
+	if err != nil {
+		return opts, err
+	}
+	if opts.warmup < 0 {
+		return opts, fmt.Errorf("-warmup must not be negative")
+	}
+	if opts.itemsOnly < 0 {
+		return opts, fmt.Errorf("-items-only must not be negative")
+	}
+	if opts.items != "" && opts.itemsOnly > 0 {
+		return opts, fmt.Errorf("-items cannot be combined with -items-only")
+	}
+	if opts.keepOutputs < 0 {
+		return opts, fmt.Errorf("-keep-outputs must not be negative")
+	}
+	if opts.seedString != "" {
+		if opts.seed != 0 {
+			return opts, fmt.Errorf("-seed and -seed-string cannot be combined")
+		}
+		opts.seed = config.SeedFromString(opts.seedString)
+	}
+	if opts.progress < 0 {
+		return opts, fmt.Errorf("-progress must not be negative")
+	}
+	if opts.exportAttempts < 1 {
+		return opts, fmt.Errorf("-export-attempts must be at least 1")
+	}
+	if utf8.RuneCountInString(delimiter) != 1 || strings.ContainsAny(delimiter, "\"\r\n") {
+		return opts, fmt.Errorf("-csv-delimiter must be a single character other than a quote or newline")
+	}
+	opts.csv.Delimiter, _ = utf8.DecodeRuneInString(delimiter)
+	if opts.format, err = export.ParseFormat(format); err != nil {
+		return opts, fmt.Errorf("-format: %w", err)
+	}
+	if opts.output == export.Stdout && opts.itemsOnly > 0 {
+		return opts, fmt.Errorf("-items-only cannot write to stdout")
+	}
+	if opts.compact && opts.output == export.Stdout {
+		return opts, fmt.Errorf("-compact cannot write the result to stdout")
+	}
+	if opts.compact && opts.summaryLog != "" {
+		return opts, fmt.Errorf("-compact cannot be combined with -summary-log")
+	}
+	return opts, nil
+}
+
+// Stages a run can fail at, as reported by an errorReporter
+const (
+	stageArgs       = "args"
+	stageItems      = "items"
+	stageValidate   = "validate"
+	stageExport     = "export"
+	stageThresholds = "thresholds"
+)
+
+// stageLabels describe each stage's failure in text reports
+var stageLabels = map[string]string{
+	stageArgs:       "Invalid arguments",
+	stageItems:      "Cannot load items",
+	stageValidate:   "Invalid configuration",
+	stageExport:     "Export failed",
+	stageThresholds: "Thresholds not met",
+}
+
+// errorReport is the JSON form of a fatal error
+type errorReport struct {
+	Error string `json:"error"`
+	Stage string `json:"stage"`
+}
+
+// errorReporter reports fatal errors as human text, or as one JSON object
+// per line for wrapping tools
+type errorReporter struct {
+	out  io.Writer
+	json bool
+}
+
+// report writes err, which failed the run at stage, to the reporter
+func (r errorReporter) report(stage string, err error) {
+	if !r.json {
+		fmt.Fprintf(r.out, "❌ %s: %v\n", stageLabels[stage], err)
+		return
+	}
+
+	// A struct of two strings always marshals
+	data, _ := json.Marshal(errorReport{Error: err.Error(), Stage: stage})
+	fmt.Fprintf(r.out, "%s\n", data)
+}
+
+// fatal reports err, which failed the run at stage, and exits non-zero
+func (r errorReporter) fatal(stage string, err error) {
+	r.report(stage, err)
+	os.Exit(1)
+}
+
 func main() {
-	printBanner()
+	opts, err := parseFlags(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	// Flags parsed before a bad one, such as -json-errors, still apply
+	reporter := errorReporter{out: os.Stderr, json: opts.jsonErrors}
+	if err != nil {
+		reporter.fatal(stageArgs, err)
+	}
 
 	// Load configuration
 	cfg := config.DefaultConfig()
+	cfg.System.Seed = opts.seed
+	cfg.System.SeedString = opts.seedString
+	cfg.System.Deterministic = opts.deterministic
+	cfg.System.ProgressInterval = opts.progress
+	cfg.System.RemoteBidAddr = opts.remoteBids
+	cfg.Report.ASCII = opts.ascii
+	cfg.Report.BidderSeeds = opts.bidderSeeds
+	if opts.ascii {
+		console = ascii.NewWriter(os.Stdout)
+	}
+	toStdout := opts.output == export.Stdout
+	if toStdout {
+		// Stdout carries only the result
+		cfg.System.Quiet = true
+	}
+
+	// Exports retry transient write failures
+	exporter := export.NewExporter(opts.output)
+	exporter.MaxAttempts = opts.exportAttempts
+	exporter.Currency = cfg.Report.Currency
+	exporter.CSV = opts.csv
+	exporter.MinifyJSON = opts.minifyJSON
+	exporter.Dedup = opts.dedup
+	exporter.Archive = opts.archive
+	exporter.ASCII = opts.ascii
+
+	// An unusable output directory falls back to a temporary one, so the
+	// results are still kept
+	if !toStdout {
+		if dir, fallback, err := exporter.PrepareOutputDir(); err != nil {
+			reporter.fatal(stageExport, err)
+		} else if fallback {
+			fmt.Fprintf(os.Stderr, "⚠️  Cannot write to %s; writing results to %s instead\n", opts.output, dir)
+		}
+	}
+
+	// Only dump the item catalog when asked to
+	if opts.itemsOnly > 0 {
+		exportItems(reporter, exporter, cfg, opts.itemsOnly)
+		return
+	}
+
+	// An interrupt closes the running auctions and abandons the export
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	if !cfg.System.Quiet {
+		printBanner()
+	}
+
+	// Auction the given items rather than generated ones
+	if opts.items != "" {
+		items, err := loadItems(opts.items, cfg.Auction.AllowUnknownCategories)
+		if err != nil {
+			reporter.fatal(stageItems, err)
+		}
+		cfg.Auction.Items = items
+		cfg.Auction.TotalAuctions = len(items)
+	}
 
 	// Validate configuration
 	if err := cfg.Validate(); err != nil {
-		log.Fatalf("❌ Invalid configuration: %v", err)
+		reporter.fatal(stageValidate, err)
 	}
 
 	// Standardize resources for consistent measurements
-	monitor.StandardizeResources(cfg.System.MaxCPUCores)
+	logger := logging.New(os.Stderr, cfg.System.LogLevel)
+	cfg.System.MaxCPUCores = monitor.StandardizeResources(logger, cfg.System.MaxCPUCores, cfg.System.GCPercent)
 
-	printConfiguration(cfg)
+	if !cfg.System.Quiet {
+		printConfiguration(cfg)
+	}
 
-	// Run the full simulation with monitoring
-	result := runFullSimulation(cfg)
+	// Run the full simulation with monitoring, after any warmup runs
+	if opts.warmup > 0 && !cfg.System.Quiet {
+		fmt.Fprintf(console, "🔥 Running %d warmup simulation(s)...\n\n", opts.warmup)
+	}
+	progress := console
+	if cfg.System.Quiet {
+		progress = io.Discard
+	}
+	analyzer := stats.NewAnalyzer()
+	analyzer.Currency = cfg.Report.Currency
+	analyzer.CloseMarginPct = cfg.Report.CloseMarginPct
+	analyzer.ASCII = cfg.Report.ASCII
+
+	if opts.compact {
+		// Compact results are too slim to export; report their statistics
+		compact := simulation.RunCompactWithWarmup(ctx, cfg, opts.warmup, progress)
+		statistics := analyzer.AnalyzeCompact(compact)
+		if !cfg.System.Quiet {
+			fmt.Fprintln(console, analyzer.FormatReport(statistics))
+		}
+		checkThresholds(reporter, opts.thresholds, statistics)
+		return
+	}
+	result := simulation.RunWithWarmup(ctx, cfg, opts.warmup, progress)
 
 	// Analyze results
-	analyzer := stats.NewAnalyzer()
 	statistics := analyzer.Analyze(result)
 
+	// Record the run in the sweep log
+	if opts.summaryLog != "" {
+		if err := stats.AppendSummaryLine(opts.summaryLog, stats.SummaryLine(cfg, statistics, result)); err != nil {
+			reporter.fatal(stageExport, err)
+		}
+	}
+
+	if toStdout {
+		if err := exporter.WriteResult(os.Stdout, opts.format, result); err != nil {
+			reporter.fatal(stageExport, err)
+		}
+		checkThresholds(reporter, opts.thresholds, statistics)
+		return
+	}
+
+	if cfg.System.Quiet {
+		// Export results without any console output
+		if err := exportResults(ctx, io.Discard, exporter, cfg, result, statistics, analyzer.FormatReport(statistics), opts.keepOutputs); err != nil {
+			reporter.fatal(stageExport, err)
+		}
+		checkThresholds(reporter, opts.thresholds, statistics)
+		return
+	}
+
 	// Display results
-	displayResults(result)
+	displayResults(result, cfg.Report.Currency)
 
 	// Display statistics
-	fmt.Println(analyzer.FormatReport(statistics))
+	fmt.Fprintln(console, analyzer.FormatReport(statistics))
 
 	// Display resource usage
 	displayResourceUsage(result)
 
 	// Export results
-	exportResults(result, analyzer.FormatReport(statistics))
+	if err := exportResults(ctx, console, exporter, cfg, result, statistics, analyzer.FormatReport(statistics), opts.keepOutputs); err != nil {
+		reporter.fatal(stageExport, err)
+	}
 
 	// Final summary
-	printFinalSummary(result, statistics)
+	printFinalSummary(result, statistics, exporter.OutputDir())
+
+	checkThresholds(reporter, opts.thresholds, statistics)
+}
+
+// checkThresholds exits non-zero, naming each failed threshold, if the
+// statistics fall below the configured minimums
+func checkThresholds(reporter errorReporter, thresholds stats.Thresholds, statistics stats.Statistics) {
+	if err := thresholds.Check(statistics); err != nil {
+		reporter.fatal(stageThresholds, err)
+	}
 }
 
 // printBanner displays the application banner
@@ -69,201 +366,129 @@ func printBanner() {
 ║                                                           ║
 ╚═══════════════════════════════════════════════════════════╝
 `
-	fmt.Println(banner)
-}
-
-// runFullSimulation orchestrates the entire auction simulation with monitoring
-func runFullSimulation(cfg *config.Config) models.SimulationResult {
-	fmt.Println("🎬 Starting Simulation")
-	fmt.Println("════════════════════════════════════════════════════════")
-	
-	// Start resource monitoring
-	resourceMonitor := monitor.NewResourceMonitor(500 * time.Millisecond)
-	resourceMonitor.Start()
-	
-	ctx := context.Background()
-	
-	// Create manager and bidder pool
-	manager := auction.NewManager(cfg)
-	bidderPool := bidder.NewPool(&cfg.Bidder)
-	
-	// Pre-create all auctions
-	items := manager.Generator.GenerateItems(cfg.Auction.TotalAuctions)
-	for i, item := range items {
-		auc := auction.NewAuction(i+1, item, cfg.Auction.AuctionTimeout)
-		manager.Auctions = append(manager.Auctions, auc)
-	}
-	
-	fmt.Printf("📦 Pre-generated %d auctions\n", len(manager.Auctions))
-	
-	var wg sync.WaitGroup
-	
-	// Record start time
-	manager.StartTime = time.Now()
-	fmt.Printf("⏱️  Start Time: %s\n\n", manager.StartTime.Format("15:04:05.000"))
-	
-	// Start all auctions
-	fmt.Println("🔨 Starting all auctions...")
-	for _, auc := range manager.Auctions {
-		wg.Add(1)
-		go func(auction *auction.Auction) {
-			defer wg.Done()
-			result := auction.Run(ctx)
-			
-			manager.Mu.Lock()
-			manager.Results = append(manager.Results, result)
-			manager.Mu.Unlock()
-		}(auc)
-	}
-	
-	// Small delay to ensure auctions are running
-	time.Sleep(50 * time.Millisecond)
-	
-	// Activate bidders
-	fmt.Println("👥 Activating bidders...")
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		bidderPool.ParticipateInAllAuctions(ctx, manager.Auctions)
-	}()
-	
-	// Wait for completion
-	fmt.Println("⏳ Waiting for completion...")
-	wg.Wait()
-	
-	manager.EndTime = time.Now()
-	fmt.Printf("\n⏱️  End Time: %s\n", manager.EndTime.Format("15:04:05.000"))
-	
-	// Stop monitoring ONCE 
-	resourceMonitor.Stop()
-	resourceStats := resourceMonitor.GetStats()
-	
-	fmt.Println("\n✅ Simulation Complete!")
-	
-	// Build result with resource metrics
-	result := manager.AggregateResults()
-	result.CPUCount = resourceStats.NumCPU
-	result.CPUUsed = resourceStats.GOMAXPROCS
-	result.InitialMemoryMB = resourceStats.InitialMemoryMB
-	result.FinalMemoryMB = resourceStats.FinalMemoryMB
-	result.PeakMemoryMB = resourceStats.PeakMemoryMB
-	result.AverageMemoryMB = resourceStats.AverageMemoryMB
-	result.PeakGoroutines = resourceStats.PeakGoroutines
-	
-	return result
+	fmt.Fprintln(console, banner)
 }
 
 // printConfiguration displays the simulation configuration
 func printConfiguration(cfg *config.Config) {
-	fmt.Printf("📊 Configuration\n")
-	fmt.Printf("════════════════════════════════════════════════════════\n")
-	fmt.Printf("  Concurrent Auctions:    %d\n", cfg.Auction.TotalAuctions)
-	fmt.Printf("  Total Bidders:          %d\n", cfg.Bidder.TotalBidders)
-	fmt.Printf("  Auction Timeout:        %v\n", cfg.Auction.AuctionTimeout)
-	fmt.Printf("  Bid Probability:        %.1f%%\n", cfg.Bidder.BidProbability*100)
-	fmt.Printf("  CPU Cores Available:    %d\n", runtime.NumCPU())
-	fmt.Printf("  CPU Cores Used:         %d\n", cfg.System.MaxCPUCores)
-	fmt.Printf("  Expected Goroutines:    ~%d\n",
+	fmt.Fprintf(console, "📊 Configuration\n")
+	fmt.Fprintf(console, "════════════════════════════════════════════════════════\n")
+	fmt.Fprintf(console, "  Concurrent Auctions:    %d\n", cfg.Auction.TotalAuctions)
+	fmt.Fprintf(console, "  Total Bidders:          %d\n", cfg.Bidder.TotalBidders)
+	fmt.Fprintf(console, "  Auction Timeout:        %v\n", cfg.Auction.AuctionTimeout)
+	fmt.Fprintf(console, "  Bid Probability:        %.1f%%\n", cfg.Bidder.BidProbability*100)
+	fmt.Fprintf(console, "  CPU Cores Available:    %d\n", monitor.EffectiveCPUs())
+	fmt.Fprintf(console, "  CPU Cores Used:         %d\n", cfg.System.MaxCPUCores)
+	fmt.Fprintf(console, "  Expected Goroutines:    ~%d\n",
 		cfg.Auction.TotalAuctions+(cfg.Bidder.TotalBidders*cfg.Auction.TotalAuctions))
-	fmt.Println()
+	fmt.Fprintln(console)
 }
 
 // displayResults shows comprehensive simulation results
-func displayResults(result models.SimulationResult) {
-	fmt.Println("\n" + strings.Repeat("═", 60))
-	fmt.Println("📊 SIMULATION RESULTS")
-	fmt.Println(strings.Repeat("═", 60))
+func displayResults(result models.SimulationResult, currency config.Currency) {
+	fmt.Fprintln(console, "\n"+strings.Repeat("═", 60))
+	fmt.Fprintln(console, "📊 SIMULATION RESULTS")
+	fmt.Fprintln(console, strings.Repeat("═", 60))
 
 	// Timing
-	fmt.Printf("\n⏱️  Timing:\n")
-	fmt.Printf("   ├─ Start:      %s\n", result.StartTime.Format("15:04:05.000"))
-	fmt.Printf("   ├─ End:        %s\n", result.EndTime.Format("15:04:05.000"))
-	fmt.Printf("   └─ Duration:   %v\n", result.TotalDuration)
+	fmt.Fprintf(console, "\n⏱️  Timing:\n")
+	fmt.Fprintf(console, "   ├─ Start:      %s\n", result.StartTime.Format("15:04:05.000"))
+	fmt.Fprintf(console, "   ├─ End:        %s\n", result.EndTime.Format("15:04:05.000"))
+	fmt.Fprintf(console, "   └─ Duration:   %v\n", result.TotalDuration)
 
 	// Auction Summary
-	fmt.Printf("\n🔨 Auction Summary:\n")
-	fmt.Printf("   ├─ Total:      %d\n", result.TotalAuctions)
-	fmt.Printf("   ├─ Successful: %d (%.1f%%)\n",
+	fmt.Fprintf(console, "\n🔨 Auction Summary:\n")
+	if result.TotalAuctions == 0 {
+		fmt.Fprintf(console, "   └─ No auctions\n")
+		return
+	}
+	fmt.Fprintf(console, "   ├─ Total:      %d\n", result.TotalAuctions)
+	fmt.Fprintf(console, "   ├─ Successful: %d (%.1f%%)\n",
 		result.SuccessfulAuctions,
-		float64(result.SuccessfulAuctions)/float64(result.TotalAuctions)*100)
-	fmt.Printf("   └─ Failed:     %d\n", result.FailedAuctions)
+		ratio(float64(result.SuccessfulAuctions), float64(result.TotalAuctions))*100)
+	fmt.Fprintf(console, "   └─ Failed:     %d\n", result.FailedAuctions)
 
 	// Bidding Activity
-	fmt.Printf("\n💰 Bidding Activity:\n")
-	fmt.Printf("   ├─ Total Bids:       %d\n", result.TotalBids)
-	fmt.Printf("   └─ Avg per Auction:  %.1f\n",
-		float64(result.TotalBids)/float64(result.TotalAuctions))
+	fmt.Fprintf(console, "\n💰 Bidding Activity:\n")
+	fmt.Fprintf(console, "   ├─ Total Bids:       %d\n", result.TotalBids)
+	fmt.Fprintf(console, "   └─ Avg per Auction:  %.1f\n",
+		ratio(float64(result.TotalBids), float64(result.TotalAuctions)))
 
 	// Top auctions
-	fmt.Printf("\n🏆 Top 5 Most Popular Auctions:\n")
-	displayTopAuctions(result.AuctionResults, 5)
+	fmt.Fprintf(console, "\n🏆 Top %d Most Popular Auctions:\n", leaderboardSize)
+	displayTopAuctions(result.AuctionResults, leaderboardSize, currency)
 
 	// Winners
-	fmt.Printf("\n🎉 Winners:\n")
-	displayWinnersSummary(result.AuctionResults)
+	fmt.Fprintf(console, "\n🎉 Winners:\n")
+	displayWinnersSummary(result.AuctionResults, currency)
 }
 
 // displayResourceUsage shows resource utilization
 func displayResourceUsage(result models.SimulationResult) {
-	fmt.Println("\n" + strings.Repeat("═", 60))
-	fmt.Println("💻 RESOURCE UTILIZATION")
-	fmt.Println(strings.Repeat("═", 60))
-
-	fmt.Printf("\n🧠 Memory:\n")
-	fmt.Printf("   ├─ Initial:        %.2f MB\n", result.InitialMemoryMB)
-	fmt.Printf("   ├─ Final:          %.2f MB\n", result.FinalMemoryMB)
-	fmt.Printf("   ├─ Peak:           %.2f MB\n", result.PeakMemoryMB)
-	fmt.Printf("   ├─ Average:        %.2f MB\n", result.AverageMemoryMB)
-	fmt.Printf("   └─ Delta:          %+.2f MB\n", result.FinalMemoryMB-result.InitialMemoryMB)
-
-	fmt.Printf("\n⚙️  CPU & Concurrency:\n")
-	fmt.Printf("   ├─ CPUs Available:     %d\n", result.CPUCount)
-	fmt.Printf("   ├─ CPUs Used:          %d (%.1f%%)\n",
+	fmt.Fprintln(console, "\n"+strings.Repeat("═", 60))
+	fmt.Fprintln(console, "💻 RESOURCE UTILIZATION")
+	fmt.Fprintln(console, strings.Repeat("═", 60))
+
+	fmt.Fprintf(console, "\n🧠 Memory:\n")
+	fmt.Fprintf(console, "   ├─ Initial:        %.2f MB\n", result.InitialMemoryMB)
+	fmt.Fprintf(console, "   ├─ Final:          %.2f MB\n", result.FinalMemoryMB)
+	fmt.Fprintf(console, "   ├─ Peak:           %.2f MB\n", result.PeakMemoryMB)
+	fmt.Fprintf(console, "   ├─ Average:        %.2f MB\n", result.AverageMemoryMB)
+	fmt.Fprintf(console, "   └─ Delta:          %+.2f MB\n", result.FinalMemoryMB-result.InitialMemoryMB)
+
+	fmt.Fprintf(console, "\n🗑️  Garbage Collection:\n")
+	fmt.Fprintf(console, "   ├─ Cycles:         %d\n", result.NumGC)
+	fmt.Fprintf(console, "   ├─ Total Pause:    %v\n", result.TotalGCPause)
+	fmt.Fprintf(console, "   └─ Last Pause:     %v\n", result.LastGCPause)
+
+	fmt.Fprintf(console, "\n⚙️  CPU & Concurrency:\n")
+	fmt.Fprintf(console, "   ├─ CPUs Available:     %d\n", result.CPUCount)
+	fmt.Fprintf(console, "   ├─ CPUs Used:          %d (%.1f%%)\n",
 		result.CPUUsed,
-		float64(result.CPUUsed)/float64(result.CPUCount)*100)
-	fmt.Printf("   └─ Peak Goroutines:    %d\n", result.PeakGoroutines)
+		ratio(float64(result.CPUUsed), float64(result.CPUCount))*100)
+	fmt.Fprintf(console, "   ├─ Peak Auctions:      %d of %d at once\n", result.PeakConcurrentAuctions, result.TotalAuctions)
+	fmt.Fprintf(console, "   └─ Peak Goroutines:    %d\n", result.PeakGoroutines)
 
-	fmt.Printf("\n📊 Efficiency:\n")
-	memPerGoroutine := result.PeakMemoryMB / float64(result.PeakGoroutines)
-	fmt.Printf("   ├─ Memory/Goroutine:   %.3f MB\n", memPerGoroutine)
+	fmt.Fprintf(console, "\n📊 Efficiency:\n")
+	memPerGoroutine := ratio(result.PeakMemoryMB, float64(result.PeakGoroutines))
+	fmt.Fprintf(console, "   ├─ Memory/Goroutine:   %.3f MB\n", memPerGoroutine)
 
-	bidsPerSecond := float64(result.TotalBids) / result.TotalDuration.Seconds()
-	fmt.Printf("   ├─ Bids/Second:        %.1f\n", bidsPerSecond)
+	bidsPerSecond := ratio(float64(result.TotalBids), result.TotalDuration.Seconds())
+	fmt.Fprintf(console, "   ├─ Bids/Second:        %.1f\n", bidsPerSecond)
 
-	auctionsPerSecond := float64(result.TotalAuctions) / result.TotalDuration.Seconds()
-	fmt.Printf("   └─ Auctions/Second:    %.2f\n", auctionsPerSecond)
+	auctionsPerSecond := ratio(float64(result.TotalAuctions), result.TotalDuration.Seconds())
+	fmt.Fprintf(console, "   └─ Auctions/Second:    %.2f\n", auctionsPerSecond)
 }
 
-// displayTopAuctions shows the most popular auctions
-func displayTopAuctions(results []models.AuctionResult, topN int) {
-	// Sort by bid count
-	sorted := make([]models.AuctionResult, len(results))
-	copy(sorted, results)
-
-	for i := 0; i < len(sorted)-1; i++ {
-		for j := 0; j < len(sorted)-i-1; j++ {
-			if sorted[j].TotalBids < sorted[j+1].TotalBids {
-				sorted[j], sorted[j+1] = sorted[j+1], sorted[j]
-			}
-		}
+// ratio returns a/b, or 0 when b is not positive, so empty or instant runs
+// display zeros rather than NaN or infinity
+func ratio(a, b float64) float64 {
+	if b <= 0 {
+		return 0
 	}
+	return a / b
+}
 
-	// Display top N
-	for i := 0; i < topN && i < len(sorted); i++ {
-		result := sorted[i]
+// leaderboardSize is how many auctions the console and export leaderboards
+// list
+const leaderboardSize = 5
+
+// displayTopAuctions shows the most popular auctions
+func displayTopAuctions(results []models.AuctionResult, topN int, currency config.Currency) {
+	for i, result := range stats.TopAuctionsByBids(results, topN) {
 		winnerInfo := "No winner"
 		if result.WinningBid != nil {
-			winnerInfo = fmt.Sprintf("Bidder #%d - $%.2f",
-				result.WinningBid.BidderID, result.WinningBid.Amount)
+			winnerInfo = fmt.Sprintf("Bidder #%d - %s",
+				result.WinningBid.BidderID, currency.Format(result.WinningBid.Amount))
 		}
 
-		fmt.Printf("   %d. Auction #%-3d: %3d bids → %s\n",
+		fmt.Fprintf(console, "   %d. Auction #%-3d: %3d bids → %s\n",
 			i+1, result.AuctionID, result.TotalBids, winnerInfo)
 	}
 }
 
 // displayWinnersSummary shows statistics about winners
-func displayWinnersSummary(results []models.AuctionResult) {
+func displayWinnersSummary(results []models.AuctionResult, currency config.Currency) {
 	winnerMap := make(map[int]int)
 	totalRevenue := 0.0
 
@@ -274,12 +499,12 @@ func displayWinnersSummary(results []models.AuctionResult) {
 		}
 	}
 
-	fmt.Printf("   ├─ Unique Winners:  %d\n", len(winnerMap))
-	fmt.Printf("   ├─ Total Revenue:   $%.2f\n", totalRevenue)
+	fmt.Fprintf(console, "   ├─ Unique Winners:  %d\n", len(winnerMap))
+	fmt.Fprintf(console, "   ├─ Total Revenue:   %s\n", currency.Format(totalRevenue))
 
 	if len(winnerMap) > 0 {
 		avgWin := totalRevenue / float64(len(winnerMap))
-		fmt.Printf("   └─ Avg Win Amount:  $%.2f\n", avgWin)
+		fmt.Fprintf(console, "   └─ Avg Win Amount:  %s\n", currency.Format(avgWin))
 
 		// Find top winner
 		maxWins := 0
@@ -292,61 +517,124 @@ func displayWinnersSummary(results []models.AuctionResult) {
 		}
 
 		if maxWins > 1 {
-			fmt.Printf("\n   🌟 Top Winner: Bidder #%d (%d auctions won)\n",
+			fmt.Fprintf(console, "\n   🌟 Top Winner: Bidder #%d (%d auctions won)\n",
 				topBidder, maxWins)
 		}
 	}
 }
 
-// exportResults exports simulation results to files
-func exportResults(result models.SimulationResult, statsReport string) {
-	fmt.Println("\n💾 Exporting Results")
-	fmt.Println("════════════════════════════════════════════════════════")
+// exportResults exports simulation results to files, until ctx ends, and
+// reports each file on out
+// Once the manifest is written, output sets older than the keepOutputs most
+// recent are removed. Returns the failed exports and any rotation failure.
+func exportResults(ctx context.Context, out io.Writer, exporter *export.Exporter, cfg *config.Config, result models.SimulationResult, statistics stats.Statistics, statsReport string, keepOutputs int) error {
+	fmt.Fprintln(out, "\n💾 Exporting Results")
+	fmt.Fprintln(out, "════════════════════════════════════════════════════════")
+
+	summary := exporter.ExportAllContext(ctx, cfg, result, statistics, statsReport, leaderboardSize)
+	for _, file := range summary.Files {
+		label := exportLabels[file.Format]
+		if file.Err != nil {
+			fmt.Fprintf(out, "   ✗ %s export failed: %v\n", label, file.Err)
+		} else {
+			fmt.Fprintf(out, "   ✓ %s exported: %s\n", label, file.Path)
+		}
+	}
+
+	var errs []error
+	for _, file := range summary.Failed() {
+		errs = append(errs, fmt.Errorf("%s export: %w", exportLabels[file.Format], file.Err))
+	}
+	if _, ok := summary.Path("manifest"); ok {
+		if err := rotateOutputs(out, exporter, keepOutputs); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
 
-	exporter := export.NewExporter("./output")
+// exportLabels names each export format on the console
+var exportLabels = map[string]string{
+	"json":                  "JSON",
+	"csv":                   "CSV",
+	"summary":               "Summary",
+	"resources":             "Resources",
+	"histogram_bids":        "Bid histogram",
+	"histogram_win_amounts": "Win amount histogram",
+	"top_auctions":          "Top auctions",
+	"bottom_auctions":       "Bottom auctions",
+	"bidder_stats":          "Bidder stats",
+	"archive":               "Archive",
+	"config":                "Config",
+	"manifest":              "Manifest",
+}
 
-	// Export JSON
-	if jsonFile, err := exporter.ExportToJSON(result); err != nil {
-		fmt.Printf("   ✗ JSON export failed: %v\n", err)
-	} else {
-		fmt.Printf("   ✓ JSON exported: %s\n", jsonFile)
+// rotateOutputs keeps the keep most recent output sets (0 = all)
+func rotateOutputs(out io.Writer, exporter *export.Exporter, keep int) error {
+	if keep == 0 {
+		return nil
+	}
+	if err := exporter.Rotate(keep); err != nil {
+		fmt.Fprintf(out, "   ✗ Output rotation failed: %v\n", err)
+		return fmt.Errorf("output rotation: %w", err)
 	}
+	fmt.Fprintf(out, "   ✓ Kept the %d most recent output set(s)\n", keep)
+	return nil
+}
 
-	// Export CSV
-	if csvFile, err := exporter.ExportToCSV(result); err != nil {
-		fmt.Printf("   ✗ CSV export failed: %v\n", err)
-	} else {
-		fmt.Printf("   ✓ CSV exported: %s\n", csvFile)
+// exportItems generates n items and exports them without running auctions
+// The items are those a run of cfg with the same seed would auction; the
+// seed used is printed.
+func exportItems(reporter errorReporter, exporter *export.Exporter, cfg *config.Config, n int) {
+	seed := cfg.System.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	generator := auction.NewItemGeneratorWithSeed(seed)
+	generator.HotFraction = cfg.Auction.HotItemFraction
+	generator.CategoryWeights = cfg.Auction.CategoryWeights
+	items := generator.GenerateItems(n)
+	fmt.Fprintf(console, "🎲 Seed: %d\n", seed)
+
+	files, err := exporter.ExportItems(items)
+	if err != nil {
+		reporter.fatal(stageExport, fmt.Errorf("item export: %w", err))
 	}
 
-	// Export Summary
-	if summaryFile, err := exporter.ExportSummary(result, statsReport); err != nil {
-		fmt.Printf("   ✗ Summary export failed: %v\n", err)
-	} else {
-		fmt.Printf("   ✓ Summary exported: %s\n", summaryFile)
+	fmt.Fprintf(console, "📦 Generated %d items\n", len(items))
+	for _, file := range files {
+		fmt.Fprintf(console, "   ✓ Items exported: %s\n", file)
 	}
+}
 
-	// Export Resource Metrics
-	if resourceFile, err := exporter.ExportResourceMetrics(result); err != nil {
-		fmt.Printf("   ✗ Resource export failed: %v\n", err)
-	} else {
-		fmt.Printf("   ✓ Resources exported: %s\n", resourceFile)
+// loadItems reads the items to auction from the JSON file at path (see
+// auction.LoadItems)
+func loadItems(path string, allowUnknownCategories bool) ([]models.AuctionItem, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open items: %w", err)
 	}
+	defer file.Close()
+	return auction.LoadItems(file, allowUnknownCategories)
 }
 
-// printFinalSummary displays final performance summary
-func printFinalSummary(result models.SimulationResult, stats stats.Statistics) {
-	fmt.Println("\n" + strings.Repeat("═", 60))
-	fmt.Println("✨ FINAL SUMMARY")
-	fmt.Println(strings.Repeat("═", 60))
-
-	fmt.Printf("\n⚡ Performance:\n")
-	fmt.Printf("   ├─ Total Time:           %v\n", result.TotalDuration)
-	fmt.Printf("   ├─ Bids/Second:          %.1f\n", stats.BidsPerSecond)
-	fmt.Printf("   ├─ Success Rate:         %.1f%%\n", stats.SuccessRate)
-	fmt.Printf("   ├─ Peak Memory:          %.2f MB\n", result.PeakMemoryMB)
-	fmt.Printf("   └─ Peak Goroutines:      %d\n", result.PeakGoroutines)
-
-	fmt.Printf("\n✅ Simulation completed successfully!\n")
-	fmt.Printf("📁 Results saved to ./output directory\n\n")
+// printFinalSummary displays final performance summary, pointing to the
+// outputDir results were exported to (export.Stdout = none)
+func printFinalSummary(result models.SimulationResult, stats stats.Statistics, outputDir string) {
+	fmt.Fprintln(console, "\n"+strings.Repeat("═", 60))
+	fmt.Fprintln(console, "✨ FINAL SUMMARY")
+	fmt.Fprintln(console, strings.Repeat("═", 60))
+
+	fmt.Fprintf(console, "\n⚡ Performance:\n")
+	fmt.Fprintf(console, "   ├─ Total Time:           %v\n", result.TotalDuration)
+	fmt.Fprintf(console, "   ├─ Bids/Second:          %.1f\n", stats.BidsPerSecond)
+	fmt.Fprintf(console, "   ├─ Success Rate:         %.1f%%\n", stats.SuccessRate)
+	fmt.Fprintf(console, "   ├─ Peak Memory:          %.2f MB\n", result.PeakMemoryMB)
+	fmt.Fprintf(console, "   └─ Peak Goroutines:      %d\n", result.PeakGoroutines)
+
+	fmt.Fprintf(console, "\n✅ Simulation completed successfully!\n")
+	if outputDir != export.Stdout {
+		fmt.Fprintf(console, "📁 Results saved to %s\n", outputDir)
+	}
+	fmt.Fprintln(console)
 }
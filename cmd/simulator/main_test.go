@@ -0,0 +1,249 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vineetjain1712/auction-simulator/config"
+	"github.com/vineetjain1712/auction-simulator/internal/auction"
+	"github.com/vineetjain1712/auction-simulator/internal/export"
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+	"github.com/vineetjain1712/auction-simulator/internal/stats"
+)
+
+func TestErrorReporterJSON(t *testing.T) {
+	var out bytes.Buffer
+	reporter := errorReporter{out: &out, json: true}
+
+	reporter.report(stageValidate, errors.New(`total auctions must be "positive"`))
+
+	var decoded map[string]string
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("Expected a JSON error report, got %q: %v", out.String(), err)
+	}
+	expected := map[string]string{"error": `total auctions must be "positive"`, "stage": "validate"}
+	if len(decoded) != len(expected) || decoded["error"] != expected["error"] || decoded["stage"] != expected["stage"] {
+		t.Errorf("Expected %v, got %v", expected, decoded)
+	}
+	if !strings.HasSuffix(out.String(), "}\n") || strings.Count(out.String(), "\n") != 1 {
+		t.Errorf("Expected a single JSON line, got %q", out.String())
+	}
+}
+
+func TestErrorReporterText(t *testing.T) {
+	var out bytes.Buffer
+	errorReporter{out: &out}.report(stageValidate, errors.New("total auctions must be positive"))
+
+	if got := out.String(); got != "❌ Invalid configuration: total auctions must be positive\n" {
+		t.Errorf("Unexpected text report %q", got)
+	}
+}
+
+func TestParseFlagsKeepsJSONErrorsOnBadFlag(t *testing.T) {
+	opts, err := parseFlags([]string{"-json-errors", "-warmup", "-1"})
+	if err == nil {
+		t.Fatal("Expected a negative warmup to be rejected")
+	}
+	if !opts.jsonErrors {
+		t.Error("Expected -json-errors to apply to the argument error")
+	}
+}
+
+func TestParseFlagsStdoutFormat(t *testing.T) {
+	opts, err := parseFlags([]string{"-output", "-", "-format", "csv"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.output != export.Stdout || opts.format != export.FormatCSV {
+		t.Errorf("Expected CSV on stdout, got output %q and format %q", opts.output, opts.format)
+	}
+
+	if _, err := parseFlags([]string{"-output", "-", "-format", "xml"}); err == nil {
+		t.Error("Expected an unknown format to be rejected")
+	}
+}
+
+func TestParseFlagsSeedString(t *testing.T) {
+	opts, err := parseFlags([]string{"-seed-string", "experiment-42"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.seed != config.SeedFromString("experiment-42") {
+		t.Errorf("Expected the seed derived from the label, got %d", opts.seed)
+	}
+
+	if _, err := parseFlags([]string{"-seed", "7", "-seed-string", "experiment-42"}); err == nil {
+		t.Error("Expected -seed and -seed-string together to be rejected")
+	}
+}
+
+func TestParseFlagsCompact(t *testing.T) {
+	opts, err := parseFlags([]string{"-compact"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !opts.compact {
+		t.Error("Expected -compact to be set")
+	}
+
+	if _, err := parseFlags([]string{"-compact", "-output", "-"}); err == nil {
+		t.Error("Expected -compact with the result on stdout to be rejected")
+	}
+	if _, err := parseFlags([]string{"-compact", "-summary-log", "sweep.tsv"}); err == nil {
+		t.Error("Expected -compact with -summary-log to be rejected")
+	}
+}
+
+func TestLoadExportedItems(t *testing.T) {
+	opts, err := parseFlags([]string{"-items", "items.json"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.items != "items.json" {
+		t.Errorf("Expected -items to be set, got %q", opts.items)
+	}
+	if _, err := parseFlags([]string{"-items", "items.json", "-items-only", "5"}); err == nil {
+		t.Error("Expected -items with -items-only to be rejected")
+	}
+
+	// An exported item catalog loads back as the items to auction
+	items := []models.AuctionItem{
+		{ID: 1, Name: "Lamp", Category: models.CategoryFurniture, BasePrice: 50},
+		{ID: 2, Name: "Ring", Category: models.CategoryJewelry, BasePrice: 900},
+	}
+	files, err := export.NewExporter(t.TempDir()).ExportItems(items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := loadItems(files[0], false)
+	if err != nil {
+		t.Fatalf("Expected the exported items to load, got %v", err)
+	}
+	if len(loaded) != len(items) || loaded[1].Name != "Ring" || loaded[1].Fingerprint == "" {
+		t.Errorf("Unexpected items loaded: %+v", loaded)
+	}
+
+	if _, err := loadItems(filepath.Join(t.TempDir(), "missing.json"), false); err == nil {
+		t.Error("Expected a missing item file to fail")
+	}
+}
+
+func TestExportResultsRecordsSeed(t *testing.T) {
+	dir := t.TempDir()
+	cfg := config.DefaultConfig() // Time-based: Seed 0
+	result := models.SimulationResult{Seed: 123456789, TotalDuration: time.Second}
+
+	if err := exportResults(context.Background(), io.Discard, export.NewExporter(dir), cfg, result, stats.Statistics{}, "", 0); err != nil {
+		t.Fatal(err)
+	}
+
+	read := func(pattern string) []byte {
+		matches, _ := filepath.Glob(filepath.Join(dir, pattern))
+		if len(matches) != 1 {
+			t.Fatalf("Expected one %s file, got %v", pattern, matches)
+		}
+		data, err := os.ReadFile(matches[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		return data
+	}
+
+	var exported config.Config
+	if err := json.Unmarshal(read("config_*.json"), &exported); err != nil {
+		t.Fatal(err)
+	}
+	if exported.System.Seed != result.Seed {
+		t.Errorf("Expected the exported config to carry seed %d, got %d", result.Seed, exported.System.Seed)
+	}
+
+	var manifest export.Manifest
+	if err := json.Unmarshal(read("manifest_*.json"), &manifest); err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Seed != result.Seed {
+		t.Errorf("Expected the manifest to carry seed %d, got %d", result.Seed, manifest.Seed)
+	}
+	if cfg.System.Seed != 0 {
+		t.Error("Expected the run's configuration to be left unchanged")
+	}
+}
+
+func TestDisplayEmptyResult(t *testing.T) {
+	var out bytes.Buffer
+	console = &out
+	defer func() { console = os.Stdout }()
+
+	displayResults(models.SimulationResult{}, config.DefaultCurrency)
+	displayResourceUsage(models.SimulationResult{})
+
+	if got := out.String(); !strings.Contains(got, "No auctions") || strings.Contains(got, "NaN") || strings.Contains(got, "Inf") {
+		t.Errorf("Expected a no-auctions display without NaN or infinity, got:\n%s", got)
+	}
+}
+
+func TestExportResultsReportsFailures(t *testing.T) {
+	// A file where the output directory should be makes every write fail
+	dir := filepath.Join(t.TempDir(), "blocked")
+	if err := os.WriteFile(dir, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	err := exportResults(context.Background(), io.Discard, export.NewExporter(dir), config.DefaultConfig(), models.SimulationResult{}, stats.Statistics{}, "", 0)
+	if err == nil || !strings.Contains(err.Error(), "JSON export") {
+		t.Errorf("Expected the failed exports to be returned, got %v", err)
+	}
+}
+
+func TestExportItemsUsesSeed(t *testing.T) {
+	console = io.Discard
+	defer func() { console = os.Stdout }()
+
+	cfg := config.DefaultConfig()
+	cfg.System.Seed = 42
+	dir := t.TempDir()
+	exportItems(errorReporter{out: io.Discard}, export.NewExporter(dir), cfg, 5)
+
+	matches, err := filepath.Glob(filepath.Join(dir, "items_*.json"))
+	if err != nil || len(matches) != 1 {
+		t.Fatalf("Expected one item export, got %v (%v)", matches, err)
+	}
+	loaded, err := loadItems(matches[0], false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := auction.NewItemGeneratorWithSeed(42).GenerateItems(5)
+	if len(loaded) != len(want) {
+		t.Fatalf("Expected %d items, got %d", len(want), len(loaded))
+	}
+	for i := range want {
+		if loaded[i].Name != want[i].Name || loaded[i].BasePrice != want[i].BasePrice {
+			t.Errorf("Item %d: expected the seeded run's %+v, got %+v", i, want[i], loaded[i])
+		}
+	}
+}
+
+func TestFinalSummaryNamesOutputDir(t *testing.T) {
+	var out bytes.Buffer
+	console = &out
+	defer func() { console = os.Stdout }()
+
+	printFinalSummary(models.SimulationResult{}, stats.Statistics{}, "/tmp/runs")
+	if !strings.Contains(out.String(), "Results saved to /tmp/runs") {
+		t.Errorf("Expected the output directory named, got:\n%s", out.String())
+	}
+
+	out.Reset()
+	printFinalSummary(models.SimulationResult{}, stats.Statistics{}, export.Stdout)
+	if strings.Contains(out.String(), "Results saved") {
+		t.Errorf("Expected no output directory for stdout, got:\n%s", out.String())
+	}
+}
@@ -3,7 +3,12 @@ package config
 
 import (
 	"fmt"
+	"hash/fnv"
+	"math"
+	"strconv"
 	"time"
+
+	"github.com/vineetjain1712/auction-simulator/internal/models"
 )
 
 // Config holds all simulation configuration
@@ -11,15 +16,87 @@ type Config struct {
 	Auction AuctionConfig
 	Bidder  BidderConfig
 	System  SystemConfig
+	Report  ReportConfig
 }
 
 // AuctionConfig holds auction-specific settings
 type AuctionConfig struct {
-	TotalAuctions       int           // Number of concurrent auctions (40)
-	AuctionTimeout      time.Duration // How long each auction runs
-	MinimumBidIncrement float64       // Minimum bid increase
+	TotalAuctions       int            // Number of concurrent auctions (40)
+	AuctionTimeout      time.Duration  // How long each auction runs
+	MinimumBidIncrement float64        // Minimum bid increase
+	TieBreakByReceipt   bool           // Break equal-amount ties by receive time instead of bid timestamp
+	CollectionMode      CollectionMode // How auctions collect bids ("" = channel)
+	Rounds              int            // Rounds per auction with CollectInRounds
+	Type                AuctionType    // How auctions allocate their item ("" = winner takes all)
+	BidBufferSize       int            // Bid channel buffer per auction (0 = derived from TotalBidders)
+	ExcludeLateBids     bool           // Ignore bids placed or received after the deadline when picking the winner
+	DedupBids           bool           // Drop exact-duplicate bids (same bidder, amount and timestamp), counting them instead
+	ReserveMultiplier   float64        // Reserve price = BasePrice * multiplier (0 = no reserve)
+	PublicReserve       bool           // Bidders know the reserve and never bid below it; otherwise it is hidden
+	HotItemFraction     float64        // Fraction of generated items designated hot (0 = none)
+	BuyNowMultiplier    float64        // Buy-now price = BasePrice * multiplier; reaching it closes the auction (0 = none)
+	MinDuration         time.Duration  // Auctions stay open at least this long, even after a buy-now bid
+
+	AllowUnknownCategories bool // Accept loaded items and category weights whose category is not a known models.Category
+
+	// CategoryTimeouts overrides AuctionTimeout for items of a category,
+	// keyed by category name, e.g. "Art"
+	CategoryTimeouts map[string]time.Duration
+
+	// CategoryWeights sets the category mix of generated items, keyed by
+	// category name, e.g. {"Electronics": 0.5, "Art": 0.3, ...}; weights
+	// are normalized, and unlisted categories are not generated (nil =
+	// uniform)
+	CategoryWeights map[string]float64
+
+	// Items, if set, are auctioned instead of generated items, one auction
+	// each, e.g. a catalog read with auction.LoadItems; TotalAuctions must
+	// match their count
+	Items []models.AuctionItem `json:",omitempty"`
+}
+
+// TimeoutFor returns how long an auction of an item in category runs: its
+// CategoryTimeouts override, or AuctionTimeout
+func (c *AuctionConfig) TimeoutFor(category string) time.Duration {
+	if timeout, ok := c.CategoryTimeouts[category]; ok {
+		return timeout
+	}
+	return c.AuctionTimeout
 }
 
+// CollectionMode selects how an auction collects bids from bidders
+type CollectionMode string
+
+const (
+	// CollectViaChannel sends bids over a per-auction channel drained by the auction goroutine
+	CollectViaChannel CollectionMode = "channel"
+	// CollectViaMutex has bidders append directly to the auction's bids under its mutex
+	CollectViaMutex CollectionMode = "mutex"
+	// CollectInRounds splits the auction's timeout into AuctionConfig.Rounds
+	// equal rounds, taking at most one bid per bidder in each
+	CollectInRounds CollectionMode = "rounds"
+)
+
+// AuctionType selects how an auction allocates its item among bidders
+type AuctionType string
+
+const (
+	// WinnerTakesAll gives the whole item to the winning bid
+	WinnerTakesAll AuctionType = "winner_takes_all"
+	// ProportionalShare divides the item among all bidders in proportion
+	// to their bids, for divisible goods
+	ProportionalShare AuctionType = "proportional_share"
+	// FirstCome gives the item to the first qualifying bid received,
+	// whatever its amount, and closes the auction on it, for raffle and
+	// flash-sale models
+	FirstCome AuctionType = "first_come"
+	// English plays out an ascending auction: outbid bidders raise the
+	// high bid by at least MinimumBidIncrement after their patience (see
+	// BidderConfig.PatienceMinMs) until one bidder is left; the highest bid
+	// wins. Bids are played out in simulated time, as in Deterministic runs.
+	English AuctionType = "english"
+)
+
 // BidderConfig holds bidder-specific settings
 type BidderConfig struct {
 	TotalBidders     int     // Number of bidders (100)
@@ -28,13 +105,83 @@ type BidderConfig struct {
 	MaxBidMultiplier float64 // Max bid = BasePrice * multiplier
 	BidDelayMinMs    int     // Min delay before bidding (ms)
 	BidDelayMaxMs    int     // Max delay before bidding (ms)
+	BidRate          float64 // Max bids per second per bidder across all auctions (0 = unlimited); always wall-clock, even with SimulatedTime
+	PatienceMinMs    int     // Min delay before re-bidding after being outbid in English auctions (ms)
+	PatienceMaxMs    int     // Max delay before re-bidding after being outbid in English auctions (ms)
+
+	ShippingCostPerKg  float64 // Bids are discounted by ShipWeight * cost (0 = shipping is free)
+	HotBidMultiplier   float64 // BidProbability is multiplied by this for hot items, capped at 1 (0 = no boost)
+	DropoutProbability float64 // Chance that a bidder who decided to bid drops out without sending it
+	TieJitter          bool    // Take a tiny deterministic amount (under a cent) off each bid so amounts never tie
+	RushBeforeDeadline bool    // Cut a thinking time that would miss the auction's deadline to half the time left
+	ProbabilitySpread  float64 // Each bidder's BidProbability is drawn uniformly within ± this of BidProbability (0 = all equal)
+	FloorAtBasePrice   bool    // Raise every bid to at least the item's base price, even above the bidder's valuation when MinBidMultiplier is below 1
+	Budget             float64 // Most a bidder may spend on wins across the simulation; over-budget wins go to the runner-up (0 = unlimited)
+	MaxAbsoluteBid     float64 // No bid exceeds this amount, whatever the item's base price (0 = unlimited)
+	TickSize           float64 // Bids are rounded to the nearest multiple of this, e.g. 0.5, overriding TieJitter (0 = exact amounts)
 }
 
 // SystemConfig holds system resource settings
 type SystemConfig struct {
-	MaxCPUCores     int    // Maximum CPU cores to use
+	MaxCPUCores     int    // Maximum CPU cores to use (0 = the container's CPU quota, or all cores)
+	GCPercent       int    // GC target percentage; lower trades CPU for lower peak memory (0 or negative = leave as is)
 	EnableProfiling bool   // Enable CPU/memory profiling
 	LogLevel        string // "debug", "info", "warn", "error"
+	Quiet           bool   // Suppress banner, configuration dump and progress output
+	Seed            int64  // Seed for item and bidder randomness (0 = time-based)
+	SeedString      string // Label Seed was derived from with SeedFromString ("" = none)
+	Deterministic   bool   // Collect bids sequentially per auction so a seeded run is exactly repeatable
+	SimulatedTime   bool   // Run on a simulated clock that skips ahead to the next timer instead of waiting
+
+	MemoryCeilingMB float64 // Run fewer auctions at once while allocated memory exceeds this (0 = unlimited)
+	RemoteBidAddr   string  // TCP address serving JSON-RPC bids from external bidders while auctions run, e.g. ":7070" ("" = none)
+
+	ProgressInterval time.Duration // How often to report completed auctions and an ETA while running (0 = never)
+}
+
+// DefaultMaxCPUCores is the default MaxCPUCores, the same on every machine
+// with enough cores for consistent measurements
+const DefaultMaxCPUCores = 4
+
+// ReportConfig holds report and export formatting settings
+type ReportConfig struct {
+	Currency       Currency // Currency of all amounts
+	CloseMarginPct float64  // Auctions won by less than this % over the runner-up count as close
+	ASCII          bool     // Render reports and console output in plain ASCII, without box drawing or emoji
+	BidderSeeds    bool     // Record each bidder's seed in the result and export per-bidder statistics with it
+}
+
+// DefaultCloseMarginPct is the default CloseMarginPct
+const DefaultCloseMarginPct = 5.0
+
+// Currency describes how monetary amounts are written
+type Currency struct {
+	Symbol   string // Prefix of formatted amounts, e.g. "$" or "€"
+	Code     string // ISO code appended to CSV amount headers, e.g. "EUR" ("" = none)
+	Decimals int    // Decimal places of formatted amounts
+}
+
+// DefaultCurrency is US dollars with cents, as the simulator always reported
+var DefaultCurrency = Currency{Symbol: "$", Decimals: 2}
+
+// Format writes amount with the currency symbol and precision, e.g. "$12.50"
+func (c Currency) Format(amount float64) string {
+	return c.Symbol + c.FormatNumber(amount)
+}
+
+// FormatNumber writes amount with the currency precision but no symbol,
+// e.g. "12.50", for machine-readable exports
+func (c Currency) FormatNumber(amount float64) string {
+	return strconv.FormatFloat(amount, 'f', c.Decimals, 64)
+}
+
+// Header returns a CSV column name for amounts, suffixed with the currency
+// code if one is set, e.g. "WinningAmount_EUR"
+func (c Currency) Header(name string) string {
+	if c.Code == "" {
+		return name
+	}
+	return name + "_" + c.Code
 }
 
 // DefaultConfig returns a default configuration
@@ -52,25 +199,159 @@ func DefaultConfig() *Config {
 			MaxBidMultiplier: 2.5, // Bid up to 2.5x base price
 			BidDelayMinMs:    100,
 			BidDelayMaxMs:    2000,
+			PatienceMinMs:    100,
+			PatienceMaxMs:    1000,
 		},
 		System: SystemConfig{
-			MaxCPUCores:     4, // Use 4 cores for consistency
+			MaxCPUCores:     DefaultMaxCPUCores,
 			EnableProfiling: true,
 			LogLevel:        "info",
 		},
+		Report: ReportConfig{
+			Currency:       DefaultCurrency,
+			CloseMarginPct: DefaultCloseMarginPct,
+		},
 	}
 }
 
+// SeedFromString derives a seed from a human-memorable label, e.g.
+// "experiment-42", by FNV-1a hashing it, so a run can be named and later
+// reproduced from the label
+// The seed is never 0, which would mean a time-based run.
+func SeedFromString(label string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(label))
+	if seed := int64(h.Sum64()); seed != 0 {
+		return seed
+	}
+	return 1
+}
+
+// maxBidDelayMs bounds the configurable bid delay (one day)
+const maxBidDelayMs = 24 * 60 * 60 * 1000
+
 // Validate checks if configuration is valid
 func (c *Config) Validate() error {
 	if c.Auction.TotalAuctions <= 0 {
 		return fmt.Errorf("total auctions must be positive")
 	}
+	if c.Auction.Items != nil && len(c.Auction.Items) != c.Auction.TotalAuctions {
+		return fmt.Errorf("total auctions (%d) must match the %d items to auction",
+			c.Auction.TotalAuctions, len(c.Auction.Items))
+	}
 	if c.Bidder.TotalBidders <= 0 {
 		return fmt.Errorf("total bidders must be positive")
 	}
-	if c.Bidder.BidProbability < 0 || c.Bidder.BidProbability > 1 {
+	if c.Auction.AuctionTimeout <= 0 {
+		return fmt.Errorf("auction timeout must be positive")
+	}
+	// Written as negated ranges so NaN values are rejected too
+	if !(c.Bidder.BidProbability >= 0 && c.Bidder.BidProbability <= 1) {
 		return fmt.Errorf("bid probability must be between 0 and 1")
 	}
+	if !(c.Bidder.MinBidMultiplier > 0 && c.Bidder.MinBidMultiplier <= c.Bidder.MaxBidMultiplier) ||
+		math.IsInf(c.Bidder.MaxBidMultiplier, 0) {
+		return fmt.Errorf("bid multipliers must be finite with 0 < min <= max")
+	}
+	if c.Bidder.BidDelayMinMs < 0 || c.Bidder.BidDelayMaxMs < c.Bidder.BidDelayMinMs ||
+		c.Bidder.BidDelayMaxMs > maxBidDelayMs {
+		return fmt.Errorf("bid delays must satisfy 0 <= min <= max <= %d ms", maxBidDelayMs)
+	}
+	if c.Bidder.PatienceMinMs < 0 || c.Bidder.PatienceMaxMs < c.Bidder.PatienceMinMs ||
+		c.Bidder.PatienceMaxMs > maxBidDelayMs {
+		return fmt.Errorf("patience must satisfy 0 <= min <= max <= %d ms", maxBidDelayMs)
+	}
+	switch c.Auction.CollectionMode {
+	case "", CollectViaChannel, CollectViaMutex:
+	case CollectInRounds:
+		if c.Auction.Rounds < 1 {
+			return fmt.Errorf("round-based collection needs at least one round")
+		}
+	default:
+		return fmt.Errorf("unknown collection mode %q", c.Auction.CollectionMode)
+	}
+	switch c.Auction.Type {
+	case "", WinnerTakesAll, ProportionalShare, FirstCome:
+	case English:
+		if !(c.Auction.MinimumBidIncrement > 0) || math.IsInf(c.Auction.MinimumBidIncrement, 1) {
+			return fmt.Errorf("English auctions need a positive, finite minimum bid increment")
+		}
+	default:
+		return fmt.Errorf("unknown auction type %q", c.Auction.Type)
+	}
+	if !(c.Auction.ReserveMultiplier >= 0) || math.IsInf(c.Auction.ReserveMultiplier, 1) {
+		return fmt.Errorf("reserve multiplier must be finite and not negative")
+	}
+	if c.Auction.BidBufferSize < 0 {
+		return fmt.Errorf("bid buffer size must not be negative")
+	}
+	if c.Report.Currency.Decimals < 0 {
+		return fmt.Errorf("currency decimals must not be negative")
+	}
+	if !(c.Bidder.BidRate >= 0) {
+		return fmt.Errorf("bid rate must not be negative")
+	}
+	if !(c.System.MemoryCeilingMB >= 0) || math.IsInf(c.System.MemoryCeilingMB, 1) {
+		return fmt.Errorf("memory ceiling must be finite and not negative")
+	}
+	if !(c.Auction.BuyNowMultiplier >= 0) || math.IsInf(c.Auction.BuyNowMultiplier, 1) {
+		return fmt.Errorf("buy-now multiplier must be finite and not negative")
+	}
+	if c.Auction.MinDuration < 0 || c.Auction.MinDuration > c.Auction.AuctionTimeout {
+		return fmt.Errorf("minimum duration must be between 0 and the auction timeout")
+	}
+	for category, timeout := range c.Auction.CategoryTimeouts {
+		if timeout <= 0 || c.Auction.MinDuration > timeout {
+			return fmt.Errorf("timeout for category %q must be positive and at least the minimum duration", category)
+		}
+	}
+	if len(c.Auction.CategoryWeights) > 0 {
+		total := 0.0
+		for category, weight := range c.Auction.CategoryWeights {
+			if !c.Auction.AllowUnknownCategories && !models.Category(category).Valid() {
+				return fmt.Errorf("category weights name unknown category %q", category)
+			}
+			if !(weight >= 0) || math.IsInf(weight, 1) {
+				return fmt.Errorf("weight of category %q must be finite and not negative", category)
+			}
+			total += weight
+		}
+		if !(total > 0) || math.IsInf(total, 1) {
+			return fmt.Errorf("category weights must have a positive, finite total")
+		}
+	}
+	if !(c.Auction.HotItemFraction >= 0 && c.Auction.HotItemFraction <= 1) {
+		return fmt.Errorf("hot item fraction must be between 0 and 1")
+	}
+	if !(c.Report.CloseMarginPct >= 0) || math.IsInf(c.Report.CloseMarginPct, 1) {
+		return fmt.Errorf("close margin must be finite and not negative")
+	}
+	if !(c.Bidder.ProbabilitySpread >= 0 && c.Bidder.ProbabilitySpread <= 1) {
+		return fmt.Errorf("probability spread must be between 0 and 1")
+	}
+	if !(c.Bidder.MaxAbsoluteBid >= 0) || math.IsInf(c.Bidder.MaxAbsoluteBid, 1) {
+		return fmt.Errorf("maximum absolute bid must be non-negative and finite")
+	}
+	if !(c.Bidder.TickSize >= 0) || math.IsInf(c.Bidder.TickSize, 1) {
+		return fmt.Errorf("tick size must be non-negative and finite")
+	}
+	if !(c.Bidder.Budget >= 0) || math.IsInf(c.Bidder.Budget, 1) {
+		return fmt.Errorf("bidder budget must be non-negative and finite")
+	}
+	if !(c.Bidder.DropoutProbability >= 0 && c.Bidder.DropoutProbability <= 1) {
+		return fmt.Errorf("dropout probability must be between 0 and 1")
+	}
+	if !(c.Bidder.HotBidMultiplier >= 0) || math.IsInf(c.Bidder.HotBidMultiplier, 1) {
+		return fmt.Errorf("hot bid multiplier must be finite and not negative")
+	}
+	if !(c.Bidder.ShippingCostPerKg >= 0) || math.IsInf(c.Bidder.ShippingCostPerKg, 1) {
+		return fmt.Errorf("shipping cost per kg must be finite and not negative")
+	}
+	if c.System.ProgressInterval < 0 {
+		return fmt.Errorf("progress interval must not be negative")
+	}
+	if c.System.RemoteBidAddr != "" && (c.System.Deterministic || c.Auction.Type == English) {
+		return fmt.Errorf("remote bids cannot be served in a deterministic run or English auctions")
+	}
 	return nil
 }
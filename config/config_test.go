@@ -0,0 +1,105 @@
+package config_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/vineetjain1712/auction-simulator/config"
+	"github.com/vineetjain1712/auction-simulator/internal/bidder"
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+// FuzzValidateConfig checks that any configuration accepted by Validate can
+// drive a bidder without panicking and within the configured ranges
+func FuzzValidateConfig(f *testing.F) {
+	defaults := config.DefaultConfig()
+	f.Add(defaults.Auction.TotalAuctions, int64(defaults.Auction.AuctionTimeout), defaults.Bidder.TotalBidders,
+		defaults.Bidder.BidProbability, defaults.Bidder.MinBidMultiplier, defaults.Bidder.MaxBidMultiplier,
+		defaults.Bidder.BidDelayMinMs, defaults.Bidder.BidDelayMaxMs, defaults.Bidder.BidRate)
+	f.Add(1, int64(time.Millisecond), 1, 1.0, 1.0, 1.0, 0, 0, 0.0)
+	f.Add(0, int64(0), 0, -0.5, 2.0, 1.0, 500, 100, -1.0)
+	f.Add(40, int64(time.Second), 100, math.NaN(), math.NaN(), math.Inf(1), -1, math.MaxInt, math.NaN())
+
+	f.Fuzz(func(t *testing.T, auctions int, timeout int64, bidders int, probability, minMult, maxMult float64,
+		delayMin, delayMax int, bidRate float64) {
+		cfg := config.DefaultConfig()
+		cfg.Auction.TotalAuctions = auctions
+		cfg.Auction.AuctionTimeout = time.Duration(timeout)
+		cfg.Bidder.TotalBidders = bidders
+		cfg.Bidder.BidProbability = probability
+		cfg.Bidder.MinBidMultiplier = minMult
+		cfg.Bidder.MaxBidMultiplier = maxMult
+		cfg.Bidder.BidDelayMinMs = delayMin
+		cfg.Bidder.BidDelayMaxMs = delayMax
+		cfg.Bidder.BidRate = bidRate
+
+		if err := cfg.Validate(); err != nil {
+			return
+		}
+
+		if cfg.Auction.TotalAuctions <= 0 || cfg.Bidder.TotalBidders <= 0 || cfg.Auction.AuctionTimeout <= 0 {
+			t.Fatalf("Validate accepted non-positive sizes: %+v", cfg.Auction)
+		}
+
+		b := bidder.NewBidder(1, &cfg.Bidder)
+
+		delay := b.SimulateBidDelay()
+		if delay < time.Duration(delayMin)*time.Millisecond || delay > time.Duration(delayMax)*time.Millisecond {
+			t.Fatalf("Delay %v outside [%d, %d] ms", delay, delayMin, delayMax)
+		}
+
+		item := models.AuctionItem{ID: 1, BasePrice: 100}
+		amount := b.CalculateBidAmount(item)
+		low, high := item.BasePrice*minMult, item.BasePrice*maxMult
+		if math.IsNaN(amount) || amount < low || amount > high*(1+1e-12) {
+			t.Fatalf("Bid %v outside [%v, %v]", amount, low, high)
+		}
+	})
+}
+
+func TestSeedFromString(t *testing.T) {
+	seed := config.SeedFromString("experiment-42")
+	if seed == 0 {
+		t.Fatal("Expected a non-zero seed")
+	}
+	if again := config.SeedFromString("experiment-42"); again != seed {
+		t.Errorf("Expected the same label to give seed %d, got %d", seed, again)
+	}
+	for _, other := range []string{"experiment-43", "Experiment-42", ""} {
+		if config.SeedFromString(other) == seed {
+			t.Errorf("Expected %q to give a different seed than \"experiment-42\"", other)
+		}
+	}
+}
+
+func TestValidateCategoryWeights(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Auction.CategoryWeights = map[string]float64{"Electronics": 2, "Art": 1}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Expected known categories to be accepted, got %v", err)
+	}
+
+	cfg.Auction.CategoryWeights["Electronix"] = 1
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected a weight for an unknown category to be rejected")
+	}
+
+	cfg.Auction.AllowUnknownCategories = true
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected unknown categories to be accepted when allowed, got %v", err)
+	}
+}
+
+func TestValidateItems(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Auction.Items = []models.AuctionItem{{ID: 1, Name: "Lamp", Category: models.CategoryFurniture, BasePrice: 50}}
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected a total auction count other than the item count to be rejected")
+	}
+
+	cfg.Auction.TotalAuctions = len(cfg.Auction.Items)
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected one auction per item to be accepted, got %v", err)
+	}
+}
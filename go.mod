@@ -1,3 +1,5 @@
 module github.com/vineetjain1712/auction-simulator
 
 go 1.24.2
+
+require golang.org/x/time v0.14.0
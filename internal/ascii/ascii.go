@@ -0,0 +1,118 @@
+// Package ascii renders the simulator's console output and reports in plain
+// ASCII, for terminals and log systems that corrupt Unicode: box drawing
+// becomes |, - and +, a few symbols get ASCII spellings, and emoji are
+// dropped.
+package ascii
+
+import (
+	"io"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+// replacements spells out symbols that carry meaning
+var replacements = map[rune]string{
+	'✓': "[ok]",
+	'✗': "[fail]",
+	'❌': "[fail]",
+	'⚠': "[!]",
+	'•': "*",
+	'→': "->",
+	'±': "+/-",
+	'×': "x",
+	'…': "...",
+	'€': "EUR",
+	'£': "GBP",
+	'¥': "JPY",
+}
+
+// String returns s rendered in ASCII
+func String(s string) string {
+	var t transliterator
+	return t.convert(s)
+}
+
+// transliterator converts text to ASCII, carrying state across chunks
+type transliterator struct {
+	// A dropped symbol also drops the spaces after it, so "📈 Progress"
+	// becomes "Progress", even when they arrive in the next chunk
+	skipSpaces bool
+}
+
+func (t *transliterator) convert(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for _, r := range s {
+		if r == ' ' && t.skipSpaces {
+			continue
+		}
+		t.skipSpaces = false
+
+		switch {
+		case r < utf8.RuneSelf:
+			b.WriteRune(r)
+		case replacements[r] != "":
+			b.WriteString(replacements[r])
+		case r >= 0x2500 && r <= 0x257F:
+			b.WriteByte(boxDrawing(r))
+		default:
+			// Emoji, variation selectors and anything else without an
+			// ASCII spelling
+			t.skipSpaces = true
+		}
+	}
+	return b.String()
+}
+
+// boxDrawing returns the ASCII stand-in of a box-drawing character
+func boxDrawing(r rune) byte {
+	switch r {
+	case '─', '━', '═', '┄', '┅', '┈', '┉', '╌', '╍':
+		return '-'
+	case '│', '┃', '║', '┆', '┇', '┊', '┋', '╎', '╏':
+		return '|'
+	default:
+		// Corners, tees and crossings
+		return '+'
+	}
+}
+
+// writer converts everything written through it to ASCII
+// It is safe for concurrent use, e.g. as the output of every auction.
+type writer struct {
+	w       io.Writer
+	mu      sync.Mutex // Protects t and pending, and orders writes to w
+	t       transliterator
+	pending []byte // Start of a character split across writes
+}
+
+// NewWriter returns a writer that renders everything written to it in ASCII
+// before passing it on to w
+func NewWriter(w io.Writer) io.Writer {
+	return &writer{w: w}
+}
+
+// Write converts p, holding back a character split across writes until the
+// rest of it arrives
+func (w *writer) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	data := append(w.pending, p...)
+	complete := len(data)
+	for i := len(data) - 1; i >= 0 && i >= len(data)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(data[i]) {
+			if !utf8.FullRune(data[i:]) {
+				complete = i
+			}
+			break
+		}
+	}
+	w.pending = append([]byte(nil), data[complete:]...)
+
+	if _, err := io.WriteString(w.w, w.t.convert(string(data[:complete]))); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
@@ -0,0 +1,61 @@
+package ascii
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	for _, tc := range []struct{ in, want string }{
+		{"📈 DETAILED STATISTICS", "DETAILED STATISTICS"},
+		{"═══\n", "---\n"},
+		{"   ├─ Total Bids: 3\n   └─ Max: 4", "   +- Total Bids: 3\n   +- Max: 4"},
+		{"║ x ║", "| x |"},
+		{"⏱️  Duration", "Duration"},
+		{"   ✓ JSON exported", "   [ok] JSON exported"},
+		{"Revenue: €12.50", "Revenue: EUR12.50"},
+	} {
+		if got := String(tc.in); got != tc.want {
+			t.Errorf("String(%q) = %q, want %q", tc.in, got, tc.want)
+		}
+	}
+}
+
+func TestWriterJoinsSplitCharacters(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+
+	// Split a box-drawing character, and an emoji from its space
+	text := []byte("├─ Bids\n🔨 Auction")
+	for _, chunk := range [][]byte{text[:1], text[1:5], text[5:12], text[12:16], text[16:]} {
+		if n, err := w.Write(chunk); err != nil || n != len(chunk) {
+			t.Fatalf("Write(%q) = %d, %v", chunk, n, err)
+		}
+	}
+	if got, want := buf.String(), "+- Bids\nAuction"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestWriterConcurrent(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewWriter(&buf)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				w.Write([]byte("🔨 Auction ├─ started\n"))
+			}
+		}()
+	}
+	wg.Wait()
+
+	if got, want := strings.Count(buf.String(), "Auction +- started\n"), 800; got != want {
+		t.Errorf("Expected %d converted lines, got %d", want, got)
+	}
+}
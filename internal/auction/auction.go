@@ -7,10 +7,17 @@ package auction
 import (
 	"context"
 	"fmt"
+	"io"
+	"log/slog"
+	"math"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/vineetjain1712/auction-simulator/config"
+	"github.com/vineetjain1712/auction-simulator/internal/clock"
+	"github.com/vineetjain1712/auction-simulator/internal/logging"
 	"github.com/vineetjain1712/auction-simulator/internal/models"
 )
 
@@ -20,26 +27,123 @@ type Auction struct {
 	Item    models.AuctionItem
 	Timeout time.Duration
 
+	// Clock times the auction, and the bidders taking part through
+	// Participate (defaults to the wall clock)
+	Clock clock.Clock
+
+	// Output receives console progress lines (io.Discard to silence them)
+	Output io.Writer
+	// Logger receives structured progress logs
+	Logger *slog.Logger
+
+	// TieBreakByReceipt breaks ties between equal bids by the auction-side
+	// receive time rather than the bidder-side timestamp
+	TieBreakByReceipt bool
+
+	// CollectionMode selects how SubmitBid delivers bids to the auction
+	// Defaults to config.CollectViaChannel
+	CollectionMode config.CollectionMode
+	// Rounds is the number of rounds with config.CollectInRounds (values
+	// below 1 mean a single round)
+	Rounds int
+
+	// Type selects how the item is allocated ("" = config.WinnerTakesAll)
+	// With config.ProportionalShare every bidder gets a share (see
+	// models.AuctionResult.Shares) and Winner is not used. With
+	// config.FirstCome the first qualifying bid received wins and closes
+	// the auction at once; later bids are turned away, and Winner,
+	// BuyNowPrice and MinDuration are not used.
+	Type config.AuctionType
+
+	// Reserve is the lowest amount that can win (0 = no reserve)
+	// Below it the auction fails with models.StatusReserveNotMet
+	Reserve float64
+	// PublicReserve tells bidders the reserve, so they never bid below it,
+	// and turns away any bid below it on arrival; otherwise the reserve is
+	// hidden, bidders bid normally and it only decides the outcome
+	PublicReserve bool
+
+	// BuyNowPrice closes the auction early once a bid reaches it
+	// (0 = no buy-now); the winner then has status models.StatusBuyNow
+	BuyNowPrice float64
+	// MinDuration keeps the auction open at least this long after it
+	// starts, even after a buy-now bid, so higher bids can still supersede it
+	MinDuration time.Duration
+
+	// Winner, if set, replaces the highest-bid-wins rule (see WinnerFunc)
+	Winner WinnerFunc
+
+	// ExcludeLateBids ignores late bids (see IsLate) when picking the winner
+	// They are still recorded and counted in the result
+	ExcludeLateBids bool
+
+	// DedupBids drops a bid identical to an earlier one (same bidder,
+	// amount and timestamp), e.g. resent by a retrying client, so it isn't
+	// counted twice; dropped bids are counted in the result's DuplicateBids
+	DedupBids bool
+
+	// Decisions, if set, records how every incoming bid was treated
+	Decisions *DecisionRecorder
+
+	// OnEvent, if set, is called as the auction stores each bid and when
+	// it closes
+	// It runs synchronously on the bidders' and the auction's goroutines,
+	// so it must be safe for concurrent use and return quickly.
+	OnEvent func(models.AuctionEvent)
+
+	// Bidders who decided to bid, whether or not their bid arrived in time
+	attempts atomic.Int64
+
 	// Channel to receive bids
 	bidChannel chan models.Bid
 
 	// Store all received bids
-	bids []models.Bid
-	mu   sync.Mutex // Protects bids slice
+	bids      []models.Bid
+	closed    bool       // Set once the auction stops accepting bids
+	underBids int        // Bids turned away below a public reserve
+	boughtNow bool       // Set once a bid reaches BuyNowPrice
+	mu        sync.Mutex // Protects bids, closed, underBids, boughtNow, the dedup and round state and writes of startTime
+
+	// Bids seen so far and duplicates dropped, with DedupBids
+	seen       map[bidKey]struct{}
+	duplicates int
+
+	// Round state with config.CollectInRounds
+	round        int          // Current round, from 1 (0 = not collecting in rounds)
+	roundBidders map[int]bool // Bidders who have bid in the current round
+
+	// Signalled once when a bid reaches BuyNowPrice
+	buyNowHit chan struct{}
+	// Signalled once when a config.FirstCome auction takes its winning bid
+	firstCome chan struct{}
+	// Closed once the auction starts
+	started chan struct{}
 
 	// Timing
 	startTime time.Time
 	endTime   time.Time
 }
 
+// WinnerFunc picks the winning bid of an auction format, e.g. "median bid
+// wins", from the valid bids on item, ordered highest first
+// It returns nil if no bid wins (models.StatusNoWinner). The reserve still
+// applies to its choice.
+type WinnerFunc func(item models.AuctionItem, bids []models.Bid) *models.Bid
+
 // NewAuction creates a new auction instance
 func NewAuction(id int, item models.AuctionItem, timeout time.Duration) *Auction {
 	return &Auction{
 		ID:         id,
 		Item:       item,
 		Timeout:    timeout,
+		Clock:      clock.Real(),
+		Output:     io.Discard,
+		Logger:     logging.Discard(),
 		bidChannel: make(chan models.Bid, 100), // Buffered channel for bids
 		bids:       make([]models.Bid, 0),
+		buyNowHit:  make(chan struct{}, 1),
+		firstCome:  make(chan struct{}, 1),
+		started:    make(chan struct{}),
 	}
 }
 
@@ -48,32 +152,134 @@ func (a *Auction) GetBidChannel() chan<- models.Bid {
 	return a.bidChannel
 }
 
-// Run starts the auction and runs it until timeout
-// Returns the auction result
-func (a *Auction) Run(ctx context.Context) models.AuctionResult {
-	a.startTime = time.Now()
+// SubmitBid delivers a bid to the auction using its CollectionMode
+// Returns false if the bid was not delivered because ctx ended or the
+// auction has closed
+func (a *Auction) SubmitBid(ctx context.Context, bid models.Bid) bool {
+	if a.CollectionMode == config.CollectViaMutex {
+		if ctx.Err() != nil {
+			return false
+		}
+		return a.receiveBid(ctx, bid)
+	}
 
-	// Only log every 10th auction to reduce noise
-	if a.ID%10 == 0 || a.ID == 1 {
-		fmt.Printf("🔨 Auction #%d started: %s (Base: $%.2f)\n",
-			a.ID, a.Item.Name, a.Item.BasePrice)
+	select {
+	case a.bidChannel <- bid:
+		return true
+	case <-ctx.Done():
+		return false
 	}
+}
+
+// Run starts the auction and runs it until timeout, or until a buy-now bid
+// once MinDuration has passed
+// Returns the auction result
+func (a *Auction) Run(ctx context.Context) models.AuctionResult {
+	a.start(ctx)
 
 	// Create a context with timeout for this auction
-	auctionCtx, cancel := context.WithTimeout(ctx, a.Timeout)
+	auctionCtx, cancel := clock.WithTimeout(ctx, a.Clock, a.Timeout)
 	defer cancel()
 
 	// Collect bids until timeout
-	a.collectBids(auctionCtx)
+	switch a.CollectionMode {
+	case config.CollectViaMutex:
+		// Bidders append directly; just wait for the auction to close
+		a.waitForClose(auctionCtx)
+	case config.CollectInRounds:
+		a.collectRounds(auctionCtx)
+	default:
+		a.collectBids(auctionCtx)
+	}
+
+	a.mu.Lock()
+	a.closed = true
+	a.mu.Unlock()
+
+	return a.close(ctx)
+}
+
+// RunSequential runs the auction over a fixed sequence of bids, received in
+// the given order on the calling goroutine, instead of collecting them
+// concurrently until the timeout
+// Used for deterministic runs; stops early if ctx ends. With
+// config.CollectInRounds each bid falls in the round of its timestamp.
+func (a *Auction) RunSequential(ctx context.Context, bids []models.Bid) models.AuctionResult {
+	a.start(ctx)
+
+	// A buy-now bid closes the auction at its (simulated) time, or at
+	// MinDuration if later
+	var closeAt time.Time
+	for _, bid := range bids {
+		if ctx.Err() != nil {
+			break
+		}
+		if !closeAt.IsZero() && bid.Timestamp.After(closeAt) {
+			break
+		}
+		if a.CollectionMode == config.CollectInRounds {
+			a.enterRound(a.roundAt(bid.Timestamp))
+		}
+		if a.receiveBid(ctx, bid) && a.Type == config.FirstCome && a.isClosed() {
+			// The first qualifying bid took the item
+			break
+		}
+		if closeAt.IsZero() && a.isBuyNow(bid) {
+			closeAt = maxTime(bid.Timestamp, a.startTime.Add(a.MinDuration))
+		}
+	}
+
+	a.mu.Lock()
+	a.closed = true
+	a.mu.Unlock()
+
+	return a.close(ctx)
+}
+
+// start records the start time and reports the auction as started
+func (a *Auction) start(ctx context.Context) {
+	a.mu.Lock()
+	a.startTime = a.Clock.Now()
+	select {
+	case <-a.started:
+	default:
+		close(a.started)
+	}
+	a.mu.Unlock()
 
-	a.endTime = time.Now()
+	// Only log every 10th auction to reduce noise
+	if a.ID%10 == 0 || a.ID == 1 {
+		fmt.Fprintf(a.Output, "🔨 Auction #%d started: %s (Base: $%.2f)\n",
+			a.ID, a.Item.Name, a.Item.BasePrice)
+	}
+	a.Logger.DebugContext(ctx, "auction started",
+		"auction_id", a.ID, "item", a.Item.Name, "base_price", a.Item.BasePrice)
+}
+
+// close records the end of collection, determines the winner, records the
+// end time and reports the auction as closed
+func (a *Auction) close(ctx context.Context) models.AuctionResult {
+	collected := a.Clock.Now()
+	a.endTime = collected
 
 	// Determine winner
 	result := a.determineWinner()
 
+	// The auction ends once its winner is known
+	a.endTime = a.Clock.Now()
+	result.EndTime = a.endTime
+	result.Duration = a.endTime.Sub(a.startTime)
+	result.CollectionDuration = collected.Sub(a.startTime)
+	result.DeterminationDuration = a.endTime.Sub(collected)
+
 	// Only log every 10th auction
 	if a.ID%10 == 0 || a.ID == 1 {
-		fmt.Printf("✅ Auction #%d ended: %d bids received\n", a.ID, result.TotalBids)
+		fmt.Fprintf(a.Output, "✅ Auction #%d ended: %d bids received\n", a.ID, result.TotalBids)
+	}
+	a.Logger.DebugContext(ctx, "auction closed",
+		"auction_id", a.ID, "bids", result.TotalBids, "status", result.Status)
+	if a.OnEvent != nil {
+		a.OnEvent(models.AuctionEvent{Kind: models.EventAuctionClosed, AuctionID: a.ID, Result: &result})
 	}
 
 	return result
@@ -81,6 +287,9 @@ func (a *Auction) Run(ctx context.Context) models.AuctionResult {
 
 // collectBids listens for incoming bids until auction closes
 func (a *Auction) collectBids(ctx context.Context) {
+	// Fires once a buy-now bid may close the auction
+	var buyNowClose <-chan time.Time
+
 	for {
 		select {
 		case bid, ok := <-a.bidChannel:
@@ -90,30 +299,288 @@ func (a *Auction) collectBids(ctx context.Context) {
 			}
 
 			// Received a bid
-			a.mu.Lock()
-			a.bids = append(a.bids, bid)
-			a.mu.Unlock()
+			a.receiveBid(ctx, bid)
+
+		case <-a.buyNowHit:
+			buyNowClose = a.Clock.After(a.untilMinDuration())
+
+		case <-buyNowClose:
+			// Bought now: take the bids already sent, then close
+			a.drainBids(ctx)
+			return
+
+		case <-a.firstCome:
+			// The first qualifying bid won; the rest are turned away
+			a.drainBids(ctx)
+			return
 
 		case <-ctx.Done():
 			// Timeout reached, auction is closing
 			// DON'T close the channel - just stop listening
-			// Drain any buffered bids
-			for {
-				select {
-				case bid, ok := <-a.bidChannel:
-					if !ok {
-						return
-					}
-					a.mu.Lock()
-					a.bids = append(a.bids, bid)
-					a.mu.Unlock()
-				default:
-					// No more buffered bids
-					return
-				}
+			a.drainBids(ctx)
+			return
+		}
+	}
+}
+
+// drainBids receives the bids still buffered in the bid channel
+func (a *Auction) drainBids(ctx context.Context) {
+	for {
+		select {
+		case bid, ok := <-a.bidChannel:
+			if !ok {
+				return
 			}
+			a.receiveBid(ctx, bid)
+		default:
+			// No more buffered bids
+			return
+		}
+	}
+}
+
+// waitForClose waits until the auction closes when bidders append bids
+// directly: at timeout, or after a buy-now bid once MinDuration has passed
+func (a *Auction) waitForClose(ctx context.Context) {
+	select {
+	case <-a.buyNowHit:
+	case <-a.firstCome:
+		return
+	case <-ctx.Done():
+		return
+	}
+
+	timer := a.Clock.NewTimer(a.untilMinDuration())
+	defer timer.Stop()
+	select {
+	case <-timer.C():
+	case <-ctx.Done():
+	}
+}
+
+// untilMinDuration returns how long the auction must still stay open to
+// honour MinDuration (0 if it already has)
+func (a *Auction) untilMinDuration() time.Duration {
+	return max(a.MinDuration-clock.Since(a.Clock, a.startTime), 0)
+}
+
+// isBuyNow reports whether bid reaches the buy-now price
+func (a *Auction) isBuyNow(bid models.Bid) bool {
+	return a.BuyNowPrice > 0 && validAmount(bid.Amount) && bid.Amount >= a.BuyNowPrice
+}
+
+// maxTime returns the later of t and u
+func maxTime(t, u time.Time) time.Time {
+	if t.After(u) {
+		return t
+	}
+	return u
+}
+
+// Receive takes bid in directly on the calling goroutine, whatever the
+// collection mode, and reports how it was treated: models.ReasonAccepted
+// once it is stored (it can still be outbid), or why it was turned away
+// Call it only once the auction has started (see Started).
+func (a *Auction) Receive(ctx context.Context, bid models.Bid) models.DecisionReason {
+	return a.receive(ctx, bid)
+}
+
+// Started returns a channel that is closed once the auction starts
+func (a *Auction) Started() <-chan struct{} {
+	return a.started
+}
+
+// receiveBid stamps a bid with its receive time and stores it
+// Bids are stamped under the lock, so ReceivedAt is monotonic in arrival
+// order. Returns false if the auction has already closed.
+func (a *Auction) receiveBid(ctx context.Context, bid models.Bid) bool {
+	return a.receive(ctx, bid) == models.ReasonAccepted
+}
+
+// receive is receiveBid, returning models.ReasonAccepted if the bid was
+// stored or else why it was turned away
+func (a *Auction) receive(ctx context.Context, bid models.Bid) models.DecisionReason {
+	a.mu.Lock()
+	bid.ReceivedAt = a.Clock.Now()
+	if a.closed {
+		a.mu.Unlock()
+		a.recordDecision(bid, false, models.ReasonClosed)
+		return models.ReasonClosed
+	}
+	if a.PublicReserve && bid.Amount < a.Reserve {
+		// A public reserve is the opening high bid: nothing below it is taken
+		a.underBids++
+		a.mu.Unlock()
+		a.recordDecision(bid, false, models.ReasonTooLow)
+		return models.ReasonTooLow
+	}
+	if a.DedupBids {
+		key := bidKey{bid.BidderID, bid.Amount, bid.Timestamp.UnixNano()}
+		if _, ok := a.seen[key]; ok {
+			a.duplicates++
+			a.mu.Unlock()
+			a.recordDecision(bid, false, models.ReasonDuplicate)
+			return models.ReasonDuplicate
 		}
+		if a.seen == nil {
+			a.seen = make(map[bidKey]struct{})
+		}
+		a.seen[key] = struct{}{}
+	}
+	if a.round > 0 {
+		if a.roundBidders[bid.BidderID] {
+			a.mu.Unlock()
+			a.recordDecision(bid, false, models.ReasonRepeatInRound)
+			return models.ReasonRepeatInRound
+		}
+		a.roundBidders[bid.BidderID] = true
+		bid.Round = a.round
+	}
+	a.bids = append(a.bids, bid)
+	buyNow := a.Type != config.FirstCome && !a.boughtNow && a.isBuyNow(bid)
+	if buyNow {
+		a.boughtNow = true
+	}
+	firstCome := a.Type == config.FirstCome && a.qualifies(bid)
+	if firstCome {
+		// The item is taken: no later bid is accepted
+		a.closed = true
+	}
+	a.mu.Unlock()
+
+	if a.Decisions != nil {
+		accepted, reason := a.classify(bid)
+		a.recordDecision(bid, accepted, reason)
+	}
+
+	if a.OnEvent != nil {
+		a.OnEvent(models.AuctionEvent{Kind: models.EventBidReceived, AuctionID: a.ID, Bid: bid})
+	}
+
+	if buyNow {
+		// Tell the collecting goroutine to close the auction
+		select {
+		case a.buyNowHit <- struct{}{}:
+		default:
+		}
+	}
+	if firstCome {
+		select {
+		case a.firstCome <- struct{}{}:
+		default:
+		}
+	}
+
+	a.Logger.DebugContext(ctx, "bid received",
+		"auction_id", a.ID, "bidder_id", bid.BidderID, "amount", bid.Amount)
+	return models.ReasonAccepted
+}
+
+// bidKey identifies a bid for DedupBids
+type bidKey struct {
+	bidderID  int
+	amount    float64
+	timestamp int64 // Unix nanoseconds
+}
+
+// qualifies reports whether bid can win: a valid amount at or above the
+// reserve, and not late if late bids are excluded
+func (a *Auction) qualifies(bid models.Bid) bool {
+	return validAmount(bid.Amount) && bid.Amount >= a.Reserve && !(a.ExcludeLateBids && a.IsLate(bid))
+}
+
+// isClosed reports whether the auction has stopped accepting bids
+func (a *Auction) isClosed() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.closed
+}
+
+// RecordAttempt notes that a bidder decided to bid on the auction
+// If attempts were made but no bid arrived, the auction ends with
+// models.StatusMissedWindow rather than models.StatusNoBids
+func (a *Auction) RecordAttempt() {
+	a.attempts.Add(1)
+}
+
+// VisibleReserve returns the reserve as bidders see it: the reserve if it
+// is public, 0 if it is hidden
+func (a *Auction) VisibleReserve() float64 {
+	if a.PublicReserve {
+		return a.Reserve
+	}
+	return 0
+}
+
+// BidWindow reports whether the auction has started and closed, and its
+// deadline once started
+// Unlike Deadline, it is safe to call while the auction runs.
+func (a *Auction) BidWindow() (deadline time.Time, started, closed bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.startTime.Add(a.Timeout), !a.startTime.IsZero(), a.closed
+}
+
+// Deadline returns when the auction stops accepting bids: its start time
+// plus its timeout
+func (a *Auction) Deadline() time.Time {
+	return a.startTime.Add(a.Timeout)
+}
+
+// classify decides whether bid, as received, can win and why
+func (a *Auction) classify(bid models.Bid) (accepted bool, reason models.DecisionReason) {
+	switch {
+	case !validAmount(bid.Amount):
+		return false, models.ReasonInvalid
+	case a.IsLate(bid):
+		return !a.ExcludeLateBids, models.ReasonLate
+	case bid.Amount < a.Reserve:
+		return false, models.ReasonTooLow
+	case a.isBuyNow(bid):
+		return true, models.ReasonBuyNow
+	default:
+		return true, models.ReasonAccepted
+	}
+}
+
+// recordDecision records how bid was treated, if the auction has a
+// DecisionRecorder
+func (a *Auction) recordDecision(bid models.Bid, accepted bool, reason models.DecisionReason) {
+	if a.Decisions == nil {
+		return
+	}
+	a.Decisions.Record(models.BidDecision{
+		AuctionID:  a.ID,
+		BidderID:   bid.BidderID,
+		Amount:     bid.Amount,
+		ReceivedAt: bid.ReceivedAt,
+		Accepted:   accepted,
+		Reason:     reason,
+	})
+}
+
+// IsLate reports whether bid was placed or received after the deadline,
+// e.g. while the auction was draining its buffered bids
+func (a *Auction) IsLate(bid models.Bid) bool {
+	deadline := a.Deadline()
+	return bid.Timestamp.After(deadline) || bid.ReceivedAt.After(deadline)
+}
+
+// validAmount reports whether amount is a usable bid: positive and finite
+func validAmount(amount float64) bool {
+	return amount > 0 && !math.IsInf(amount, 1)
+}
+
+// ranksAhead reports whether bid x ranks ahead of y: a higher amount, or
+// for equal amounts the earlier bid (by receive time with TieBreakByReceipt)
+func (a *Auction) ranksAhead(x, y models.Bid) bool {
+	if x.Amount == y.Amount {
+		if a.TieBreakByReceipt {
+			return x.ReceivedAt.Before(y.ReceivedAt)
+		}
+		return x.Timestamp.Before(y.Timestamp)
 	}
+	return x.Amount > y.Amount
 }
 
 // determineWinner analyzes bids and determines the auction winner
@@ -122,37 +589,116 @@ func (a *Auction) determineWinner() models.AuctionResult {
 	defer a.mu.Unlock()
 
 	result := models.AuctionResult{
-		AuctionID: a.ID,
-		Item:      a.Item,
-		TotalBids: len(a.bids),
-		StartTime: a.startTime,
-		EndTime:   a.endTime,
-		Duration:  a.endTime.Sub(a.startTime),
+		AuctionID:     a.ID,
+		Item:          a.Item,
+		Bids:          append([]models.Bid(nil), a.bids...),
+		TotalBids:     len(a.bids),
+		DuplicateBids: a.duplicates,
+		StartTime:     a.startTime,
+		EndTime:       a.endTime,
+		Duration:      a.endTime.Sub(a.startTime),
+	}
+
+	// Flag late bids, leaving them out of the running if so configured
+	// Bids without a valid amount can never win. Highest-bid-wins needs no
+	// ordering beyond the best two bidders, so it tracks them in one pass
+	// over the bids; only a custom Winner rule gets all candidates sorted.
+	var candidates []models.Bid
+	collect := a.Winner != nil || a.Type == config.ProportionalShare
+	if collect {
+		candidates = make([]models.Bid, 0, len(a.bids))
+	}
+	eligible := 0
+	best, second := -1, -1 // Indexes into a.bids; second is the best bid from anyone but best's bidder
+	for i, bid := range a.bids {
+		if !validAmount(bid.Amount) {
+			continue
+		}
+		if a.IsLate(bid) {
+			result.LateBids++
+			if a.ExcludeLateBids {
+				continue
+			}
+		}
+		eligible++
+
+		switch {
+		case collect:
+			candidates = append(candidates, bid)
+		case best < 0 || a.ranksAhead(bid, a.bids[best]):
+			if best >= 0 && bid.BidderID != a.bids[best].BidderID {
+				second = best
+			}
+			best = i
+		case bid.BidderID != a.bids[best].BidderID && (second < 0 || a.ranksAhead(bid, a.bids[second])):
+			second = i
+		}
 	}
 
 	// Check if we have any bids
-	if len(a.bids) == 0 {
-		result.Status = "no_bids"
+	if eligible == 0 {
+		result.Status = models.StatusNoBids
+		switch {
+		case result.TotalBids == 0 && a.underBids > 0:
+			// Every bid was turned away below the public reserve
+			result.Status = models.StatusReserveNotMet
+		case result.TotalBids == 0 && a.attempts.Load() > 0:
+			// Bidders were interested, but none of their bids made it in time
+			result.Status = models.StatusMissedWindow
+		}
 		result.WinningBid = nil
 		return result
 	}
 
-	// Sort bids by amount (descending) to find highest bid
-	sortedBids := make([]models.Bid, len(a.bids))
-	copy(sortedBids, a.bids)
+	if a.Type == config.ProportionalShare {
+		a.allocateShares(&result, candidates)
+		return result
+	}
+	if a.Type == config.FirstCome {
+		a.awardFirstCome(&result)
+		return result
+	}
 
-	sort.Slice(sortedBids, func(i, j int) bool {
-		// If amounts are equal, earlier bid wins
-		if sortedBids[i].Amount == sortedBids[j].Amount {
-			return sortedBids[i].Timestamp.Before(sortedBids[j].Timestamp)
+	// Winner is the highest bid, or the custom rule's pick, if it meets
+	// the reserve
+	var winningBid models.Bid
+	var runnerUp *models.Bid
+	if a.Winner == nil {
+		winningBid = a.bids[best]
+		if second >= 0 {
+			bid := a.bids[second]
+			runnerUp = &bid
 		}
-		return sortedBids[i].Amount > sortedBids[j].Amount
-	})
+	} else {
+		// Sort bids by amount (descending), ties by time, for the rule
+		sort.SliceStable(candidates, func(i, j int) bool {
+			return a.ranksAhead(candidates[i], candidates[j])
+		})
+		chosen := a.Winner(a.Item, append([]models.Bid(nil), candidates...))
+		if chosen == nil {
+			result.Status = models.StatusNoWinner
+			return result
+		}
+		winningBid = *chosen
 
-	// Winner is the highest bid
-	winningBid := sortedBids[0]
+		// Runner-up is the next best bid from anyone but the winner
+		for _, bid := range candidates {
+			if bid.BidderID != winningBid.BidderID {
+				runnerUp = &bid
+				break
+			}
+		}
+	}
+	if winningBid.Amount < a.Reserve {
+		result.Status = models.StatusReserveNotMet
+		return result
+	}
 	result.WinningBid = &winningBid
-	result.Status = "completed"
+	result.RunnerUp = runnerUp
+	result.Status = models.StatusCompleted
+	if a.boughtNow && a.isBuyNow(winningBid) {
+		result.Status = models.StatusBuyNow
+	}
 
 	// Only log winners for interesting auctions
 	// (removed logging here to reduce noise)
@@ -160,6 +706,75 @@ func (a *Auction) determineWinner() models.AuctionResult {
 	return result
 }
 
+// awardFirstCome gives the item to the first qualifying bid received
+// Later bids were turned away, so there is no runner-up.
+func (a *Auction) awardFirstCome(result *models.AuctionResult) {
+	for _, bid := range a.bids {
+		if a.qualifies(bid) {
+			result.WinningBid = &bid
+			result.Status = models.StatusCompleted
+			return
+		}
+	}
+	// Every eligible bid was below the reserve
+	result.Status = models.StatusReserveNotMet
+}
+
+// allocateShares divides the item among the bidders of candidates in
+// proportion to their best bids at or above the reserve
+// The largest share's bid is the WinningBid, the next bidder's the RunnerUp.
+func (a *Auction) allocateShares(result *models.AuctionResult, candidates []models.Bid) {
+	best := make(map[int]models.Bid)
+	for _, bid := range candidates {
+		if bid.Amount < a.Reserve {
+			continue
+		}
+		if current, ok := best[bid.BidderID]; !ok || a.ranksAhead(bid, current) {
+			best[bid.BidderID] = bid
+		}
+	}
+	if len(best) == 0 {
+		result.Status = models.StatusReserveNotMet
+		return
+	}
+
+	// Rank the bids, so the total is summed in a fixed order
+	ranked := make([]models.Bid, 0, len(best))
+	for _, bid := range best {
+		ranked = append(ranked, bid)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		x, y := ranked[i], ranked[j]
+		if a.ranksAhead(x, y) || a.ranksAhead(y, x) {
+			return a.ranksAhead(x, y)
+		}
+		return x.BidderID < y.BidderID // Full ties
+	})
+
+	total := 0.0
+	for _, bid := range ranked {
+		total += bid.Amount
+	}
+	result.Shares = make(map[int]float64, len(ranked))
+	for _, bid := range ranked {
+		result.Shares[bid.BidderID] = bid.Amount / total
+	}
+
+	result.WinningBid = &ranked[0]
+	if len(ranked) > 1 {
+		result.RunnerUp = &ranked[1]
+	}
+	result.Status = models.StatusCompleted
+}
+
+// releaseBids drops the stored bids of a closed auction to free memory
+// GetAllBids returns none afterwards.
+func (a *Auction) releaseBids() {
+	a.mu.Lock()
+	a.bids = nil
+	a.mu.Unlock()
+}
+
 // GetAllBids returns all bids received (for testing/analysis)
 func (a *Auction) GetAllBids() []models.Bid {
 	a.mu.Lock()
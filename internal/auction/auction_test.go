@@ -2,9 +2,16 @@ package auction
 
 import (
 	"context"
+	"encoding/binary"
+	"io"
+	"math"
+	"math/rand"
+	"reflect"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/vineetjain1712/auction-simulator/config"
 	"github.com/vineetjain1712/auction-simulator/internal/models"
 )
 
@@ -38,6 +45,35 @@ func TestItemGenerator(t *testing.T) {
 	}
 }
 
+func TestCategoryWeights(t *testing.T) {
+	generator := NewItemGeneratorWithSeed(7)
+	generator.CategoryWeights = map[string]float64{
+		"Electronics": 5,
+		"Art":         3,
+		"Books":       2,
+		"Furniture":   0,
+	}
+
+	const n = 10000
+	counts := make(map[models.Category]int)
+	for _, item := range generator.GenerateItems(n) {
+		counts[item.Category]++
+	}
+
+	for category, want := range map[models.Category]float64{
+		models.CategoryElectronics: 0.5,
+		models.CategoryArt:         0.3,
+		models.CategoryBooks:       0.2,
+	} {
+		if got := float64(counts[category]) / n; math.Abs(got-want) > 0.02 {
+			t.Errorf("%s: expected about %.0f%% of items, got %.1f%%", category, want*100, got*100)
+		}
+	}
+	if len(counts) != 3 {
+		t.Errorf("Expected only the 3 weighted categories, got %v", counts)
+	}
+}
+
 func TestAuctionWithNoBids(t *testing.T) {
 	generator := NewItemGenerator()
 	item := generator.GenerateItem(1)
@@ -110,3 +146,661 @@ func TestAuctionWithBids(t *testing.T) {
 		t.Errorf("Expected bidder 2 to win, got bidder %d", result.WinningBid.BidderID)
 	}
 }
+
+func TestReceivedAtTieBreak(t *testing.T) {
+	item := models.AuctionItem{ID: 1, Name: "Tie Item", BasePrice: 100.0}
+
+	auction := NewAuction(1, item, 200*time.Millisecond)
+	auction.TieBreakByReceipt = true
+
+	done := make(chan models.AuctionResult)
+	go func() {
+		done <- auction.Run(context.Background())
+	}()
+
+	// Bidder 2's clock says it bid first, but the auction receives bidder 1 first
+	now := time.Now()
+	bidChannel := auction.GetBidChannel()
+	bidChannel <- models.Bid{BidderID: 1, AuctionID: 1, Amount: 150.0, Timestamp: now}
+	bidChannel <- models.Bid{BidderID: 2, AuctionID: 1, Amount: 150.0, Timestamp: now.Add(-time.Second)}
+	bidChannel <- models.Bid{BidderID: 3, AuctionID: 1, Amount: 120.0, Timestamp: now}
+
+	result := <-done
+
+	// ReceivedAt must be set and monotonic in receive order
+	bids := auction.GetAllBids()
+	if len(bids) != 3 {
+		t.Fatalf("Expected 3 bids, got %d", len(bids))
+	}
+	for i, bid := range bids {
+		if bid.ReceivedAt.IsZero() {
+			t.Errorf("Bid %d has no ReceivedAt", i)
+		}
+		if i > 0 && bid.ReceivedAt.Before(bids[i-1].ReceivedAt) {
+			t.Errorf("ReceivedAt not monotonic: bid %d received before bid %d", i, i-1)
+		}
+	}
+
+	if result.WinningBid == nil || result.WinningBid.BidderID != 1 {
+		t.Errorf("Expected first-received bidder 1 to win the tie, got %+v", result.WinningBid)
+	}
+}
+
+// seededBids returns n bids from a fixed seed, with whole-dollar amounts so
+// that some of them tie
+func seededBids(seed int64, n int) []models.Bid {
+	r := rand.New(rand.NewSource(seed))
+	start := time.Unix(1700000000, 0)
+
+	bids := make([]models.Bid, n)
+	for i := range bids {
+		bids[i] = models.Bid{
+			BidderID:  r.Intn(100) + 1,
+			AuctionID: 1,
+			Amount:    float64(100 + r.Intn(50)),
+			Timestamp: start.Add(time.Duration(r.Intn(1000)) * time.Millisecond),
+		}
+	}
+	return bids
+}
+
+// runWithBids runs an auction in the given collection mode, submits bids to
+// it concurrently and closes it once all of them have been delivered
+func runWithBids(mode config.CollectionMode, item models.AuctionItem, bids []models.Bid) models.AuctionResult {
+	auction := NewAuction(1, item, time.Minute)
+	auction.Output = io.Discard
+	auction.CollectionMode = mode
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan models.AuctionResult)
+	go func() {
+		done <- auction.Run(ctx)
+	}()
+
+	var wg sync.WaitGroup
+	for _, bid := range bids {
+		wg.Add(1)
+		go func(bid models.Bid) {
+			defer wg.Done()
+			auction.SubmitBid(context.Background(), bid)
+		}(bid)
+	}
+	wg.Wait()
+
+	cancel()
+	return <-done
+}
+
+func TestCollectionModesSelectSameWinner(t *testing.T) {
+	item := NewItemGenerator().GenerateItem(1)
+
+	for seed := int64(1); seed <= 5; seed++ {
+		bids := seededBids(seed, 200)
+
+		viaChannel := runWithBids(config.CollectViaChannel, item, bids)
+		viaMutex := runWithBids(config.CollectViaMutex, item, bids)
+
+		if viaChannel.TotalBids != len(bids) || viaMutex.TotalBids != len(bids) {
+			t.Fatalf("Seed %d: expected %d bids in both modes, got channel=%d mutex=%d",
+				seed, len(bids), viaChannel.TotalBids, viaMutex.TotalBids)
+		}
+		if viaChannel.WinningBid == nil || viaMutex.WinningBid == nil {
+			t.Fatalf("Seed %d: expected a winner in both modes", seed)
+		}
+
+		// ReceivedAt differs between runs; compare the bid itself
+		channelWinner, mutexWinner := *viaChannel.WinningBid, *viaMutex.WinningBid
+		channelWinner.ReceivedAt, mutexWinner.ReceivedAt = time.Time{}, time.Time{}
+		if channelWinner != mutexWinner {
+			t.Errorf("Seed %d: winners differ: channel=%+v mutex=%+v", seed, channelWinner, mutexWinner)
+		}
+	}
+}
+
+func TestMutexModeRejectsBidsAfterClose(t *testing.T) {
+	auction := NewAuction(1, NewItemGenerator().GenerateItem(1), 50*time.Millisecond)
+	auction.Output = io.Discard
+	auction.CollectionMode = config.CollectViaMutex
+
+	auction.Run(context.Background())
+
+	if auction.SubmitBid(context.Background(), models.Bid{BidderID: 1, Amount: 100}) {
+		t.Error("Expected bid after close to be rejected")
+	}
+	if len(auction.GetAllBids()) != 0 {
+		t.Errorf("Expected no stored bids, got %d", len(auction.GetAllBids()))
+	}
+}
+
+// BenchmarkCollectionModes compares delivering bids over the auction channel
+// against appending them under the auction mutex, with many concurrent bidders
+func BenchmarkCollectionModes(b *testing.B) {
+	item := NewItemGenerator().GenerateItem(1)
+	bids := seededBids(42, 1000)
+
+	for _, mode := range []config.CollectionMode{config.CollectViaChannel, config.CollectViaMutex} {
+		b.Run(string(mode), func(b *testing.B) {
+			for b.Loop() {
+				runWithBids(mode, item, bids)
+			}
+		})
+	}
+}
+
+func TestLateBids(t *testing.T) {
+	for _, exclude := range []bool{false, true} {
+		auction := NewAuction(1, NewItemGenerator().GenerateItem(1), 100*time.Millisecond)
+		auction.Output = io.Discard
+		auction.ExcludeLateBids = exclude
+
+		done := make(chan models.AuctionResult)
+		go func() {
+			done <- auction.Run(context.Background())
+		}()
+
+		bidChannel := auction.GetBidChannel()
+		bidChannel <- models.Bid{BidderID: 1, AuctionID: 1, Amount: 100, Timestamp: time.Now()}
+		// Placed just after the deadline, but still delivered
+		bidChannel <- models.Bid{BidderID: 2, AuctionID: 1, Amount: 200,
+			Timestamp: time.Now().Add(110 * time.Millisecond)}
+
+		result := <-done
+
+		if result.LateBids != 1 {
+			t.Errorf("exclude=%v: expected 1 late bid, got %d", exclude, result.LateBids)
+		}
+		if result.TotalBids != 2 {
+			t.Errorf("exclude=%v: expected late bids to still be counted, got %d bids", exclude, result.TotalBids)
+		}
+
+		expectedWinner := 2
+		if exclude {
+			expectedWinner = 1
+		}
+		if result.WinningBid == nil || result.WinningBid.BidderID != expectedWinner {
+			t.Errorf("exclude=%v: expected bidder %d to win, got %+v", exclude, expectedWinner, result.WinningBid)
+		}
+	}
+}
+
+// FuzzDetermineWinner decodes arbitrary bytes into bids (amount bits and a
+// timestamp offset per bid) and checks that the winner is always the highest
+// valid bid, earliest first on ties
+func FuzzDetermineWinner(f *testing.F) {
+	encode := func(amounts ...float64) []byte {
+		data := make([]byte, 0, len(amounts)*16)
+		for i, amount := range amounts {
+			data = binary.LittleEndian.AppendUint64(data, math.Float64bits(amount))
+			data = binary.LittleEndian.AppendUint64(data, uint64(i))
+		}
+		return data
+	}
+	f.Add([]byte{})
+	f.Add(encode(100, 200, 150))
+	f.Add(encode(100, 100, 100))
+	f.Add(encode(-5, 0, math.NaN(), math.Inf(1), math.Inf(-1)))
+	f.Add(encode(math.NaN(), 42, math.NaN(), 42.5, -1e308))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		start := time.Unix(1700000000, 0)
+
+		var bids []models.Bid
+		for i := 0; i+16 <= len(data); i += 16 {
+			bids = append(bids, models.Bid{
+				BidderID:  len(bids) + 1,
+				AuctionID: 1,
+				Amount:    math.Float64frombits(binary.LittleEndian.Uint64(data[i:])),
+				Timestamp: start.Add(time.Duration(binary.LittleEndian.Uint64(data[i+8:]) % uint64(time.Hour))),
+			})
+		}
+
+		auction := NewAuction(1, models.AuctionItem{ID: 1}, time.Hour)
+		auction.bids = bids
+		auction.startTime = start
+		auction.endTime = start.Add(time.Hour)
+
+		result := auction.determineWinner()
+
+		if result.TotalBids != len(bids) {
+			t.Fatalf("Expected %d bids counted, got %d", len(bids), result.TotalBids)
+		}
+
+		// Find the genuine best valid bid
+		var best *models.Bid
+		for i := range bids {
+			b := &bids[i]
+			if !(b.Amount > 0) || math.IsInf(b.Amount, 1) {
+				continue
+			}
+			if best == nil || b.Amount > best.Amount ||
+				(b.Amount == best.Amount && b.Timestamp.Before(best.Timestamp)) {
+				best = b
+			}
+		}
+
+		if best == nil {
+			if result.WinningBid != nil {
+				t.Fatalf("Expected no winner among invalid bids, got %+v", result.WinningBid)
+			}
+			return
+		}
+		if result.WinningBid == nil {
+			t.Fatalf("Expected winner %+v, got none", *best)
+		}
+		if result.WinningBid.Amount != best.Amount || !result.WinningBid.Timestamp.Equal(best.Timestamp) {
+			t.Fatalf("Expected winner %+v, got %+v", *best, *result.WinningBid)
+		}
+	})
+}
+
+func TestReserveNotMet(t *testing.T) {
+	auction := NewAuction(1, models.AuctionItem{ID: 1, BasePrice: 100}, time.Hour)
+	auction.Reserve = 150
+	auction.bids = []models.Bid{{BidderID: 1, Amount: 120}, {BidderID: 2, Amount: 140}}
+
+	result := auction.determineWinner()
+	if result.WinningBid != nil || result.Status != "reserve_not_met" {
+		t.Errorf("Expected no winner below the reserve, got %+v (%s)", result.WinningBid, result.Status)
+	}
+
+	auction.bids = append(auction.bids, models.Bid{BidderID: 3, Amount: 150})
+	result = auction.determineWinner()
+	if result.WinningBid == nil || result.WinningBid.BidderID != 3 {
+		t.Errorf("Expected bidder 3 to win at the reserve, got %+v", result.WinningBid)
+	}
+}
+
+func TestPublicReserveRejectsBidsOnArrival(t *testing.T) {
+	newAuction := func() *Auction {
+		auction := NewAuction(1, models.AuctionItem{ID: 1, BasePrice: 100}, time.Hour)
+		auction.Output = io.Discard
+		auction.Reserve = 150
+		auction.PublicReserve = true
+		auction.Decisions = &DecisionRecorder{}
+		return auction
+	}
+
+	// Bidders append directly, so SubmitBid reports the decision
+	auction := newAuction()
+	auction.CollectionMode = config.CollectViaMutex
+	if auction.SubmitBid(context.Background(), models.Bid{BidderID: 1, Amount: 149.99}) {
+		t.Error("Expected a bid below the public reserve to be turned away")
+	}
+	if !auction.SubmitBid(context.Background(), models.Bid{BidderID: 2, Amount: 150}) {
+		t.Error("Expected a bid at the public reserve to be taken")
+	}
+
+	decisions := auction.Decisions.Decisions()
+	if len(decisions) != 2 || decisions[0].Reason != models.ReasonTooLow || decisions[0].Accepted {
+		t.Errorf("Expected the low bid recorded as rejected too low, got %+v", decisions)
+	}
+	if bids := auction.GetAllBids(); len(bids) != 1 || bids[0].BidderID != 2 {
+		t.Errorf("Expected only bidder 2's bid stored, got %+v", bids)
+	}
+
+	// With every bid below it, the auction fails on the reserve
+	auction = newAuction()
+	result := auction.RunSequential(context.Background(), []models.Bid{{BidderID: 1, Amount: 120}, {BidderID: 2, Amount: 140}})
+	if result.TotalBids != 0 || result.Status != models.StatusReserveNotMet {
+		t.Errorf("Expected no bids taken and reserve not met, got %d bids (%s)", result.TotalBids, result.Status)
+	}
+}
+
+func TestRunnerUp(t *testing.T) {
+	auction := NewAuction(1, models.AuctionItem{ID: 1, BasePrice: 100}, time.Hour)
+	auction.bids = []models.Bid{
+		{BidderID: 1, Amount: 120},
+		{BidderID: 2, Amount: 180},
+		{BidderID: 3, Amount: 150},
+	}
+
+	result := auction.determineWinner()
+	if result.WinningBid == nil || result.WinningBid.BidderID != 2 {
+		t.Fatalf("Expected bidder 2 to win, got %+v", result.WinningBid)
+	}
+	if result.RunnerUp == nil || result.RunnerUp.BidderID != 3 || result.RunnerUp.Amount != 150 {
+		t.Errorf("Expected bidder 3 as runner-up at 150, got %+v", result.RunnerUp)
+	}
+
+	// A lone bidder has no runner-up, even with several bids
+	auction.bids = []models.Bid{{BidderID: 1, Amount: 120}, {BidderID: 1, Amount: 130}}
+	if result := auction.determineWinner(); result.RunnerUp != nil {
+		t.Errorf("Expected no runner-up for a single bidder, got %+v", result.RunnerUp)
+	}
+}
+
+func TestCustomWinnerFunc(t *testing.T) {
+	// Lowest unique bid wins: the smallest amount nobody else bid
+	lowestUnique := func(item models.AuctionItem, bids []models.Bid) *models.Bid {
+		counts := make(map[float64]int)
+		for _, bid := range bids {
+			counts[bid.Amount]++
+		}
+		var winner *models.Bid
+		for i, bid := range bids {
+			if counts[bid.Amount] == 1 && (winner == nil || bid.Amount < winner.Amount) {
+				winner = &bids[i]
+			}
+		}
+		return winner
+	}
+
+	auction := NewAuction(1, models.AuctionItem{ID: 1, BasePrice: 10}, time.Hour)
+	auction.Winner = lowestUnique
+	auction.bids = []models.Bid{
+		{BidderID: 1, Amount: 12},
+		{BidderID: 2, Amount: 12},
+		{BidderID: 3, Amount: 15},
+		{BidderID: 4, Amount: 20},
+		{BidderID: 5, Amount: 14},
+	}
+
+	result := auction.determineWinner()
+	if result.WinningBid == nil || result.WinningBid.BidderID != 5 || result.Status != "completed" {
+		t.Fatalf("Expected bidder 5 to win with the lowest unique bid, got %+v (%s)",
+			result.WinningBid, result.Status)
+	}
+	if result.RunnerUp == nil || result.RunnerUp.BidderID != 4 {
+		t.Errorf("Expected the highest other bid (bidder 4) as runner-up, got %+v", result.RunnerUp)
+	}
+
+	// No unique bid at all: no winner
+	auction.bids = []models.Bid{{BidderID: 1, Amount: 12}, {BidderID: 2, Amount: 12}}
+	if result := auction.determineWinner(); result.WinningBid != nil || result.Status != "no_winner" {
+		t.Errorf("Expected no winner without a unique bid, got %+v (%s)", result.WinningBid, result.Status)
+	}
+}
+
+func TestMinDurationHoldsBuyNow(t *testing.T) {
+	item := models.AuctionItem{ID: 1, BasePrice: 100}
+
+	for _, mode := range []config.CollectionMode{config.CollectViaChannel, config.CollectViaMutex} {
+		auction := NewAuction(1, item, 5*time.Second)
+		auction.Output = io.Discard
+		auction.CollectionMode = mode
+		auction.BuyNowPrice = 150
+		auction.MinDuration = 200 * time.Millisecond
+
+		done := make(chan models.AuctionResult)
+		go func() {
+			done <- auction.Run(context.Background())
+		}()
+
+		// A buy-now bid right away, then a higher one while the floor holds
+		auction.SubmitBid(context.Background(), models.Bid{BidderID: 1, AuctionID: 1, Amount: 150, Timestamp: time.Now()})
+		time.Sleep(50 * time.Millisecond)
+		auction.SubmitBid(context.Background(), models.Bid{BidderID: 2, AuctionID: 1, Amount: 200, Timestamp: time.Now()})
+
+		result := <-done
+		if result.Duration < auction.MinDuration || result.Duration >= auction.Timeout {
+			t.Errorf("%s: expected to close between %v and %v, ran %v",
+				mode, auction.MinDuration, auction.Timeout, result.Duration)
+		}
+		if result.WinningBid == nil || result.WinningBid.BidderID != 2 {
+			t.Errorf("%s: expected the higher bid to supersede the buy-now, got %+v", mode, result.WinningBid)
+		}
+		if result.Status != models.StatusBuyNow {
+			t.Errorf("%s: expected status %q, got %q", mode, models.StatusBuyNow, result.Status)
+		}
+	}
+}
+
+func TestDurationBreakdown(t *testing.T) {
+	auction := NewAuction(1, models.AuctionItem{ID: 1, BasePrice: 100}, time.Hour)
+	auction.Output = io.Discard
+	// A custom rule gets every bid sorted first, so determination takes
+	// measurable time
+	auction.Winner = func(item models.AuctionItem, bids []models.Bid) *models.Bid { return &bids[0] }
+
+	r := rand.New(rand.NewSource(1))
+	bids := make([]models.Bid, 50000)
+	for i := range bids {
+		bids[i] = models.Bid{BidderID: i + 1, AuctionID: 1, Amount: 100 + r.Float64()*1000, Timestamp: time.Now()}
+	}
+	result := auction.RunSequential(context.Background(), bids)
+
+	if result.CollectionDuration <= 0 || result.DeterminationDuration <= 0 {
+		t.Errorf("Expected both phases timed, got collection %v and determination %v",
+			result.CollectionDuration, result.DeterminationDuration)
+	}
+	if sum := result.CollectionDuration + result.DeterminationDuration; sum != result.Duration {
+		t.Errorf("Expected the phases to sum to the duration %v, got %v", result.Duration, sum)
+	}
+	if !result.EndTime.Equal(result.StartTime.Add(result.Duration)) {
+		t.Errorf("Expected the end time to include determination")
+	}
+}
+
+func TestDedupBids(t *testing.T) {
+	at := time.Now()
+	send := func(auction *Auction) models.AuctionResult {
+		// A retrying client resends bidder 1's bid; bidder 2 bids the same
+		// amount at the same time, which is no duplicate
+		for _, bid := range []models.Bid{
+			{BidderID: 1, AuctionID: 1, Amount: 120, Timestamp: at},
+			{BidderID: 1, AuctionID: 1, Amount: 120, Timestamp: at},
+			{BidderID: 2, AuctionID: 1, Amount: 120, Timestamp: at},
+			{BidderID: 1, AuctionID: 1, Amount: 120, Timestamp: at},
+		} {
+			auction.GetBidChannel() <- bid
+		}
+		return auction.Run(context.Background())
+	}
+
+	auction := NewAuction(1, models.AuctionItem{ID: 1, BasePrice: 100}, 50*time.Millisecond)
+	auction.Output = io.Discard
+	auction.DedupBids = true
+	auction.Decisions = &DecisionRecorder{}
+	result := send(auction)
+
+	if result.TotalBids != 2 || result.DuplicateBids != 2 {
+		t.Errorf("Expected 2 bids kept and 2 duplicates dropped, got %d and %d", result.TotalBids, result.DuplicateBids)
+	}
+	duplicates := 0
+	for _, decision := range auction.Decisions.Decisions() {
+		if decision.Reason == models.ReasonDuplicate {
+			duplicates++
+		}
+	}
+	if duplicates != 2 {
+		t.Errorf("Expected 2 duplicate decisions, got %d", duplicates)
+	}
+
+	// Without the option every copy counts
+	auction = NewAuction(1, models.AuctionItem{ID: 1, BasePrice: 100}, 50*time.Millisecond)
+	auction.Output = io.Discard
+	if result := send(auction); result.TotalBids != 4 || result.DuplicateBids != 0 {
+		t.Errorf("Expected all 4 bids counted, got %d (%d duplicates)", result.TotalBids, result.DuplicateBids)
+	}
+}
+
+func TestDecisionRecorderClassifiesBids(t *testing.T) {
+	auction := NewAuction(1, models.AuctionItem{ID: 1, BasePrice: 100}, time.Minute)
+	auction.Output = io.Discard
+	auction.CollectionMode = config.CollectViaMutex
+	auction.Reserve = 100
+	auction.BuyNowPrice = 500
+	auction.Decisions = &DecisionRecorder{}
+
+	future := time.Now().Add(time.Hour)
+	auction.RunSequential(context.Background(), []models.Bid{
+		{BidderID: 1, Amount: 150},
+		{BidderID: 2, Amount: math.NaN()},
+		{BidderID: 3, Amount: -10},
+		{BidderID: 4, Amount: 80},
+		{BidderID: 5, Amount: 200, Timestamp: future},
+		{BidderID: 6, Amount: 600},
+	})
+	auction.SubmitBid(context.Background(), models.Bid{BidderID: 7, Amount: 300})
+
+	expected := []struct {
+		accepted bool
+		reason   models.DecisionReason
+	}{
+		{true, models.ReasonAccepted},
+		{false, models.ReasonInvalid},
+		{false, models.ReasonInvalid},
+		{false, models.ReasonTooLow},
+		{true, models.ReasonLate},
+		{true, models.ReasonBuyNow},
+		{false, models.ReasonClosed},
+	}
+
+	decisions := auction.Decisions.Decisions()
+	if len(decisions) != len(expected) {
+		t.Fatalf("Expected %d decisions, got %d", len(expected), len(decisions))
+	}
+	for i, want := range expected {
+		got := decisions[i]
+		if got.BidderID != i+1 || got.Accepted != want.accepted || got.Reason != want.reason {
+			t.Errorf("Bidder %d: expected accepted=%v reason=%q, got bidder %d accepted=%v reason=%q",
+				i+1, want.accepted, want.reason, got.BidderID, got.Accepted, got.Reason)
+		}
+	}
+}
+
+// highestFirst is the highest-bid-wins rule as a WinnerFunc, which sends
+// determineWinner down its sorting path
+func highestFirst(_ models.AuctionItem, bids []models.Bid) *models.Bid {
+	return &bids[0]
+}
+
+// closedWith returns a closed auction holding bids
+func closedWith(bids []models.Bid, winner WinnerFunc, byReceipt bool) *Auction {
+	start := time.Unix(1700000000, 0)
+	auction := NewAuction(1, models.AuctionItem{ID: 1}, time.Hour)
+	auction.bids = bids
+	auction.startTime = start
+	auction.endTime = start.Add(time.Hour)
+	auction.Winner = winner
+	auction.TieBreakByReceipt = byReceipt
+	return auction
+}
+
+func TestSinglePassWinnerMatchesSort(t *testing.T) {
+	for seed := int64(1); seed <= 50; seed++ {
+		bids := seededBids(seed, 1+int(seed)*7)
+		for i := range bids {
+			// Coarse receive times, so receipt ties happen too
+			bids[i].ReceivedAt = bids[i].Timestamp.Truncate(100 * time.Millisecond)
+		}
+
+		for _, byReceipt := range []bool{false, true} {
+			scanned := closedWith(bids, nil, byReceipt).determineWinner()
+			sorted := closedWith(bids, highestFirst, byReceipt).determineWinner()
+
+			if !reflect.DeepEqual(scanned.WinningBid, sorted.WinningBid) {
+				t.Fatalf("Seed %d, by receipt %v: winners differ: scan %+v, sort %+v",
+					seed, byReceipt, scanned.WinningBid, sorted.WinningBid)
+			}
+			if !reflect.DeepEqual(scanned.RunnerUp, sorted.RunnerUp) {
+				t.Fatalf("Seed %d, by receipt %v: runners-up differ: scan %+v, sort %+v",
+					seed, byReceipt, scanned.RunnerUp, sorted.RunnerUp)
+			}
+		}
+	}
+}
+
+// BenchmarkDetermineWinner compares the single-pass highest-bid-wins path
+// against sorting the bids for a winner rule
+func BenchmarkDetermineWinner(b *testing.B) {
+	bids := seededBids(42, 1000)
+	rules := []struct {
+		name   string
+		winner WinnerFunc
+	}{
+		{"scan", nil},
+		{"sort", highestFirst},
+	}
+
+	for _, rule := range rules {
+		b.Run(rule.name, func(b *testing.B) {
+			auction := closedWith(bids, rule.winner, false)
+			b.ReportAllocs()
+			for b.Loop() {
+				auction.determineWinner()
+			}
+		})
+	}
+}
+
+func TestProportionalShares(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	bid := func(bidderID int, amount float64) models.Bid {
+		return models.Bid{BidderID: bidderID, AuctionID: 1, Amount: amount, Timestamp: start}
+	}
+
+	auction := closedWith([]models.Bid{
+		bid(1, 100), bid(2, 300), bid(3, 150),
+		bid(3, 200), // Only a bidder's best bid counts
+	}, nil, false)
+	auction.Type = config.ProportionalShare
+	result := auction.determineWinner()
+
+	expected := map[int]float64{1: 100.0 / 600, 2: 300.0 / 600, 3: 200.0 / 600}
+	if len(result.Shares) != len(expected) {
+		t.Fatalf("Expected shares for %d bidders, got %v", len(expected), result.Shares)
+	}
+	sum := 0.0
+	for bidderID, want := range expected {
+		if got := result.Shares[bidderID]; math.Abs(got-want) > 1e-12 {
+			t.Errorf("Bidder %d: expected share %.4f, got %.4f", bidderID, want, got)
+		}
+		sum += result.Shares[bidderID]
+	}
+	if math.Abs(sum-1) > 1e-12 {
+		t.Errorf("Expected shares to sum to 1, got %v", sum)
+	}
+
+	if result.Status != models.StatusCompleted || result.WinningBid == nil || result.WinningBid.BidderID != 2 {
+		t.Errorf("Expected the largest share's bidder 2 as winner, got %s %+v", result.Status, result.WinningBid)
+	}
+	if result.RunnerUp == nil || result.RunnerUp.BidderID != 3 {
+		t.Errorf("Expected bidder 3 as runner-up, got %+v", result.RunnerUp)
+	}
+}
+
+func TestFirstCome(t *testing.T) {
+	item := models.AuctionItem{ID: 1, BasePrice: 100}
+	now := time.Now()
+	bids := []models.Bid{
+		{BidderID: 1, AuctionID: 1, Amount: 40, Timestamp: now}, // Below the reserve
+		{BidderID: 2, AuctionID: 1, Amount: 60, Timestamp: now.Add(time.Millisecond)},
+		{BidderID: 3, AuctionID: 1, Amount: 500, Timestamp: now.Add(2 * time.Millisecond)},
+		{BidderID: 4, AuctionID: 1, Amount: 80, Timestamp: now.Add(3 * time.Millisecond)},
+	}
+	newAuction := func(mode config.CollectionMode) *Auction {
+		auction := NewAuction(1, item, 5*time.Second)
+		auction.Output = io.Discard
+		auction.CollectionMode = mode
+		auction.Type = config.FirstCome
+		auction.Reserve = 50
+		return auction
+	}
+	check := func(name string, result models.AuctionResult) {
+		t.Helper()
+		if result.WinningBid == nil || result.WinningBid.BidderID != 2 || result.Status != models.StatusCompleted {
+			t.Errorf("%s: expected the first qualifying bid (bidder 2) to win, got %s %+v", name, result.Status, result.WinningBid)
+		}
+		if result.TotalBids != 2 || result.RunnerUp != nil {
+			t.Errorf("%s: expected later bids turned away, got %d bids and runner-up %+v", name, result.TotalBids, result.RunnerUp)
+		}
+		if result.Duration >= 5*time.Second {
+			t.Errorf("%s: expected the winning bid to close the auction, ran %v", name, result.Duration)
+		}
+	}
+
+	for _, mode := range []config.CollectionMode{config.CollectViaChannel, config.CollectViaMutex} {
+		auction := newAuction(mode)
+		done := make(chan models.AuctionResult)
+		go func() {
+			done <- auction.Run(context.Background())
+		}()
+		for _, bid := range bids {
+			auction.SubmitBid(context.Background(), bid)
+		}
+		check(string(mode), <-done)
+	}
+
+	check("sequential", newAuction(config.CollectViaChannel).RunSequential(context.Background(), bids))
+}
@@ -0,0 +1,154 @@
+package auction
+
+import (
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+// ReconcileBudgets enforces Bidder.Budget across the finished auctions in
+// Results, whose winners were picked independently: while a bidder's wins
+// add up to more than the budget, its lowest win is dropped and that
+// auction goes to its runner-up
+// In proportional-share auctions every bidder with a share pays its bid;
+// a dropped bidder's share is divided among the others instead.
+// An auction left without an eligible bidder gets models.StatusOverBudget,
+// or models.StatusReserveNotMet if the runner-up is below the reserve. Call
+// it after Wait and before AggregateResults; Compact runs keep no bids to
+// reassign and are left alone. Returns the number of wins dropped.
+func (m *Manager) ReconcileBudgets() int {
+	budget := m.config.Bidder.Budget
+	if budget <= 0 {
+		return 0
+	}
+
+	m.Mu.Lock()
+	defer m.Mu.Unlock()
+
+	// Bidders dropped from each auction, by index in Results
+	excluded := make(map[int]map[int]bool)
+	dropped := 0
+	for {
+		spend := make(map[int]float64)
+		for _, result := range m.Results {
+			for bidderID, amount := range payments(result) {
+				spend[bidderID] += amount
+			}
+		}
+
+		// Settle the lowest over-budget bidder ID first, so the outcome
+		// doesn't depend on map order
+		over, found := 0, false
+		for bidderID, total := range spend {
+			if total > budget && (!found || bidderID < over) {
+				over, found = bidderID, true
+			}
+		}
+		if !found {
+			return dropped
+		}
+
+		lowest, lowestAmount := -1, 0.0
+		for i, result := range m.Results {
+			amount, ok := payments(result)[over]
+			if ok && (lowest < 0 || amount < lowestAmount) {
+				lowest, lowestAmount = i, amount
+			}
+		}
+
+		if excluded[lowest] == nil {
+			excluded[lowest] = make(map[int]bool)
+		}
+		excluded[lowest][over] = true
+		if m.Results[lowest].Shares != nil {
+			m.reshare(&m.Results[lowest], excluded[lowest])
+		} else {
+			m.reassign(&m.Results[lowest], excluded[lowest])
+		}
+		dropped++
+	}
+}
+
+// payments returns what each bidder pays in result: the winner its winning
+// amount, or with proportional shares every bidder with a share its bid,
+// that share of the sum of the bids
+func payments(result models.AuctionResult) map[int]float64 {
+	if result.WinningBid == nil {
+		return nil
+	}
+	if result.Shares == nil {
+		return map[int]float64{result.WinningBid.BidderID: result.WinningBid.Amount}
+	}
+
+	total := models.Revenue(result)
+	paid := make(map[int]float64, len(result.Shares))
+	for bidderID, share := range result.Shares {
+		paid[bidderID] = share * total
+	}
+	return paid
+}
+
+// reshare divides the item of a proportional-share result again among the
+// bidders not in excluded
+func (m *Manager) reshare(result *models.AuctionResult, excluded map[int]bool) {
+	auc, ok := m.auction(result.AuctionID)
+	if !ok {
+		auc = &Auction{}
+	}
+
+	var candidates []models.Bid
+	for _, bid := range result.Bids {
+		if excluded[bid.BidderID] || !validAmount(bid.Amount) || (auc.ExcludeLateBids && auc.IsLate(bid)) {
+			continue
+		}
+		candidates = append(candidates, bid)
+	}
+
+	result.WinningBid, result.RunnerUp, result.Shares = nil, nil, nil
+	if len(candidates) == 0 {
+		result.Status = models.StatusOverBudget
+		return
+	}
+	auc.allocateShares(result, candidates)
+}
+
+// reassign gives result, whose winner was dropped, to its runner-up and
+// finds the new runner-up among bidders not in excluded
+func (m *Manager) reassign(result *models.AuctionResult, excluded map[int]bool) {
+	auc, ok := m.auction(result.AuctionID)
+	if !ok {
+		// Rank by amount and bid time alone
+		auc = &Auction{}
+	}
+
+	winner := result.RunnerUp
+	result.WinningBid, result.RunnerUp = nil, nil
+	switch {
+	case winner == nil:
+		result.Status = models.StatusOverBudget
+		return
+	case winner.Amount < auc.Reserve:
+		result.Status = models.StatusReserveNotMet
+		return
+	}
+
+	result.WinningBid = winner
+	result.Status = models.StatusCompleted
+	excluded[winner.BidderID] = true
+	result.RunnerUp = auc.bestBidExcept(result.Bids, excluded)
+	delete(excluded, winner.BidderID)
+}
+
+// bestBidExcept returns the bid in bids that would win the auction among
+// bidders not in excluded, highest bid wins (nil if there is none)
+func (a *Auction) bestBidExcept(bids []models.Bid, excluded map[int]bool) *models.Bid {
+	var best *models.Bid
+	for i := range bids {
+		bid := bids[i]
+		if excluded[bid.BidderID] || !validAmount(bid.Amount) || (a.ExcludeLateBids && a.IsLate(bid)) {
+			continue
+		}
+		if best == nil || a.ranksAhead(bid, *best) {
+			best = &bid
+		}
+	}
+	return best
+}
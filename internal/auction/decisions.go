@@ -0,0 +1,29 @@
+package auction
+
+import (
+	"sync"
+
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+// DecisionRecorder accumulates the accept/reject decision for every bid the
+// auctions it is attached to receive, for debugging winner selection
+// One recorder may be shared by many auctions.
+type DecisionRecorder struct {
+	mu        sync.Mutex
+	decisions []models.BidDecision
+}
+
+// Record adds a decision to the log
+func (r *DecisionRecorder) Record(decision models.BidDecision) {
+	r.mu.Lock()
+	r.decisions = append(r.decisions, decision)
+	r.mu.Unlock()
+}
+
+// Decisions returns a copy of the recorded decisions, in arrival order
+func (r *DecisionRecorder) Decisions() []models.BidDecision {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]models.BidDecision(nil), r.decisions...)
+}
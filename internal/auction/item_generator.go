@@ -3,6 +3,7 @@ package auction
 import (
 	"fmt"
 	"math/rand"
+	"sort"
 	"sync"
 	"time"
 
@@ -11,22 +12,49 @@ import (
 
 // ItemGenerator generates random auction items
 type ItemGenerator struct {
+	// HotFraction is the chance that a generated item is designated hot
+	// (0 = none, and no extra random draws, so seeded items are unchanged)
+	HotFraction float64
+
+	// CategoryWeights, if set, skews the category mix: each category is
+	// drawn in proportion to its weight, keyed by category name, e.g.
+	// {"Electronics": 5, "Art": 3}; categories without a weight are never
+	// drawn (nil = every known category equally likely)
+	// The weights are read once, when the first item is generated.
+	CategoryWeights map[string]float64
+
+	// Weighted categories in draw order and their total weight, from
+	// CategoryWeights on the first draw
+	weighted    []weightedCategory
+	totalWeight float64
+	weighed     bool
+
 	rand *rand.Rand
-	mu   sync.Mutex // Protects rand for thread-safety
+	mu   sync.Mutex // Protects rand and the weighted categories for thread-safety
+}
+
+// weightedCategory is a category drawn in proportion to its weight
+type weightedCategory struct {
+	category models.Category
+	weight   float64
 }
 
 // NewItemGenerator creates a new item generator
 func NewItemGenerator() *ItemGenerator {
 	// Create a new random source with current time as seed
-	source := rand.NewSource(time.Now().UnixNano())
+	return NewItemGeneratorWithSeed(time.Now().UnixNano())
+}
+
+// NewItemGeneratorWithSeed creates an item generator that produces the same
+// items for the same seed
+func NewItemGeneratorWithSeed(seed int64) *ItemGenerator {
 	return &ItemGenerator{
-		rand: rand.New(source),
+		rand: rand.New(rand.NewSource(seed)),
 	}
 }
 
 // Predefined lists for generating realistic items
 var (
-	categories     = []string{"Electronics", "Art", "Collectibles", "Jewelry", "Furniture", "Books", "Clothing"}
 	brands         = []string{"Apple", "Samsung", "Sony", "Nike", "Canon", "Rolex", "Generic"}
 	conditions     = []string{"New", "Like New", "Used", "Refurbished", "Fair"}
 	colors         = []string{"Black", "White", "Silver", "Gold", "Blue", "Red", "Green"}
@@ -42,13 +70,13 @@ func (g *ItemGenerator) GenerateItem(id int) models.AuctionItem {
 	g.mu.Lock()
 	defer g.mu.Unlock()
 
-	category := g.randomChoiceUnsafe(categories)
+	category := g.randomCategoryUnsafe()
 	brand := g.randomChoiceUnsafe(brands)
 
 	// Generate a contextual name based on category
 	name := fmt.Sprintf("%s %s %d", brand, category, id)
 
-	return models.AuctionItem{
+	item := models.AuctionItem{
 		// Attribute 1-5
 		ID:        id,
 		Name:      name,
@@ -76,11 +104,51 @@ func (g *ItemGenerator) GenerateItem(id int) models.AuctionItem {
 		Dimensions:    fmt.Sprintf("%.1fx%.1fx%.1f", g.randomFloatUnsafe(5, 100), g.randomFloatUnsafe(5, 100), g.randomFloatUnsafe(5, 100)),
 		Certification: g.randomChoiceUnsafe(certifications),
 		Rating:        g.randomFloatUnsafe(3.0, 10.0), // 3.0 to 10.0
+
+		Hot: g.HotFraction > 0 && g.rand.Float64() < g.HotFraction,
 	}
+	item.Fingerprint = item.ComputeFingerprint()
+	return item
 }
 
 // Helper functions - "Unsafe" means caller must hold mutex
 
+// randomCategoryUnsafe draws a category, weighted by CategoryWeights if set
+func (g *ItemGenerator) randomCategoryUnsafe() models.Category {
+	if !g.weighed {
+		g.weighCategoriesUnsafe()
+	}
+	if g.totalWeight <= 0 {
+		return models.Categories[g.rand.Intn(len(models.Categories))]
+	}
+
+	x := g.rand.Float64() * g.totalWeight
+	for _, weighted := range g.weighted {
+		if x < weighted.weight {
+			return weighted.category
+		}
+		x -= weighted.weight
+	}
+	// Rounding left x at the very top: take the last weighted category
+	return g.weighted[len(g.weighted)-1].category
+}
+
+// weighCategoriesUnsafe orders the positively weighted categories of
+// CategoryWeights by name, so seeded items are repeatable, and totals
+// their weights
+func (g *ItemGenerator) weighCategoriesUnsafe() {
+	g.weighed = true
+	for name, weight := range g.CategoryWeights {
+		if weight > 0 {
+			g.weighted = append(g.weighted, weightedCategory{models.Category(name), weight})
+			g.totalWeight += weight
+		}
+	}
+	sort.Slice(g.weighted, func(i, j int) bool {
+		return g.weighted[i].category < g.weighted[j].category
+	})
+}
+
 func (g *ItemGenerator) randomChoiceUnsafe(choices []string) string {
 	return choices[g.rand.Intn(len(choices))]
 }
@@ -0,0 +1,41 @@
+package auction
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+// LoadItems reads a JSON array of auction items, such as an exported item
+// catalog. Items with an unknown category are rejected unless
+// allowUnknownCategories is set, so a typo cannot silently create a new category.
+// Items without a Fingerprint are given one.
+func LoadItems(r io.Reader, allowUnknownCategories bool) ([]models.AuctionItem, error) {
+	var items []models.AuctionItem
+	if err := json.NewDecoder(r).Decode(&items); err != nil {
+		return nil, fmt.Errorf("failed to decode items: %w", err)
+	}
+
+	if !allowUnknownCategories {
+		for _, item := range items {
+			if !item.Category.Valid() {
+				return nil, fmt.Errorf("item %d has unknown category %q", item.ID, item.Category)
+			}
+		}
+	}
+
+	for i := range items {
+		if items[i].Fingerprint == "" {
+			items[i].Fingerprint = items[i].ComputeFingerprint()
+		}
+	}
+
+	return items, nil
+}
+
+// LoadItems reads external items, applying the manager's category policy
+func (m *Manager) LoadItems(r io.Reader) ([]models.AuctionItem, error) {
+	return LoadItems(r, m.config.Auction.AllowUnknownCategories)
+}
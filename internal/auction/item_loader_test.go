@@ -0,0 +1,34 @@
+package auction
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadItemsCategories(t *testing.T) {
+	known := `[{"ID": 1, "Name": "Lamp", "Category": "Furniture", "BasePrice": 50}]`
+	unknown := `[{"ID": 2, "Name": "Phone", "Category": "Electronix", "BasePrice": 500}]`
+
+	items, err := LoadItems(strings.NewReader(known), false)
+	if err != nil {
+		t.Fatalf("Expected known category to load, got %v", err)
+	}
+	if len(items) != 1 || items[0].Category != "Furniture" {
+		t.Errorf("Unexpected items: %+v", items)
+	}
+
+	if _, err := LoadItems(strings.NewReader(unknown), false); err == nil {
+		t.Error("Expected unknown category to be rejected")
+	} else if !strings.Contains(err.Error(), "Electronix") {
+		t.Errorf("Expected error to name the category, got %q", err)
+	}
+
+	// The override accepts unknown categories as-is
+	items, err = LoadItems(strings.NewReader(unknown), true)
+	if err != nil {
+		t.Fatalf("Expected unknown category to load with override, got %v", err)
+	}
+	if items[0].Category != "Electronix" {
+		t.Errorf("Expected category to be kept, got %q", items[0].Category)
+	}
+}
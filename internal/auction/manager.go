@@ -1,10 +1,13 @@
 package auction
 
 import (
+	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/vineetjain1712/auction-simulator/config"
+	"github.com/vineetjain1712/auction-simulator/internal/clock"
 	"github.com/vineetjain1712/auction-simulator/internal/models"
 )
 
@@ -23,6 +26,58 @@ type Manager struct {
 	// Results collection
 	Results []models.AuctionResult
 	Mu      sync.Mutex // EXPORTED
+
+	// Compact makes StartAuctions keep only a CompactResult per auction, in
+	// CompactResults instead of Results, and release each auction's bids
+	// once it closes, to bound memory on very large runs
+	Compact        bool
+	CompactResults []models.CompactResult
+
+	// IsSuccess decides which results count as successful auctions
+	// Defaults to models.HasWinner
+	IsSuccess models.SuccessPredicate
+
+	// Winner, if set, is the winner rule of every auction created by
+	// NewAuction (nil = highest bid wins)
+	Winner WinnerFunc
+
+	// Decisions, if set, records the bid decisions of every auction created
+	// by NewAuction
+	Decisions *DecisionRecorder
+
+	// OnEvent, if set, receives the events of every auction created by
+	// NewAuction (see Auction.OnEvent)
+	OnEvent func(models.AuctionEvent)
+
+	// Clock, if set, times every auction created by NewAuction
+	// (nil = wall clock)
+	Clock clock.Clock
+
+	// Throttle, if set, gates the launch of each auction on memory use
+	// (nil = all auctions run at once)
+	Throttle *MemoryThrottle
+
+	// Lock-free collection used by StartAuctions: each auction goroutine
+	// owns one slot, and running totals are kept in atomic counters
+	slots      []models.AuctionResult
+	compact    []models.CompactResult
+	running    sync.WaitGroup
+	completed  atomic.Int64
+	bids       atomic.Int64
+	successful atomic.Int64
+	failed     atomic.Int64
+
+	// Auctions running now, and the most ever running at once
+	active     atomic.Int64
+	peakActive atomic.Int64
+}
+
+// Progress is a point-in-time view of the live auction counters
+type Progress struct {
+	Completed  int // Auctions that have finished
+	TotalBids  int // Bids received by finished auctions
+	Successful int // Finished auctions counted as successful
+	Failed     int // Finished auctions counted as failed
 }
 
 // NewManager creates a new auction manager
@@ -32,7 +87,184 @@ func NewManager(cfg *config.Config) *Manager {
 		Generator: NewItemGenerator(),
 		Auctions:  make([]*Auction, 0, cfg.Auction.TotalAuctions),
 		Results:   make([]models.AuctionResult, 0, cfg.Auction.TotalAuctions),
+		IsSuccess: models.HasWinner,
+	}
+}
+
+// maxDerivedBidBuffer caps the bid channel buffer derived from the bidder
+// count, so very large pools don't allocate huge buffers per auction
+const maxDerivedBidBuffer = 1000
+
+// BidBufferSize returns the bid channel buffer size for auctions under cfg:
+// Auction.BidBufferSize if set, otherwise one slot per bidder up to
+// maxDerivedBidBuffer
+func BidBufferSize(cfg *config.Config) int {
+	if cfg.Auction.BidBufferSize > 0 {
+		return cfg.Auction.BidBufferSize
+	}
+	return max(1, min(cfg.Bidder.TotalBidders, maxDerivedBidBuffer))
+}
+
+// NewAuction creates an auction for item using the manager's auction settings
+func (m *Manager) NewAuction(id int, item models.AuctionItem) *Auction {
+	auc := NewAuction(id, item, m.config.Auction.TimeoutFor(string(item.Category)))
+	auc.bidChannel = make(chan models.Bid, BidBufferSize(m.config))
+	auc.TieBreakByReceipt = m.config.Auction.TieBreakByReceipt
+	auc.CollectionMode = m.config.Auction.CollectionMode
+	auc.Rounds = m.config.Auction.Rounds
+	auc.Type = m.config.Auction.Type
+	auc.ExcludeLateBids = m.config.Auction.ExcludeLateBids
+	auc.DedupBids = m.config.Auction.DedupBids
+	auc.Reserve = item.BasePrice * m.config.Auction.ReserveMultiplier
+	auc.PublicReserve = m.config.Auction.PublicReserve
+	auc.Winner = m.Winner
+	auc.Decisions = m.Decisions
+	auc.OnEvent = m.OnEvent
+	auc.BuyNowPrice = item.BasePrice * m.config.Auction.BuyNowMultiplier
+	auc.MinDuration = m.config.Auction.MinDuration
+	if m.Clock != nil {
+		auc.Clock = m.Clock
+	}
+	return auc
+}
+
+// StartAuctions runs every auction in m.Auctions on its own goroutine
+// Results are written to per-auction slots and totals to atomic counters, so
+// completing auctions never contend on a lock. Call Wait to collect them.
+func (m *Manager) StartAuctions(ctx context.Context) {
+	m.StartAuctionsWith(ctx, (*Auction).Run)
+}
+
+// StartAuctionsWith is StartAuctions with a custom way of running each
+// auction, such as Auction.RunSequential over precomputed bids
+// With a Throttle, auctions are launched in order as it admits them; queued
+// auctions start late, and bids sent to them meanwhile wait in their buffers
+func (m *Manager) StartAuctionsWith(ctx context.Context, run func(*Auction, context.Context) models.AuctionResult) {
+	isSuccess := m.successPredicate()
+	if m.Compact {
+		m.compact = make([]models.CompactResult, len(m.Auctions))
+	} else {
+		m.slots = make([]models.AuctionResult, len(m.Auctions))
+	}
+	m.running.Add(len(m.Auctions))
+
+	launch := func(slot int, auction *Auction, throttled bool) {
+		go func() {
+			defer m.running.Done()
+			if throttled {
+				defer m.Throttle.Release()
+			}
+
+			m.enter()
+			result := run(auction, ctx)
+			m.active.Add(-1)
+			if m.Compact {
+				m.compact[slot] = models.NewCompactResult(result)
+				auction.releaseBids()
+			} else {
+				m.slots[slot] = result
+			}
+
+			m.bids.Add(int64(result.TotalBids))
+			if isSuccess(result) {
+				m.successful.Add(1)
+			} else {
+				m.failed.Add(1)
+			}
+			m.completed.Add(1)
+		}()
+	}
+
+	if m.Throttle == nil {
+		for i, auc := range m.Auctions {
+			launch(i, auc, false)
+		}
+		return
+	}
+
+	go func() {
+		for i, auc := range m.Auctions {
+			// Once ctx ends, remaining auctions close at once, so they are
+			// launched without waiting for a slot
+			launch(i, auc, m.Throttle.Acquire(ctx))
+		}
+	}()
+}
+
+// Wait blocks until all auctions started by StartAuctions have finished,
+// then merges their results into Results
+func (m *Manager) Wait() {
+	m.running.Wait()
+
+	m.Mu.Lock()
+	m.Results = append(m.Results, m.slots...)
+	m.CompactResults = append(m.CompactResults, m.compact...)
+	m.slots, m.compact = nil, nil
+	m.Mu.Unlock()
+}
+
+// Progress returns the live counters of auctions started by StartAuctions
+// Safe to call while auctions are running
+func (m *Manager) Progress() Progress {
+	return Progress{
+		Completed:  int(m.completed.Load()),
+		TotalBids:  int(m.bids.Load()),
+		Successful: int(m.successful.Load()),
+		Failed:     int(m.failed.Load()),
+	}
+}
+
+// minETACompleted is the fewest finished auctions an ETA is estimated from;
+// below it (or 5% of the auctions, if more) early completions are too few
+// to extrapolate from
+const minETACompleted = 3
+
+// Percent returns how much of total auctions have finished, in [0, 100]
+func (p Progress) Percent(total int) float64 {
+	if total <= 0 {
+		return 100
 	}
+	return min(100, 100*float64(p.Completed)/float64(total))
+}
+
+// ETA estimates the time left until total auctions have finished, given
+// elapsed time since they started, from the average rate of completion
+// so far
+// It reports false while too few auctions have finished for an estimate.
+func (p Progress) ETA(total int, elapsed time.Duration) (time.Duration, bool) {
+	if p.Completed >= total {
+		return 0, true
+	}
+	if p.Completed < max(minETACompleted, total/20) || elapsed <= 0 {
+		return 0, false
+	}
+	remaining := float64(total - p.Completed)
+	return time.Duration(float64(elapsed) * remaining / float64(p.Completed)), true
+}
+
+// enter counts an auction as running, raising the peak if needed
+func (m *Manager) enter() {
+	n := m.active.Add(1)
+	for peak := m.peakActive.Load(); n > peak; peak = m.peakActive.Load() {
+		if m.peakActive.CompareAndSwap(peak, n) {
+			return
+		}
+	}
+}
+
+// PeakConcurrent returns the most auctions started by StartAuctions that
+// were running at once
+// Below the auction count when a Throttle staggers their launch.
+func (m *Manager) PeakConcurrent() int {
+	return int(m.peakActive.Load())
+}
+
+// successPredicate returns IsSuccess, falling back to models.HasWinner
+func (m *Manager) successPredicate() models.SuccessPredicate {
+	if m.IsSuccess == nil {
+		return models.HasWinner
+	}
+	return m.IsSuccess
 }
 
 // AggregateResults compiles all auction results into a simulation result
@@ -40,14 +272,18 @@ func (m *Manager) AggregateResults() models.SimulationResult {
 	m.Mu.Lock()
 	defer m.Mu.Unlock()
 
+	isSuccess := m.successPredicate()
+
 	totalBids := 0
+	duplicateBids := 0
 	successfulAuctions := 0
 	failedAuctions := 0
 
 	for _, result := range m.Results {
 		totalBids += result.TotalBids
+		duplicateBids += result.DuplicateBids
 
-		if result.Status == "completed" && result.WinningBid != nil {
+		if isSuccess(result) {
 			successfulAuctions++
 		} else {
 			failedAuctions++
@@ -63,7 +299,53 @@ func (m *Manager) AggregateResults() models.SimulationResult {
 		SuccessfulAuctions: successfulAuctions,
 		FailedAuctions:     failedAuctions,
 		TotalBids:          totalBids,
+		DuplicateBids:      duplicateBids,
+
+		PeakConcurrentAuctions: m.PeakConcurrent(),
+	}
+}
+
+// AggregateCompact compiles the compact results of a Compact run into a
+// compact simulation result
+// Totals come from the live counters of StartAuctions, so they follow
+// IsSuccess like AggregateResults does.
+func (m *Manager) AggregateCompact() models.CompactSimulationResult {
+	m.Mu.Lock()
+	defer m.Mu.Unlock()
+
+	return models.CompactSimulationResult{
+		TotalAuctions:      m.config.Auction.TotalAuctions,
+		TotalDuration:      m.EndTime.Sub(m.StartTime),
+		StartTime:          m.StartTime,
+		EndTime:            m.EndTime,
+		AuctionResults:     m.CompactResults,
+		SuccessfulAuctions: int(m.successful.Load()),
+		FailedAuctions:     int(m.failed.Load()),
+		TotalBids:          int(m.bids.Load()),
+	}
+}
+
+// Item returns the item of the auction with auctionID, e.g. to expand a
+// CompactResult
+func (m *Manager) Item(auctionID int) (models.AuctionItem, bool) {
+	if auc, ok := m.auction(auctionID); ok {
+		return auc.Item, true
+	}
+	return models.AuctionItem{}, false
+}
+
+// auction returns the auction with auctionID
+func (m *Manager) auction(auctionID int) (*Auction, bool) {
+	// Auctions are usually numbered from 1 in order
+	if i := auctionID - 1; i >= 0 && i < len(m.Auctions) && m.Auctions[i].ID == auctionID {
+		return m.Auctions[i], true
+	}
+	for _, auc := range m.Auctions {
+		if auc.ID == auctionID {
+			return auc, true
+		}
 	}
+	return nil, false
 }
 
 // GetAuctions returns all auction instances (for backwards compatibility)
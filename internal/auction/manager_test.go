@@ -0,0 +1,521 @@
+package auction
+
+import (
+	"context"
+	"io"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vineetjain1712/auction-simulator/config"
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+// mixedResults returns results covering the different auction statuses
+func mixedResults() []models.AuctionResult {
+	winner := &models.Bid{BidderID: 1, Amount: 150.0}
+	return []models.AuctionResult{
+		{AuctionID: 1, Status: "completed", WinningBid: winner, TotalBids: 3},
+		{AuctionID: 2, Status: "buy_now", WinningBid: winner, TotalBids: 1},
+		{AuctionID: 3, Status: "no_bids"},
+		{AuctionID: 4, Status: "reserve_not_met", TotalBids: 2},
+		{AuctionID: 5, Status: "completed", WinningBid: winner, TotalBids: 4},
+	}
+}
+
+func TestAggregateResultsDefaultSuccess(t *testing.T) {
+	cfg := config.DefaultConfig()
+	manager := NewManager(cfg)
+	manager.Results = mixedResults()
+
+	result := manager.AggregateResults()
+
+	// completed, buy_now and completed have winners
+	if result.SuccessfulAuctions != 3 {
+		t.Errorf("Expected 3 successful auctions, got %d", result.SuccessfulAuctions)
+	}
+	if result.FailedAuctions != 2 {
+		t.Errorf("Expected 2 failed auctions, got %d", result.FailedAuctions)
+	}
+	if result.TotalBids != 10 {
+		t.Errorf("Expected 10 bids, got %d", result.TotalBids)
+	}
+}
+
+func TestAggregateResultsCustomSuccess(t *testing.T) {
+	cfg := config.DefaultConfig()
+	manager := NewManager(cfg)
+	manager.Results = mixedResults()
+
+	// Only auctions that ran to completion count
+	manager.IsSuccess = func(result models.AuctionResult) bool {
+		return result.Status == "completed"
+	}
+
+	result := manager.AggregateResults()
+
+	if result.SuccessfulAuctions != 2 {
+		t.Errorf("Expected 2 successful auctions, got %d", result.SuccessfulAuctions)
+	}
+	if result.FailedAuctions != 3 {
+		t.Errorf("Expected 3 failed auctions, got %d", result.FailedAuctions)
+	}
+}
+
+func TestStartAuctionsTotalsMatch(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Auction.TotalAuctions = 20
+	cfg.Auction.AuctionTimeout = 100 * time.Millisecond
+
+	manager := NewManager(cfg)
+	for i, item := range manager.Generator.GenerateItems(cfg.Auction.TotalAuctions) {
+		manager.Auctions = append(manager.Auctions, manager.NewAuction(i+1, item))
+	}
+	for _, auc := range manager.Auctions {
+		auc.Output = io.Discard
+	}
+
+	manager.StartAuctions(context.Background())
+
+	// Every other auction receives as many bids as its ID
+	for _, auc := range manager.Auctions {
+		if auc.ID%2 == 1 {
+			continue
+		}
+		for bidder := 1; bidder <= auc.ID; bidder++ {
+			auc.GetBidChannel() <- models.Bid{BidderID: bidder, AuctionID: auc.ID, Amount: 100, Timestamp: time.Now()}
+		}
+	}
+
+	manager.Wait()
+
+	progress := manager.Progress()
+	result := manager.AggregateResults()
+
+	if len(result.AuctionResults) != cfg.Auction.TotalAuctions {
+		t.Fatalf("Expected %d results, got %d", cfg.Auction.TotalAuctions, len(result.AuctionResults))
+	}
+	if progress.Completed != cfg.Auction.TotalAuctions {
+		t.Errorf("Expected %d completed auctions, got %d", cfg.Auction.TotalAuctions, progress.Completed)
+	}
+
+	// 2 + 4 + ... + 20 bids across the even auctions
+	if progress.TotalBids != 110 || result.TotalBids != 110 {
+		t.Errorf("Expected 110 bids, got %d (atomic) and %d (aggregate)", progress.TotalBids, result.TotalBids)
+	}
+	if progress.Successful != result.SuccessfulAuctions || progress.Successful != 10 {
+		t.Errorf("Expected 10 successful auctions, got %d (atomic) and %d (aggregate)",
+			progress.Successful, result.SuccessfulAuctions)
+	}
+	if progress.Failed != result.FailedAuctions || progress.Failed != 10 {
+		t.Errorf("Expected 10 failed auctions, got %d (atomic) and %d (aggregate)",
+			progress.Failed, result.FailedAuctions)
+	}
+
+	// Results are merged in auction order
+	for i, r := range result.AuctionResults {
+		if r.AuctionID != i+1 {
+			t.Fatalf("Expected result %d to be auction #%d, got #%d", i, i+1, r.AuctionID)
+		}
+	}
+}
+
+func TestBidBufferSize(t *testing.T) {
+	tests := []struct {
+		bidders  int
+		explicit int
+		expected int
+	}{
+		{bidders: 1, expected: 1},
+		{bidders: 100, expected: 100},
+		{bidders: 1000, expected: 1000},
+		{bidders: 50000, expected: maxDerivedBidBuffer},
+		{bidders: 100, explicit: 16, expected: 16},
+		{bidders: 50000, explicit: 5000, expected: 5000},
+	}
+
+	for _, tt := range tests {
+		cfg := config.DefaultConfig()
+		cfg.Bidder.TotalBidders = tt.bidders
+		cfg.Auction.BidBufferSize = tt.explicit
+
+		if got := BidBufferSize(cfg); got != tt.expected {
+			t.Errorf("%d bidders, explicit %d: expected buffer %d, got %d",
+				tt.bidders, tt.explicit, tt.expected, got)
+		}
+
+		auc := NewManager(cfg).NewAuction(1, models.AuctionItem{ID: 1})
+		if cap(auc.bidChannel) != tt.expected {
+			t.Errorf("%d bidders, explicit %d: expected auction channel capacity %d, got %d",
+				tt.bidders, tt.explicit, tt.expected, cap(auc.bidChannel))
+		}
+	}
+}
+
+// collectionAuctions is the number of concurrently completing auctions in
+// BenchmarkStartAuctions
+const collectionAuctions = 1000
+
+// BenchmarkStartAuctions runs many concurrent auctions through
+// StartAuctions and Wait in each collection mode, so the cost of recording
+// their results is measured as the simulation pays it
+func BenchmarkStartAuctions(b *testing.B) {
+	item := NewItemGenerator().GenerateItem(1)
+	bids := seededBids(42, 10)
+
+	for _, mode := range []config.CollectionMode{config.CollectViaChannel, config.CollectViaMutex} {
+		b.Run(string(mode), func(b *testing.B) {
+			cfg := config.DefaultConfig()
+			cfg.Auction.AuctionTimeout = time.Minute
+			cfg.Auction.CollectionMode = mode
+
+			for b.Loop() {
+				manager := NewManager(cfg)
+				for i := 1; i <= collectionAuctions; i++ {
+					auc := manager.NewAuction(i, item)
+					auc.Output = io.Discard
+					manager.Auctions = append(manager.Auctions, auc)
+				}
+
+				ctx, cancel := context.WithCancel(context.Background())
+				manager.StartAuctions(ctx)
+				for _, auc := range manager.Auctions {
+					for _, bid := range bids {
+						bid.AuctionID = auc.ID
+						auc.SubmitBid(ctx, bid)
+					}
+				}
+				// Closing every auction at once has them all record their
+				// results concurrently
+				cancel()
+				manager.Wait()
+			}
+		})
+	}
+}
+
+func TestMemoryThrottleBoundsConcurrency(t *testing.T) {
+	// peakGoroutines runs 50 short auctions under throttle and returns the
+	// most goroutines seen beyond those that existed before
+	peakGoroutines := func(throttle *MemoryThrottle) int {
+		cfg := config.DefaultConfig()
+		manager := NewManager(cfg)
+		manager.Throttle = throttle
+		for i := 1; i <= 50; i++ {
+			manager.Auctions = append(manager.Auctions, manager.NewAuction(i, models.AuctionItem{ID: i}))
+		}
+
+		baseline := runtime.NumGoroutine()
+		var peak atomic.Int64
+		manager.StartAuctionsWith(context.Background(), func(auc *Auction, ctx context.Context) models.AuctionResult {
+			n := int64(runtime.NumGoroutine() - baseline)
+			for old := peak.Load(); n > old && !peak.CompareAndSwap(old, n); old = peak.Load() {
+			}
+			time.Sleep(5 * time.Millisecond)
+			return models.AuctionResult{AuctionID: auc.ID}
+		})
+		manager.Wait()
+
+		if len(manager.Results) != 50 {
+			t.Fatalf("Expected 50 results, got %d", len(manager.Results))
+		}
+		return int(peak.Load())
+	}
+
+	// Memory always over the ceiling: the limit stays at its minimum of 2,
+	// plus the launching goroutine
+	over := NewMemoryThrottle(1, func() float64 { return 1000 })
+	over.Min = 2
+	if peak := peakGoroutines(over); peak > 3 {
+		t.Errorf("Expected at most 3 extra goroutines over the memory ceiling, got %d", peak)
+	}
+
+	// Memory under the ceiling: the limit grows
+	under := NewMemoryThrottle(1000, func() float64 { return 1 })
+	under.Min = 2
+	if peak := peakGoroutines(under); peak <= 3 {
+		t.Errorf("Expected concurrency to grow under the memory ceiling, got %d extra goroutines", peak)
+	}
+}
+
+func TestPeakConcurrentAuctions(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Auction.TotalAuctions = 20
+	cfg.Auction.AuctionTimeout = 5 * time.Millisecond
+	manager := NewManager(cfg)
+	// Memory always over the ceiling staggers launches two at a time
+	manager.Throttle = NewMemoryThrottle(1, func() float64 { return 1000 })
+	manager.Throttle.Min = 2
+	for i := 1; i <= cfg.Auction.TotalAuctions; i++ {
+		manager.Auctions = append(manager.Auctions, manager.NewAuction(i, models.AuctionItem{ID: i}))
+	}
+
+	manager.StartAuctions(context.Background())
+	manager.Wait()
+
+	result := manager.AggregateResults()
+	if result.PeakConcurrentAuctions < 1 || result.PeakConcurrentAuctions > 2 {
+		t.Errorf("Expected at most 2 of %d auctions at once, got a peak of %d",
+			result.TotalAuctions, result.PeakConcurrentAuctions)
+	}
+}
+
+func TestProgressETA(t *testing.T) {
+	const total = 100
+
+	// Too few completions to extrapolate from
+	for _, completed := range []int{0, 1, 4} {
+		if eta, ok := (Progress{Completed: completed}).ETA(total, time.Second); ok {
+			t.Errorf("%d done: expected no estimate yet, got %v", completed, eta)
+		}
+	}
+
+	// A steady 10 auctions per second
+	for _, snapshot := range []struct {
+		completed int
+		elapsed   time.Duration
+		want      time.Duration
+	}{
+		{5, 500 * time.Millisecond, 9500 * time.Millisecond},
+		{50, 5 * time.Second, 5 * time.Second},
+		{90, 9 * time.Second, time.Second},
+	} {
+		progress := Progress{Completed: snapshot.completed}
+		eta, ok := progress.ETA(total, snapshot.elapsed)
+		if !ok {
+			t.Errorf("%d done: expected an estimate", snapshot.completed)
+			continue
+		}
+		if diff := eta - snapshot.want; diff < -time.Millisecond || diff > time.Millisecond {
+			t.Errorf("%d done after %v: expected an ETA of %v, got %v",
+				snapshot.completed, snapshot.elapsed, snapshot.want, eta)
+		}
+		if got, want := progress.Percent(total), float64(snapshot.completed); got != want {
+			t.Errorf("%d done: expected %.0f%%, got %.1f%%", snapshot.completed, want, got)
+		}
+	}
+
+	if eta, ok := (Progress{Completed: total}).ETA(total, time.Minute); !ok || eta != 0 {
+		t.Errorf("Expected no time left once done, got %v (%v)", eta, ok)
+	}
+}
+
+func TestCategoryTimeouts(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Auction.AuctionTimeout = 10 * time.Second
+	cfg.Auction.CategoryTimeouts = map[string]time.Duration{
+		string(models.CategoryArt):   30 * time.Second,
+		string(models.CategoryBooks): 5 * time.Second,
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Expected category timeouts to be valid, got %v", err)
+	}
+	manager := NewManager(cfg)
+
+	expected := map[models.Category]time.Duration{
+		models.CategoryArt:         30 * time.Second,
+		models.CategoryBooks:       5 * time.Second,
+		models.CategoryElectronics: 10 * time.Second, // No override
+	}
+	id := 0
+	for category, timeout := range expected {
+		id++
+		auc := manager.NewAuction(id, models.AuctionItem{ID: id, Category: category, BasePrice: 100})
+		if auc.Timeout != timeout {
+			t.Errorf("%s: expected timeout %v, got %v", category, timeout, auc.Timeout)
+		}
+	}
+
+	cfg.Auction.CategoryTimeouts[string(models.CategoryBooks)] = 0
+	if err := cfg.Validate(); err == nil {
+		t.Error("Expected a zero category timeout to be rejected")
+	}
+}
+
+// runRetained runs a large deterministic simulation and returns the heap
+// still in use with its results held, and the manager holding them
+func runRetained(compact bool) (uint64, *Manager) {
+	cfg := config.DefaultConfig()
+	cfg.Auction.TotalAuctions = 2000
+
+	manager := NewManager(cfg)
+	manager.Compact = compact
+	generator := NewItemGeneratorWithSeed(42)
+	for i := range cfg.Auction.TotalAuctions {
+		auc := manager.NewAuction(i+1, generator.GenerateItem(i+1))
+		auc.Output = io.Discard
+		manager.Auctions = append(manager.Auctions, auc)
+	}
+
+	manager.StartAuctionsWith(context.Background(), func(auc *Auction, ctx context.Context) models.AuctionResult {
+		bids := make([]models.Bid, 20)
+		for i := range bids {
+			bids[i] = models.Bid{BidderID: i + 1, AuctionID: auc.ID, Amount: float64(100 + i)}
+		}
+		return auc.RunSequential(ctx, bids)
+	})
+	manager.Wait()
+
+	var m runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&m)
+	return m.HeapAlloc, manager
+}
+
+func TestCompactRunUsesLessMemory(t *testing.T) {
+	full, _ := runRetained(false)
+	compact, manager := runRetained(true)
+
+	if compact >= full {
+		t.Errorf("Expected a compact run to retain less memory: compact=%d full=%d bytes", compact, full)
+	}
+	t.Logf("Retained heap: full=%d compact=%d bytes", full, compact)
+
+	// Compact results carry the outcome and still lead to the item
+	result := manager.AggregateCompact()
+	if len(result.AuctionResults) != 2000 || result.TotalBids != 2000*20 || result.SuccessfulAuctions != 2000 {
+		t.Fatalf("Expected 2000 successful auctions with 20 bids each, got %d results, %d bids, %d successful",
+			len(result.AuctionResults), result.TotalBids, result.SuccessfulAuctions)
+	}
+	first := result.AuctionResults[0]
+	if !first.Won || first.WinnerBidderID != 20 || first.WinningAmount != 119 {
+		t.Errorf("Expected bidder 20 to win at 119, got %+v", first)
+	}
+	if item, ok := manager.Item(first.AuctionID); !ok || item.ID != first.ItemID {
+		t.Errorf("Expected to look up item #%d, got %+v (found=%v)", first.ItemID, item, ok)
+	}
+	if len(manager.Results) != 0 {
+		t.Errorf("Expected no full results in compact mode, got %d", len(manager.Results))
+	}
+}
+
+func TestReconcileBudgetsReassignsOverBudgetWins(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Bidder.Budget = 250
+	manager := NewManager(cfg)
+
+	won := func(auctionID int, bids ...models.Bid) models.AuctionResult {
+		auc := NewAuction(auctionID, models.AuctionItem{ID: auctionID}, time.Hour)
+		auc.Output = io.Discard
+		auc.bids = bids
+		manager.Auctions = append(manager.Auctions, auc)
+		return auc.determineWinner()
+	}
+	bid := func(bidderID int, amount float64) models.Bid {
+		return models.Bid{BidderID: bidderID, Amount: amount}
+	}
+
+	// Bidder 1 wins 100 + 120 + 150 + 50 = 420, over its 250 budget
+	manager.Results = []models.AuctionResult{
+		won(1, bid(1, 100), bid(2, 90), bid(3, 80)),
+		won(2, bid(1, 120), bid(3, 110)),
+		won(3, bid(1, 150), bid(2, 60)),
+		won(4, bid(1, 50)),
+	}
+
+	if dropped := manager.ReconcileBudgets(); dropped != 3 {
+		t.Fatalf("Expected 3 wins dropped, got %d", dropped)
+	}
+
+	// Its lowest wins (50, 100, then 120) go to the runner-up or no one
+	expected := []struct {
+		winner   int
+		runnerUp int
+		status   models.AuctionStatus
+	}{
+		{2, 3, models.StatusCompleted},
+		{3, 0, models.StatusCompleted},
+		{1, 2, models.StatusCompleted},
+		{0, 0, models.StatusOverBudget},
+	}
+	for i, want := range expected {
+		result := manager.Results[i]
+		winner, runnerUp := 0, 0
+		if result.WinningBid != nil {
+			winner = result.WinningBid.BidderID
+		}
+		if result.RunnerUp != nil {
+			runnerUp = result.RunnerUp.BidderID
+		}
+		if winner != want.winner || runnerUp != want.runnerUp || result.Status != want.status {
+			t.Errorf("Auction #%d: expected winner %d, runner-up %d, %s; got %d, %d, %s",
+				result.AuctionID, want.winner, want.runnerUp, want.status, winner, runnerUp, result.Status)
+		}
+	}
+
+	spent := 0.0
+	for _, result := range manager.Results {
+		if result.WinningBid != nil && result.WinningBid.BidderID == 1 {
+			spent += result.WinningBid.Amount
+		}
+	}
+	if spent > cfg.Bidder.Budget {
+		t.Errorf("Expected bidder 1 within its budget, spent %.2f", spent)
+	}
+}
+
+// proportionalManager returns a manager with budget whose results are the
+// proportional-share auctions of bids, one auction per entry
+func proportionalManager(budget float64, auctions ...[]models.Bid) *Manager {
+	cfg := config.DefaultConfig()
+	cfg.Bidder.Budget = budget
+	manager := NewManager(cfg)
+	for i, bids := range auctions {
+		auc := NewAuction(i+1, models.AuctionItem{ID: i + 1}, time.Hour)
+		auc.Type = config.ProportionalShare
+		auc.bids = bids
+		manager.Auctions = append(manager.Auctions, auc)
+		manager.Results = append(manager.Results, auc.determineWinner())
+	}
+	return manager
+}
+
+func TestReconcileBudgetsReshares(t *testing.T) {
+	bid := func(bidderID int, amount float64) models.Bid {
+		return models.Bid{BidderID: bidderID, Amount: amount}
+	}
+
+	// Bidder 1 pays 200 + 100, over its 250 budget; its cheaper share goes
+	manager := proportionalManager(250,
+		[]models.Bid{bid(1, 200), bid(2, 100)},
+		[]models.Bid{bid(1, 100), bid(3, 50)},
+	)
+	if dropped := manager.ReconcileBudgets(); dropped != 1 {
+		t.Fatalf("Expected 1 share dropped, got %d", dropped)
+	}
+
+	second := manager.Results[1]
+	if len(second.Shares) != 1 || second.Shares[3] != 1 {
+		t.Errorf("Expected bidder 3 to get the whole item, got shares %v", second.Shares)
+	}
+	if second.WinningBid == nil || second.WinningBid.BidderID != 3 || second.RunnerUp != nil {
+		t.Errorf("Expected bidder 3 to win alone, got %+v / %+v", second.WinningBid, second.RunnerUp)
+	}
+	if first := manager.Results[0]; first.Shares[1] != 200.0/300 {
+		t.Errorf("Expected the first auction untouched, got shares %v", first.Shares)
+	}
+}
+
+func TestReconcileBudgetsChargesEveryShare(t *testing.T) {
+	bid := func(bidderID int, amount float64) models.Bid {
+		return models.Bid{BidderID: bidderID, Amount: amount}
+	}
+
+	// Bidder 1 wins neither auction outright, but its shares cost 100 + 200
+	manager := proportionalManager(250,
+		[]models.Bid{bid(1, 100), bid(2, 150)},
+		[]models.Bid{bid(1, 200), bid(3, 240)},
+	)
+	if dropped := manager.ReconcileBudgets(); dropped != 1 {
+		t.Fatalf("Expected bidder 1's cheaper share dropped, got %d drops", dropped)
+	}
+
+	first := manager.Results[0]
+	if _, ok := first.Shares[1]; ok || first.Shares[2] != 1 {
+		t.Errorf("Expected bidder 1's share given to bidder 2, got shares %v", first.Shares)
+	}
+	if second := manager.Results[1]; second.Shares[1] != 200.0/440 {
+		t.Errorf("Expected bidder 1 to keep its affordable share, got shares %v", second.Shares)
+	}
+}
@@ -0,0 +1,81 @@
+package auction
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/vineetjain1712/auction-simulator/config"
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+// ReplayBidLog reruns winner determination on the bids of an exported bid
+// log (see models.BidLog), under the auction settings of cfg, without
+// re-simulating
+// Whether interested bidders missed the window is not logged, so replayed
+// auctions without bids are always models.StatusNoBids. Nor is when
+// collection ended, so each auction's logged duration counts as collection
+// and its DeterminationDuration is zero.
+func ReplayBidLog(path string, cfg *config.Config) (models.SimulationResult, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return models.SimulationResult{}, fmt.Errorf("failed to open bid log: %w", err)
+	}
+	defer file.Close()
+
+	var log models.BidLog
+	if err := json.NewDecoder(file).Decode(&log); err != nil {
+		return models.SimulationResult{}, fmt.Errorf("failed to decode bid log: %w", err)
+	}
+
+	return replay(log, cfg)
+}
+
+// replay reconstructs each auction of log with its bids and determines its
+// winner
+func replay(log models.BidLog, cfg *config.Config) (models.SimulationResult, error) {
+	manager := NewManager(cfg)
+
+	byID := make(map[int]*Auction, len(log.Auctions))
+	for _, entry := range log.Auctions {
+		if _, ok := byID[entry.AuctionID]; ok {
+			return models.SimulationResult{}, fmt.Errorf("auction %d logged twice", entry.AuctionID)
+		}
+		auc := manager.NewAuction(entry.AuctionID, entry.Item)
+		auc.Output = io.Discard
+		auc.startTime, auc.endTime = entry.StartTime, entry.EndTime
+		auc.closed = true
+		byID[entry.AuctionID] = auc
+		manager.Auctions = append(manager.Auctions, auc)
+
+		if manager.StartTime.IsZero() || entry.StartTime.Before(manager.StartTime) {
+			manager.StartTime = entry.StartTime
+		}
+		if entry.EndTime.After(manager.EndTime) {
+			manager.EndTime = entry.EndTime
+		}
+	}
+
+	for _, bid := range log.Bids {
+		auc, ok := byID[bid.AuctionID]
+		if !ok {
+			return models.SimulationResult{}, fmt.Errorf("bid from bidder %d for unknown auction %d",
+				bid.BidderID, bid.AuctionID)
+		}
+		auc.bids = append(auc.bids, bid)
+		if auc.isBuyNow(bid) {
+			auc.boughtNow = true
+		}
+	}
+
+	for _, auc := range manager.Auctions {
+		result := auc.determineWinner()
+		result.CollectionDuration = result.Duration
+		manager.Results = append(manager.Results, result)
+	}
+
+	result := manager.AggregateResults()
+	result.TotalAuctions = len(manager.Auctions)
+	return result, nil
+}
@@ -0,0 +1,66 @@
+package auction
+
+import (
+	"context"
+	"time"
+
+	"github.com/vineetjain1712/auction-simulator/internal/clock"
+)
+
+// collectRounds collects bids in Rounds consecutive rounds of equal length
+// until the auction closes, at most one bid per bidder per round
+// Bids sent during a round but still buffered at its end count towards it.
+// The high bid carries forward: the winner is picked over all rounds.
+func (a *Auction) collectRounds(ctx context.Context) {
+	rounds := a.rounds()
+	length := a.Timeout / time.Duration(rounds)
+	for round := 1; round <= rounds; round++ {
+		// The last round runs to the auction's own deadline
+		roundCtx, cancel := ctx, context.CancelFunc(func() {})
+		if round < rounds {
+			roundCtx, cancel = clock.WithTimeout(ctx, a.Clock, length)
+		}
+		a.enterRound(round)
+		a.collectBids(roundCtx)
+		cancel()
+
+		if ctx.Err() != nil || roundCtx.Err() == nil {
+			// Timed out, bought now, or the bid channel was closed
+			return
+		}
+	}
+}
+
+// rounds returns the number of rounds, at least 1
+func (a *Auction) rounds() int {
+	return max(a.Rounds, 1)
+}
+
+// roundAt returns the round that t falls in, clamped to the rounds there are
+func (a *Auction) roundAt(t time.Time) int {
+	rounds := a.rounds()
+	length := a.Timeout / time.Duration(rounds)
+	if length <= 0 {
+		return rounds
+	}
+	return min(max(int(t.Sub(a.startTime)/length)+1, 1), rounds)
+}
+
+// enterRound makes round the current round, so every bidder may bid again
+func (a *Auction) enterRound(round int) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if round != a.round {
+		a.round = round
+		a.roundBidders = make(map[int]bool)
+	}
+}
+
+// Round returns the current round of an auction collecting in rounds, from
+// 1 (0 before it starts or in other collection modes)
+// Safe to call while the auction runs.
+func (a *Auction) Round() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.round
+}
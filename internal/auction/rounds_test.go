@@ -0,0 +1,109 @@
+package auction
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/vineetjain1712/auction-simulator/config"
+	"github.com/vineetjain1712/auction-simulator/internal/clock"
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+func TestCollectInRounds(t *testing.T) {
+	const rounds, bidders = 3, 3
+	const length = 100 * time.Millisecond
+
+	fake := clock.NewFake(time.Unix(0, 0))
+	auction := NewAuction(1, models.AuctionItem{ID: 1, BasePrice: 100}, rounds*length)
+	auction.Output = io.Discard
+	auction.Clock = fake
+	auction.CollectionMode = config.CollectInRounds
+	auction.Rounds = rounds
+	auction.Decisions = &DecisionRecorder{}
+
+	done := make(chan models.AuctionResult)
+	go func() {
+		done <- auction.Run(context.Background())
+	}()
+
+	// waitFor polls until cond holds, as the auction runs on its own goroutine
+	waitFor := func(what string, cond func() bool) {
+		t.Helper()
+		for deadline := time.Now().Add(5 * time.Second); !cond(); time.Sleep(time.Millisecond) {
+			if time.Now().After(deadline) {
+				t.Fatalf("Timed out waiting for %s", what)
+			}
+		}
+	}
+
+	for round := 1; round <= rounds; round++ {
+		waitFor("the next round", func() bool { return auction.Round() == round })
+
+		// Every bidder raises each round, and bidder 1 tries a second bid
+		for id := 1; id <= bidders; id++ {
+			amount := float64(100*round + id)
+			auction.SubmitBid(context.Background(), models.Bid{BidderID: id, AuctionID: 1, Amount: amount, Timestamp: fake.Now()})
+		}
+		auction.SubmitBid(context.Background(), models.Bid{BidderID: 1, AuctionID: 1, Amount: 1000, Timestamp: fake.Now()})
+
+		waitFor("the round's bids", func() bool { return len(auction.Decisions.Decisions()) == round*(bidders+1) })
+		fake.Advance(length)
+	}
+	result := <-done
+
+	if result.TotalBids != rounds*bidders {
+		t.Fatalf("Expected one bid per bidder per round (%d), got %d", rounds*bidders, result.TotalBids)
+	}
+	perRound := make(map[int]int)
+	for _, bid := range result.Bids {
+		perRound[bid.Round]++
+	}
+	for round := 1; round <= rounds; round++ {
+		if perRound[round] != bidders {
+			t.Errorf("Round %d: expected %d bids, got %d", round, bidders, perRound[round])
+		}
+	}
+
+	repeats := 0
+	for _, decision := range auction.Decisions.Decisions() {
+		if decision.Reason == models.ReasonRepeatInRound {
+			repeats++
+		}
+	}
+	if repeats != rounds {
+		t.Errorf("Expected bidder 1's second bid turned away each round, got %d repeats", repeats)
+	}
+
+	// The high bid carries over the rounds: the last round's highest wins
+	if result.WinningBid == nil || result.WinningBid.BidderID != bidders || result.WinningBid.Amount != 303 {
+		t.Errorf("Expected bidder %d to win with 303, got %+v", bidders, result.WinningBid)
+	}
+}
+
+func TestRunSequentialInRounds(t *testing.T) {
+	start := time.Unix(0, 0)
+	auction := NewAuction(1, models.AuctionItem{ID: 1, BasePrice: 100}, 300*time.Millisecond)
+	auction.Output = io.Discard
+	auction.Clock = clock.NewFake(start)
+	auction.CollectionMode = config.CollectInRounds
+	auction.Rounds = 3
+
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+	result := auction.RunSequential(context.Background(), []models.Bid{
+		{BidderID: 1, Amount: 110, Timestamp: at(10)},
+		{BidderID: 1, Amount: 120, Timestamp: at(50)},  // Same round: turned away
+		{BidderID: 1, Amount: 130, Timestamp: at(150)}, // Round 2
+		{BidderID: 2, Amount: 140, Timestamp: at(250)}, // Round 3
+	})
+
+	if result.TotalBids != 3 {
+		t.Fatalf("Expected 3 bids taken, got %d", result.TotalBids)
+	}
+	for i, want := range []int{1, 2, 3} {
+		if result.Bids[i].Round != want {
+			t.Errorf("Bid %d: expected round %d, got %d", i, want, result.Bids[i].Round)
+		}
+	}
+}
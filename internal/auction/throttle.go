@@ -0,0 +1,92 @@
+package auction
+
+import (
+	"context"
+	"sync"
+)
+
+// MemoryThrottle limits how many auctions run at once under a memory budget
+// It adapts the limit on every launch and completion: the limit halves while
+// memory is above the ceiling and grows by one while it is below, between
+// Min and Max. The limit starts at Min, so a run over budget from the start
+// never bursts.
+type MemoryThrottle struct {
+	CeilingMB float64        // Memory budget in MB
+	MemoryMB  func() float64 // Reads current memory use, e.g. from a ResourceMonitor
+	Min       int            // Lowest limit (values below 1 mean 1)
+	Max       int            // Highest limit (0 = unbounded)
+
+	mu      sync.Mutex
+	limit   int
+	active  int
+	changed chan struct{} // Closed and replaced whenever a slot frees up
+}
+
+// NewMemoryThrottle creates a throttle keeping memory, as read by memoryMB,
+// near ceilingMB
+func NewMemoryThrottle(ceilingMB float64, memoryMB func() float64) *MemoryThrottle {
+	return &MemoryThrottle{
+		CeilingMB: ceilingMB,
+		MemoryMB:  memoryMB,
+		Min:       1,
+	}
+}
+
+// Acquire blocks until the auction may run, then takes a slot that must be
+// returned with Release
+// Returns false without taking a slot if ctx ends first.
+func (t *MemoryThrottle) Acquire(ctx context.Context) bool {
+	for {
+		t.mu.Lock()
+		t.adjust()
+		if t.active < t.limit {
+			t.active++
+			t.mu.Unlock()
+			return true
+		}
+		if t.changed == nil {
+			t.changed = make(chan struct{})
+		}
+		changed := t.changed
+		t.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+// Release returns a slot taken by Acquire
+func (t *MemoryThrottle) Release() {
+	t.mu.Lock()
+	t.active--
+	t.adjust()
+	if t.changed != nil {
+		close(t.changed)
+		t.changed = nil
+	}
+	t.mu.Unlock()
+}
+
+// Limit returns the current concurrency limit
+func (t *MemoryThrottle) Limit() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.limit
+}
+
+// adjust moves the limit towards the memory budget; caller must hold mu
+func (t *MemoryThrottle) adjust() {
+	lowest := max(t.Min, 1)
+	if t.limit < lowest {
+		t.limit = lowest
+	}
+
+	if t.MemoryMB() > t.CeilingMB {
+		t.limit = max(t.limit/2, lowest)
+	} else if t.Max == 0 || t.limit < t.Max {
+		t.limit++
+	}
+}
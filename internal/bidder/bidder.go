@@ -4,54 +4,303 @@ package bidder
 
 import (
 	"context"
+	"encoding/binary"
+	"hash/fnv"
+	"log/slog"
+	"math"
 	"math/rand"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"golang.org/x/time/rate"
+
 	"github.com/vineetjain1712/auction-simulator/config"
+	"github.com/vineetjain1712/auction-simulator/internal/auction"
+	"github.com/vineetjain1712/auction-simulator/internal/clock"
+	"github.com/vineetjain1712/auction-simulator/internal/logging"
 	"github.com/vineetjain1712/auction-simulator/internal/models"
 )
 
 // Bidder represents a simulated bidder
 type Bidder struct {
-	ID     int
+	ID      int
+	Segment string // Market segment for reporting, e.g. "retail" ("" if unassigned)
+
+	// BidProbability is this bidder's chance to bid: the configured
+	// BidProbability, varied per bidder by ProbabilitySpread
+	BidProbability float64
+
+	// Logger receives debug logs of the bidder's participation, tagged
+	// with its ID and the auction ID
+	Logger *slog.Logger
+
 	config *config.BidderConfig
 	rand   *rand.Rand
 	mu     sync.Mutex // Protects rand for thread-safety
+	seed   int64      // Seed of rand, also keys the private valuations
+
+	// Limits how fast this bidder submits bids across all of its
+	// concurrent auctions (nil = unlimited)
+	limiter *rate.Limiter
+
+	// Decisions to bid abandoned through DropoutProbability
+	dropouts atomic.Int64
+
+	// calculate computes bid amounts when participating from a fraction of
+	// the bid range; tests replace it to observe the calls (nil = bidAt)
+	calculate func(item models.AuctionItem, reserve, fraction float64) float64
 }
 
 // NewBidder creates a new bidder with given ID
 func NewBidder(id int, cfg *config.BidderConfig) *Bidder {
 	// Each bidder gets its own random source for thread safety
-	source := rand.NewSource(time.Now().UnixNano() + int64(id))
-	return &Bidder{
+	return NewBidderWithSeed(id, cfg, time.Now().UnixNano()+int64(id))
+}
+
+// NewBidderWithSeed creates a new bidder whose decisions and valuations are
+// derived from seed
+func NewBidderWithSeed(id int, cfg *config.BidderConfig, seed int64) *Bidder {
+	b := &Bidder{
 		ID:     id,
+		Logger: logging.Discard(),
 		config: cfg,
-		rand:   rand.New(source),
+		rand:   rand.New(rand.NewSource(seed)),
+		seed:   seed,
+	}
+
+	if cfg.BidRate > 0 {
+		b.limiter = rate.NewLimiter(rate.Limit(cfg.BidRate), 1)
+	}
+	b.BidProbability = b.sampleBidProbability()
+
+	return b
+}
+
+// Seed returns the seed the bidder's decisions and valuations derive from
+// NewBidderWithSeed with it and the same configuration replays the bidder
+// in isolation.
+func (b *Bidder) Seed() int64 {
+	return b.seed
+}
+
+// sampleBidProbability draws the bidder's bid probability uniformly within
+// ProbabilitySpread of the configured BidProbability, clamped to [0, 1]
+// It is derived from the seed alone, so it does not consume the bidder's
+// random source.
+func (b *Bidder) sampleBidProbability() float64 {
+	if b.config.ProbabilitySpread <= 0 {
+		return b.config.BidProbability
 	}
+
+	h := fnv.New64a()
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], uint64(b.seed))
+	h.Write(buf[:])
+	h.Write([]byte("probability"))
+	noise := float64(h.Sum64()>>11) / (1 << 53)
+
+	p := b.config.BidProbability + (2*noise-1)*b.config.ProbabilitySpread
+	return min(max(p, 0), 1)
 }
 
 // DecideIfBid determines if this bidder wants to bid on an item
 // Returns true if bidder decides to bid, false otherwise
 func (b *Bidder) DecideIfBid(item models.AuctionItem) bool {
+	return b.DecideIfBidAbove(item, 0)
+}
+
+// DecideIfBidAbove is DecideIfBid for an auction with a known reserve
+// A bidder never bids if it values the item, net of shipping, below the
+// reserve
+func (b *Bidder) DecideIfBidAbove(item models.AuctionItem, reserve float64) bool {
+	if _, maxBid := b.bidRange(item, reserve); reserve > maxBid {
+		return false
+	}
+
 	// Random decision based on bid probability
 	// E.g., if BidProbability is 0.3, there's 30% chance to bid
 	b.mu.Lock()
-	decision := b.rand.Float64() < b.config.BidProbability
+	decision := b.rand.Float64() < b.bidProbability(item)
 	b.mu.Unlock()
 	return decision
 }
 
+// dropsOut reports whether the bidder, having decided to bid, drops out
+// before sending the bid, counting the dropout if so
+func (b *Bidder) dropsOut() bool {
+	if b.config.DropoutProbability <= 0 {
+		return false
+	}
+
+	b.mu.Lock()
+	dropped := b.rand.Float64() < b.config.DropoutProbability
+	b.mu.Unlock()
+
+	if dropped {
+		b.dropouts.Add(1)
+	}
+	return dropped
+}
+
+// Dropouts returns how many times the bidder decided to bid but dropped out
+// before sending the bid
+func (b *Bidder) Dropouts() int {
+	return int(b.dropouts.Load())
+}
+
+// bidProbability returns the chance that the bidder bids on item: its
+// BidProbability, boosted by HotBidMultiplier for hot items
+func (b *Bidder) bidProbability(item models.AuctionItem) float64 {
+	if item.Hot && b.config.HotBidMultiplier > 0 {
+		return min(b.BidProbability*b.config.HotBidMultiplier, 1)
+	}
+	return b.BidProbability
+}
+
+// Valuation returns the bidder's private valuation of an item: the most it
+// would ever pay. It lies between the item's base price scaled by
+// MinBidMultiplier and MaxBidMultiplier, with bidder-specific noise derived
+// deterministically from the bidder's seed and the item
+func (b *Bidder) Valuation(item models.AuctionItem) float64 {
+	multiplier := b.config.MinBidMultiplier +
+		b.itemNoise(item, "")*(b.config.MaxBidMultiplier-b.config.MinBidMultiplier)
+	return item.BasePrice * multiplier
+}
+
+// itemNoise returns a uniform value in [0, 1) derived deterministically
+// from the bidder's seed, item and salt, so each use of the noise can have
+// its own stream
+func (b *Bidder) itemNoise(item models.AuctionItem, salt string) float64 {
+	h := fnv.New64a()
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], uint64(b.seed))
+	h.Write(buf[:])
+	binary.LittleEndian.PutUint64(buf[:], uint64(item.ID))
+	h.Write(buf[:])
+	h.Write([]byte(item.Name))
+	h.Write([]byte(salt))
+
+	// Top 53 bits of the hash as a uniform value in [0, 1)
+	return float64(h.Sum64()>>11) / (1 << 53)
+}
+
+// maxTieJitter is the most TieJitter takes off a bid
+const maxTieJitter = 0.01
+
+// jitter takes a tiny amount, below maxTieJitter and derived from the
+// bidder's seed and item, off amount if TieJitter is enabled, so bidders
+// never bid exactly the same amount
+// The bid stays within the valuation and never drops below reserve.
+func (b *Bidder) jitter(item models.AuctionItem, amount, reserve float64) float64 {
+	if !b.config.TieJitter {
+		return amount
+	}
+	return max(amount-b.itemNoise(item, "jitter")*maxTieJitter, reserve)
+}
+
+// ShippingCost estimates what the bidder would pay to ship item, from its
+// ShipWeight and the configured ShippingCostPerKg
+func (b *Bidder) ShippingCost(item models.AuctionItem) float64 {
+	return item.ShipWeight * b.config.ShippingCostPerKg
+}
+
 // CalculateBidAmount determines how much to bid
-// Based on the item's base price and configured multipliers
+// Based on the item's base price and configured multipliers, discounted by
+// the shipping cost, and never above the bidder's valuation of the item
+// With TieJitter, a tiny bidder-specific amount is taken off to avoid ties
+// With a TickSize, the amount is rounded to the nearest tick instead
+// The amount may be zero or less if shipping costs more than the item is
+// worth to the bidder
 func (b *Bidder) CalculateBidAmount(item models.AuctionItem) float64 {
-	// Random multiplier between MinBidMultiplier and MaxBidMultiplier
+	return b.CalculateBidAmountAbove(item, 0)
+}
+
+// CalculateBidAmountAbove is CalculateBidAmount for an auction with a known
+// reserve: the bid is at least the reserve, but still never above the
+// valuation net of shipping
+func (b *Bidder) CalculateBidAmountAbove(item models.AuctionItem, reserve float64) float64 {
+	// Random amount between the minimum and maximum bid
 	b.mu.Lock()
-	multiplier := b.config.MinBidMultiplier +
-		b.rand.Float64()*(b.config.MaxBidMultiplier-b.config.MinBidMultiplier)
+	fraction := b.rand.Float64()
 	b.mu.Unlock()
 
-	return item.BasePrice * multiplier
+	return b.bidAt(item, reserve, fraction)
+}
+
+// bidAt returns the amount bid at fraction of the way from the bottom to
+// the top of the bidder's bid range
+func (b *Bidder) bidAt(item models.AuctionItem, reserve, fraction float64) float64 {
+	minBid, maxBid := b.bidRange(item, reserve)
+	return b.price(item, minBid+fraction*(maxBid-minBid), reserve)
+}
+
+// price turns a raw amount drawn from the bid range into the amount bid:
+// jittered, limited and quantized to the tick size
+func (b *Bidder) price(item models.AuctionItem, amount, reserve float64) float64 {
+	return b.quantize(item, b.limit(item, b.jitter(item, amount, reserve)), reserve)
+}
+
+// quantize rounds amount to the nearest multiple of the configured
+// TickSize
+// An amount at or above floor, or with FloorAtBasePrice the item's base
+// price, is rounded up rather than below it. One that would end up above
+// the valuation net of shipping is rounded down instead, which wins over
+// floor but not over FloorAtBasePrice; one above MaxAbsoluteBid is always
+// rounded down.
+func (b *Bidder) quantize(item models.AuctionItem, amount, floor float64) float64 {
+	tick := b.config.TickSize
+	if tick <= 0 {
+		return amount
+	}
+
+	ceiling := max(b.Valuation(item)-b.ShippingCost(item), amount)
+	if b.config.FloorAtBasePrice && amount > 0 {
+		floor = max(floor, item.BasePrice)
+		ceiling = max(ceiling, math.Ceil(item.BasePrice/tick)*tick)
+	}
+	if limit := b.config.MaxAbsoluteBid; limit > 0 {
+		ceiling = min(ceiling, limit)
+	}
+
+	ticks := math.Round(amount / tick)
+	if amount >= floor && ticks*tick < floor {
+		ticks = math.Ceil(floor / tick)
+	}
+	if ticks*tick > ceiling {
+		ticks = math.Floor(ceiling / tick)
+	}
+	return ticks * tick
+}
+
+// limit applies the bidder's configured floor and absolute cap to amount
+// The cap wins over the floor, and may take a bid below the reserve.
+func (b *Bidder) limit(item models.AuctionItem, amount float64) float64 {
+	amount = b.floorAtBase(item, amount)
+	if b.config.MaxAbsoluteBid > 0 {
+		amount = min(amount, b.config.MaxAbsoluteBid)
+	}
+	return amount
+}
+
+// floorAtBase raises a positive amount to the item's base price if the
+// bidder is configured with FloorAtBasePrice
+// Floored bids may tie even with TieJitter.
+func (b *Bidder) floorAtBase(item models.AuctionItem, amount float64) float64 {
+	if !b.config.FloorAtBasePrice || amount <= 0 {
+		return amount
+	}
+	return max(amount, item.BasePrice)
+}
+
+// bidRange returns the lowest and highest amounts the bidder would bid on
+// item given reserve: the multiplier range up to its valuation, both
+// discounted by the shipping cost to model total cost of ownership
+func (b *Bidder) bidRange(item models.AuctionItem, reserve float64) (minBid, maxBid float64) {
+	shipping := b.ShippingCost(item)
+	maxBid = b.Valuation(item) - shipping
+	minBid = min(max(item.BasePrice*b.config.MinBidMultiplier-shipping, reserve), maxBid)
+	return minBid, maxBid
 }
 
 // SimulateBidDelay simulates the time it takes for a bidder to decide and bid
@@ -66,61 +315,221 @@ func (b *Bidder) SimulateBidDelay() time.Duration {
 	return time.Duration(delayMs) * time.Millisecond
 }
 
+// SequentialBid decides, without waiting, whether and what the bidder bids
+// on auc, taken to have started at start
+// The decision, thinking time and amount come from a random source derived
+// from the bidder's seed and the auction ID only, so the outcome does not
+// depend on scheduling or on the bidder's other auctions. The thinking time
+// is simulated: it only offsets the bid's Timestamp. BidRate is not applied.
+// Returns false if the bidder is not interested, drops out or would bid too
+// late.
+func (b *Bidder) SequentialBid(auc *auction.Auction, start time.Time) (models.Bid, bool) {
+	r := rand.New(rand.NewSource(b.seed*31 + int64(auc.ID)))
+	item := auc.Item
+	reserve := auc.VisibleReserve()
+	minBid, maxBid := b.bidRange(item, reserve)
+
+	if r.Float64() >= b.bidProbability(item) || reserve > maxBid {
+		return models.Bid{}, false
+	}
+	if p := b.config.DropoutProbability; p > 0 && r.Float64() < p {
+		// Lost the connection before bidding
+		b.dropouts.Add(1)
+		return models.Bid{}, false
+	}
+
+	delayMs := b.config.BidDelayMinMs + r.Intn(b.config.BidDelayMaxMs-b.config.BidDelayMinMs+1)
+	delay := b.rush(time.Duration(delayMs)*time.Millisecond, auc.Timeout)
+	amount := b.price(item, minBid+r.Float64()*(maxBid-minBid), reserve)
+	if amount <= 0 {
+		// Shipping costs more than the item is worth to the bidder
+		return models.Bid{}, false
+	}
+	auc.RecordAttempt()
+	if delay >= auc.Timeout {
+		return models.Bid{}, false
+	}
+
+	return models.Bid{
+		BidderID:  b.ID,
+		AuctionID: auc.ID,
+		Amount:    amount,
+		Timestamp: start.Add(delay),
+		Valuation: b.Valuation(item),
+		Segment:   b.Segment,
+	}, true
+}
+
+// expired reports whether an auction with deadline (zero = unknown) is over
+// at clk's current time, or ctx has ended
+// The deadline catches an auction on a simulated clock whose timeout fires
+// at the same instant as the bidder's timer, before ctx has been cancelled.
+func expired(ctx context.Context, clk clock.Clock, deadline time.Time) bool {
+	return ctx.Err() != nil || (!deadline.IsZero() && !clk.Now().Before(deadline))
+}
+
+// rush cuts delay to half of remaining if the bidder is configured with
+// RushBeforeDeadline and delay would not end before remaining runs out
+func (b *Bidder) rush(delay, remaining time.Duration) time.Duration {
+	if !b.config.RushBeforeDeadline || delay < remaining {
+		return delay
+	}
+	return max(remaining/2, 0)
+}
+
 // ParticipateInAuction simulates a bidder participating in an auction
 // It receives auction details, decides whether to bid, and sends bid if interested
+// The auction's deadline is ctx's deadline, if any.
 func (b *Bidder) ParticipateInAuction(
 	ctx context.Context,
 	auctionID int,
 	item models.AuctionItem,
 	bidChannel chan<- models.Bid,
 ) {
+	deadline, _ := ctx.Deadline()
+	b.participate(ctx, b.Logger, clock.Real(), nil, auctionID, item, 0, deadline, nil, func(ctx context.Context, bid models.Bid) bool {
+		select {
+		case bidChannel <- bid:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	})
+}
+
+// Participate simulates a bidder participating in auc, submitting any bid
+// through the auction's collection mode and timing it on the auction's clock
+// A public reserve keeps the bidder from bidding below it. A decision to bid
+// is recorded on the auction even if the bid then arrives too late.
+func (b *Bidder) Participate(ctx context.Context, auc *auction.Auction) {
+	b.participateLogged(ctx, b.Logger, auc, nil)
+}
+
+// participateLogged is Participate logging to logger instead of b.Logger,
+// calling waiting (if not nil) as in participate
+func (b *Bidder) participateLogged(ctx context.Context, logger *slog.Logger, auc *auction.Auction, waiting func()) {
+	// An auction not started yet runs its full timeout from about now
+	deadline, started, _ := auc.BidWindow()
+	if !started {
+		deadline = auc.Clock.Now().Add(auc.Timeout)
+	}
+	b.participate(ctx, logger, auc.Clock, waiting, auc.ID, auc.Item, auc.VisibleReserve(), deadline, auc.RecordAttempt, auc.SubmitBid)
+}
+
+// participate decides whether to bid on item and, if interested and not
+// dropped out, reports the attempt (if attempt is not nil) and submits a bid
+// of at least reserve after the bidder's thinking time on clk unless ctx
+// ends first
+// The thinking time is measured against deadline (zero = unknown) for
+// RushBeforeDeadline. Once the thinking time has started, waiting is called (if not nil). Each
+// step is logged to logger at debug level.
+func (b *Bidder) participate(
+	ctx context.Context,
+	logger *slog.Logger,
+	clk clock.Clock,
+	waiting func(),
+	auctionID int,
+	item models.AuctionItem,
+	reserve float64,
+	deadline time.Time,
+	attempt func(),
+	submit func(context.Context, models.Bid) bool,
+) {
+	logger = logger.With("bidder_id", b.ID, "auction_id", auctionID)
+
 	// First, decide if this bidder is interested
-	if !b.DecideIfBid(item) {
+	if !b.DecideIfBidAbove(item, reserve) {
 		// Not interested, don't bid
 		return
 	}
+	logger.DebugContext(ctx, "bidder decided to bid")
+	if b.dropsOut() {
+		// Lost the connection before bidding; the auction never hears of it
+		logger.DebugContext(ctx, "bid dropped", "reason", "dropout")
+		return
+	}
+
+	// Only a bidder with something to bid counts as an attempt: the top of
+	// its range, net of shipping, must be positive. The amount itself is
+	// computed once the bid can still arrive in time.
+	if _, maxBid := b.bidRange(item, reserve); maxBid <= 0 {
+		// Shipping costs more than the item is worth to the bidder
+		logger.DebugContext(ctx, "bid dropped", "reason", "shipping exceeds value")
+		return
+	}
+	b.mu.Lock()
+	fraction := b.rand.Float64()
+	b.mu.Unlock()
+	if attempt != nil {
+		attempt()
+	}
 
 	// Simulate thinking time
 	delay := b.SimulateBidDelay()
+	if !deadline.IsZero() {
+		delay = b.rush(delay, deadline.Sub(clk.Now()))
+	}
+	logger.DebugContext(ctx, "bid delay chosen", "delay", delay)
 
 	// Create a timer for the delay
-	timer := time.NewTimer(delay)
+	timer := clk.NewTimer(delay)
 	defer timer.Stop()
+	if waiting != nil {
+		waiting()
+	}
 
 	// Wait for either delay or context cancellation
 	select {
-	case <-timer.C:
+	case <-timer.C():
 		// Delay complete - check if auction is still active
-		select {
-		case <-ctx.Done():
+		if expired(ctx, clk, deadline) {
 			// Auction closed during our delay
+			logger.DebugContext(ctx, "bid dropped", "reason", "auction closed")
 			return
-		default:
-			// Auction still active, proceed with bid
 		}
 
-		// Calculate bid amount
-		amount := b.CalculateBidAmount(item)
+		// Respect the bidder's submission rate, shared across its auctions
+		if b.limiter != nil {
+			if err := b.limiter.Wait(ctx); err != nil || expired(ctx, clk, deadline) {
+				// Auction closed (or would close) before a token was available
+				logger.DebugContext(ctx, "bid dropped", "reason", "rate limited")
+				return
+			}
+		}
+
+		// Calculate bid amount, now that the bid can still arrive in time
+		calculate := b.calculate
+		if calculate == nil {
+			calculate = b.bidAt
+		}
+		amount := calculate(item, reserve, fraction)
+		if amount <= 0 {
+			// Shipping costs more than the bid drawn from the range
+			logger.DebugContext(ctx, "bid dropped", "reason", "shipping exceeds value")
+			return
+		}
 
 		// Create the bid
 		bid := models.Bid{
 			BidderID:  b.ID,
 			AuctionID: auctionID,
 			Amount:    amount,
-			Timestamp: time.Now(),
+			Timestamp: clk.Now(),
+			Valuation: b.Valuation(item),
+			Segment:   b.Segment,
 		}
 
-		// Try to send the bid, but respect context
-		select {
-		case bidChannel <- bid:
-			// Bid sent successfully
-		case <-ctx.Done():
-			// Auction closed while we were trying to send
-			return
+		// Try to submit the bid, but respect context
+		// A false return means the auction closed while we were submitting
+		if submit(ctx, bid) {
+			logger.DebugContext(ctx, "bid sent", "amount", amount)
+		} else {
+			logger.DebugContext(ctx, "bid dropped", "reason", "auction closed")
 		}
 
 	case <-ctx.Done():
 		// Auction closed during our thinking time
+		logger.DebugContext(ctx, "bid dropped", "reason", "auction closed")
 		return
 	}
 }
@@ -1,10 +1,19 @@
 package bidder
 
 import (
+	"context"
+	"io"
+	"math"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/vineetjain1712/auction-simulator/config"
+	"github.com/vineetjain1712/auction-simulator/internal/auction"
+	"github.com/vineetjain1712/auction-simulator/internal/clock"
+	"github.com/vineetjain1712/auction-simulator/internal/logging"
 	"github.com/vineetjain1712/auction-simulator/internal/models"
 )
 
@@ -72,6 +81,164 @@ func TestCalculateBidAmount(t *testing.T) {
 	}
 }
 
+func TestFloorAtBasePrice(t *testing.T) {
+	below := func(floor bool) int {
+		cfg := config.DefaultConfig()
+		cfg.Bidder.MinBidMultiplier = 0.5
+		cfg.Bidder.MaxBidMultiplier = 1.2
+		cfg.Bidder.FloorAtBasePrice = floor
+
+		bidder := NewBidderWithSeed(1, &cfg.Bidder, 9)
+		count := 0
+		for id := 1; id <= 100; id++ {
+			if bidder.CalculateBidAmount(models.AuctionItem{ID: id, BasePrice: 100}) < 100 {
+				count++
+			}
+		}
+		return count
+	}
+
+	if n := below(false); n == 0 {
+		t.Fatal("Expected some bids below base price with a 0.5 min multiplier")
+	}
+	if n := below(true); n != 0 {
+		t.Errorf("Expected no bids below base price with the floor, got %d", n)
+	}
+}
+
+func TestMaxAbsoluteBid(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Bidder.MaxAbsoluteBid = 50
+	cfg.Bidder.FloorAtBasePrice = true
+
+	bidder := NewBidderWithSeed(1, &cfg.Bidder, 9)
+	for id := 1; id <= 100; id++ {
+		item := models.AuctionItem{ID: id, BasePrice: 1000}
+		if amount := bidder.CalculateBidAmount(item); amount > 50 {
+			t.Fatalf("Bid %.2f on a %.2f item exceeds the absolute cap of 50", amount, item.BasePrice)
+		}
+	}
+}
+
+func TestTickSize(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Bidder.TickSize = 0.5
+	cfg.Bidder.TieJitter = true
+
+	bidder := NewBidderWithSeed(1, &cfg.Bidder, 9)
+	for id := 1; id <= 100; id++ {
+		item := models.AuctionItem{ID: id, BasePrice: 37.13}
+		amount := bidder.CalculateBidAmount(item)
+		if ticks := amount / 0.5; ticks != math.Trunc(ticks) {
+			t.Fatalf("Bid %v on item %d is not a multiple of the 0.5 tick", amount, id)
+		}
+	}
+
+	// Deterministic runs bid on the tick too, and never below a public
+	// reserve or the base price floor
+	cfg.Bidder.FloorAtBasePrice = true
+	cfg.Bidder.MinBidMultiplier = 0.5
+	cfg.Bidder.BidProbability = 1
+	cfg.Bidder.TotalBidders = 20
+	pool := NewPool(&cfg.Bidder, WithSeed(9))
+	for id := 1; id <= 20; id++ {
+		auc := auction.NewAuction(id, models.AuctionItem{ID: id, BasePrice: 37.13}, time.Second)
+		auc.Reserve = 40.2
+		auc.PublicReserve = id%2 == 0
+		bids := pool.SequentialBids(auc)
+		if len(bids) == 0 {
+			t.Fatalf("Auction %d: expected sequential bids", id)
+		}
+		for _, bid := range bids {
+			if ticks := bid.Amount / 0.5; ticks != math.Trunc(ticks) {
+				t.Fatalf("Sequential bid %v is not a multiple of the 0.5 tick", bid.Amount)
+			}
+			if bid.Amount < max(37.13, auc.VisibleReserve()) {
+				t.Fatalf("Sequential bid %v was rounded below the base price or public reserve", bid.Amount)
+			}
+		}
+	}
+
+	cfg.Bidder.MaxAbsoluteBid = 20.3
+	for id := 1; id <= 100; id++ {
+		if amount := bidder.CalculateBidAmount(models.AuctionItem{ID: id, BasePrice: 100}); amount != 20 {
+			t.Fatalf("Expected capped bids rounded down to 20, got %v", amount)
+		}
+	}
+}
+
+func TestBidNeverExceedsValuation(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Bidder.MinBidMultiplier = 1.0
+	cfg.Bidder.MaxBidMultiplier = 2.0
+
+	bidder := NewBidder(1, &cfg.Bidder)
+
+	for id := 1; id <= 50; id++ {
+		item := models.AuctionItem{ID: id, BasePrice: 100.0}
+
+		valuation := bidder.Valuation(item)
+		if valuation < 100.0 || valuation > 200.0 {
+			t.Errorf("Item %d: expected valuation between 100-200, got %.2f", id, valuation)
+		}
+		if again := bidder.Valuation(item); again != valuation {
+			t.Errorf("Item %d: valuation changed between calls: %.2f then %.2f", id, valuation, again)
+		}
+
+		for i := 0; i < 20; i++ {
+			if amount := bidder.CalculateBidAmount(item); amount > valuation {
+				t.Fatalf("Item %d: bid %.2f exceeds valuation %.2f", id, amount, valuation)
+			}
+		}
+	}
+
+	// Rounding to a coarse tick rounds down rather than past the valuation
+	cfg.Bidder.TickSize = 7
+	ticked := NewBidder(2, &cfg.Bidder)
+	for id := 1; id <= 50; id++ {
+		item := models.AuctionItem{ID: id, BasePrice: 100.0}
+		valuation := ticked.Valuation(item)
+		for i := 0; i < 20; i++ {
+			amount := ticked.CalculateBidAmount(item)
+			if amount > valuation {
+				t.Fatalf("Item %d: bid %.2f on a 7 tick exceeds valuation %.2f", id, amount, valuation)
+			}
+			if ticks := amount / 7; ticks != math.Trunc(ticks) {
+				t.Fatalf("Item %d: bid %v is not a multiple of the 7 tick", id, amount)
+			}
+		}
+	}
+}
+
+func TestShippingCostLowersBids(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Bidder.MinBidMultiplier = 1.0
+	cfg.Bidder.MaxBidMultiplier = 2.0
+	cfg.Bidder.ShippingCostPerKg = 1.0
+
+	// Same ID and name, so every bidder values both items identically
+	light := models.AuctionItem{ID: 1, Name: "Lamp", BasePrice: 100.0, ShipWeight: 1.0}
+	heavy := light
+	heavy.ShipWeight = 40.0
+
+	var lightTotal, heavyTotal float64
+	const bidders, bidsEach = 20, 50
+	for id := 1; id <= bidders; id++ {
+		bidder := NewBidderWithSeed(id, &cfg.Bidder, int64(id))
+		for i := 0; i < bidsEach; i++ {
+			lightTotal += bidder.CalculateBidAmount(light)
+			heavyTotal += bidder.CalculateBidAmount(heavy)
+		}
+	}
+
+	lightAvg := lightTotal / (bidders * bidsEach)
+	heavyAvg := heavyTotal / (bidders * bidsEach)
+	if heavyAvg >= lightAvg {
+		t.Errorf("Expected heavy item to get lower bids on average, got %.2f (heavy) vs %.2f (light)",
+			heavyAvg, lightAvg)
+	}
+}
+
 func TestSimulateBidDelay(t *testing.T) {
 	cfg := config.DefaultConfig()
 	cfg.Bidder.BidDelayMinMs = 100
@@ -85,3 +252,295 @@ func TestSimulateBidDelay(t *testing.T) {
 		t.Errorf("Expected delay between 100-500ms, got %v", delay)
 	}
 }
+
+func TestBidRateLimit(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Bidder.BidProbability = 1.0 // Always bid
+	cfg.Bidder.BidDelayMinMs = 0
+	cfg.Bidder.BidDelayMaxMs = 0
+	cfg.Bidder.BidRate = 20 // One bid every 50ms
+
+	bidder := NewBidder(1, &cfg.Bidder)
+	item := models.AuctionItem{ID: 1, BasePrice: 100.0}
+
+	const auctions = 5
+	bidChannel := make(chan models.Bid, auctions)
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	// The same bidder takes part in several auctions at once
+	var wg sync.WaitGroup
+	for i := 1; i <= auctions; i++ {
+		wg.Add(1)
+		go func(auctionID int) {
+			defer wg.Done()
+			bidder.ParticipateInAuction(ctx, auctionID, item, bidChannel)
+		}(i)
+	}
+	wg.Wait()
+	close(bidChannel)
+
+	timestamps := make([]time.Time, 0, auctions)
+	for bid := range bidChannel {
+		timestamps = append(timestamps, bid.Timestamp)
+	}
+	if len(timestamps) != auctions {
+		t.Fatalf("Expected %d bids, got %d", auctions, len(timestamps))
+	}
+
+	sort.Slice(timestamps, func(i, j int) bool { return timestamps[i].Before(timestamps[j]) })
+
+	// With a burst of 1, n bids need at least (n-1) intervals of 1/rate
+	span := timestamps[len(timestamps)-1].Sub(timestamps[0])
+	minSpan := time.Duration(float64(auctions-1)/cfg.Bidder.BidRate*float64(time.Second)) - 10*time.Millisecond
+	if span < minSpan {
+		t.Errorf("Expected bids to span at least %v at %.0f bids/s, got %v",
+			minSpan, cfg.Bidder.BidRate, span)
+	}
+}
+
+func TestLateBiddersMissWindow(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Bidder.BidProbability = 1.0 // Every bidder is interested
+	cfg.Bidder.BidDelayMinMs = 200  // ... but thinks past the deadline
+	cfg.Bidder.BidDelayMaxMs = 200
+
+	auc := auction.NewAuction(1, models.AuctionItem{ID: 1, BasePrice: 100.0}, 50*time.Millisecond)
+	auc.Output = io.Discard
+
+	done := make(chan models.AuctionResult)
+	go func() {
+		done <- auc.Run(context.Background())
+	}()
+
+	var wg sync.WaitGroup
+	for id := 1; id <= 3; id++ {
+		wg.Add(1)
+		go func(b *Bidder) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), auc.Timeout)
+			defer cancel()
+			b.Participate(ctx, auc)
+		}(NewBidder(id, &cfg.Bidder))
+	}
+	wg.Wait()
+
+	result := <-done
+	if result.TotalBids != 0 || result.Status != "missed_window" {
+		t.Errorf("Expected no bids and status missed_window, got %d bids and status %q",
+			result.TotalBids, result.Status)
+	}
+}
+
+func TestWorthlessBidsAreNotAttempts(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Bidder.BidProbability = 1.0 // Every bidder is interested
+	cfg.Bidder.MinBidMultiplier = 1.0
+	cfg.Bidder.MaxBidMultiplier = 1.0
+	cfg.Bidder.ShippingCostPerKg = 1.0 // ... but shipping eats the whole value
+	cfg.Bidder.BidDelayMinMs = 0
+	cfg.Bidder.BidDelayMaxMs = 0
+
+	auc := auction.NewAuction(1, models.AuctionItem{ID: 1, BasePrice: 100.0, ShipWeight: 100.0}, 50*time.Millisecond)
+
+	done := make(chan models.AuctionResult)
+	go func() {
+		done <- auc.Run(context.Background())
+	}()
+
+	var wg sync.WaitGroup
+	for id := 1; id <= 3; id++ {
+		wg.Add(1)
+		go func(b *Bidder) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), auc.Timeout)
+			defer cancel()
+			b.Participate(ctx, auc)
+		}(NewBidder(id, &cfg.Bidder))
+	}
+	wg.Wait()
+
+	result := <-done
+	if result.TotalBids != 0 || result.Status != "no_bids" {
+		t.Errorf("Expected no bids and status no_bids, got %d bids and status %q",
+			result.TotalBids, result.Status)
+	}
+}
+
+func TestRushBeforeDeadline(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Bidder.BidProbability = 1.0 // Every bidder is interested
+	cfg.Bidder.BidDelayMinMs = 200  // ... and would think past the deadline
+	cfg.Bidder.BidDelayMaxMs = 400
+	cfg.Bidder.RushBeforeDeadline = true
+
+	auc := auction.NewAuction(1, models.AuctionItem{ID: 1, BasePrice: 100.0}, 100*time.Millisecond)
+	auc.Output = io.Discard
+
+	done := make(chan models.AuctionResult)
+	go func() {
+		done <- auc.Run(context.Background())
+	}()
+
+	var wg sync.WaitGroup
+	for id := 1; id <= 3; id++ {
+		wg.Add(1)
+		go func(b *Bidder) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), auc.Timeout)
+			defer cancel()
+			b.Participate(ctx, auc)
+		}(NewBidder(id, &cfg.Bidder))
+	}
+	wg.Wait()
+
+	result := <-done
+	if result.TotalBids != 3 {
+		t.Fatalf("Expected all 3 rushed bids to land, got %d (status %q)", result.TotalBids, result.Status)
+	}
+	if result.LateBids != 0 {
+		t.Errorf("Expected every bid before the deadline, got %d late", result.LateBids)
+	}
+	for _, bid := range result.Bids {
+		if bid.Timestamp.After(result.EndTime) {
+			t.Errorf("Bid at %v landed after close at %v", bid.Timestamp, result.EndTime)
+		}
+	}
+}
+
+func TestNoBidAmountAfterDeadline(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Bidder.BidProbability = 1.0 // Always bid
+	cfg.Bidder.BidDelayMinMs = 100
+	cfg.Bidder.BidDelayMaxMs = 100
+
+	var calls atomic.Int64
+	spy := func(b *Bidder) *Bidder {
+		b.calculate = func(item models.AuctionItem, reserve, fraction float64) float64 {
+			calls.Add(1)
+			return b.bidAt(item, reserve, fraction)
+		}
+		return b
+	}
+	item := models.AuctionItem{ID: 1, BasePrice: 100.0}
+	bidChannel := make(chan models.Bid, 1)
+
+	// The context expires during the delay
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	spy(NewBidder(1, &cfg.Bidder)).ParticipateInAuction(ctx, 1, item, bidChannel)
+
+	// On a simulated clock the deadline and the delay end at the same
+	// instant, possibly before the auction's context is cancelled
+	fake := clock.NewFake(time.Unix(0, 0))
+	deadline := fake.Now().Add(100 * time.Millisecond)
+	fakeCtx, fakeCancel := clock.WithTimeout(context.Background(), fake, 100*time.Millisecond)
+	defer fakeCancel()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		spy(NewBidder(2, &cfg.Bidder)).participate(fakeCtx, logging.Discard(), fake, nil, 1, item, 0, deadline, nil,
+			func(ctx context.Context, bid models.Bid) bool {
+				bidChannel <- bid
+				return true
+			})
+	}()
+	for fake.Pending() < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	fake.Advance(100 * time.Millisecond)
+	<-done
+
+	if n := calls.Load(); n != 0 {
+		t.Errorf("Expected no bid amount computed after the deadline, got %d calls", n)
+	}
+	if len(bidChannel) != 0 {
+		t.Errorf("Expected no bid sent, got %d", len(bidChannel))
+	}
+}
+
+func TestBidAmountComputedOnce(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Bidder.BidProbability = 1.0 // Always bid
+	cfg.Bidder.BidDelayMinMs = 0
+	cfg.Bidder.BidDelayMaxMs = 0
+
+	var calls atomic.Int64
+	b := NewBidder(1, &cfg.Bidder)
+	b.calculate = func(item models.AuctionItem, reserve, fraction float64) float64 {
+		calls.Add(1)
+		return b.bidAt(item, reserve, fraction)
+	}
+	bidChannel := make(chan models.Bid, 1)
+	b.ParticipateInAuction(context.Background(), 1, models.AuctionItem{ID: 1, BasePrice: 100.0}, bidChannel)
+
+	if len(bidChannel) != 1 {
+		t.Fatalf("Expected one bid sent, got %d", len(bidChannel))
+	}
+	if n := calls.Load(); n != 1 {
+		t.Errorf("Expected the bid amount computed once, through the hook, got %d calls", n)
+	}
+}
+
+func TestDropoutLosesBids(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Bidder.BidProbability = 1.0 // Every bidder decides to bid
+	cfg.Bidder.DropoutProbability = 0.9
+	cfg.Bidder.BidDelayMinMs = 0
+	cfg.Bidder.BidDelayMaxMs = 0
+
+	const decisions = 200
+	bidder := NewBidderWithSeed(1, &cfg.Bidder, 42)
+	bidChannel := make(chan models.Bid, decisions)
+	for id := 1; id <= decisions; id++ {
+		item := models.AuctionItem{ID: id, BasePrice: 100.0}
+		bidder.ParticipateInAuction(context.Background(), id, item, bidChannel)
+	}
+
+	bids := len(bidChannel)
+	if bids > decisions/4 {
+		t.Errorf("Expected far fewer than %d bids with 90%% dropout, got %d", decisions, bids)
+	}
+	if bidder.Dropouts() != decisions-bids {
+		t.Errorf("Expected %d dropouts, got %d", decisions-bids, bidder.Dropouts())
+	}
+}
+
+func TestTieJitterRemovesTies(t *testing.T) {
+	cfg := config.DefaultConfig()
+	// Every bidder bids exactly the base price, the worst case for ties
+	cfg.Bidder.MinBidMultiplier = 1.0
+	cfg.Bidder.MaxBidMultiplier = 1.0
+
+	amounts := func() map[float64]int {
+		seen := make(map[float64]int)
+		pool := NewPool(&cfg.Bidder, WithSeed(42))
+		for id := 1; id <= 20; id++ {
+			item := models.AuctionItem{ID: id, Name: "Lamp", BasePrice: 100.0}
+			for _, b := range pool.GetBidders() {
+				amount := b.CalculateBidAmount(item)
+				if amount > b.Valuation(item) {
+					t.Fatalf("Bidder #%d: bid %.4f above valuation %.4f", b.ID, amount, b.Valuation(item))
+				}
+				seen[amount]++
+			}
+		}
+		return seen
+	}
+
+	if without := amounts(); len(without) != 1 {
+		t.Fatalf("Expected every bid to tie without jitter, got %d distinct amounts", len(without))
+	}
+
+	cfg.Bidder.TieJitter = true
+	with := amounts()
+	bids := 20 * cfg.Bidder.TotalBidders
+	if len(with) != bids {
+		t.Errorf("Expected %d distinct amounts with jitter, got %d", bids, len(with))
+	}
+	for amount := range with {
+		if amount < 100-maxTieJitter || amount > 100 {
+			t.Errorf("Expected jitter under %.2f, got a bid of %.4f", maxTieJitter, amount)
+		}
+	}
+}
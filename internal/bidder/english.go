@@ -0,0 +1,129 @@
+package bidder
+
+import (
+	"container/heap"
+	"math/rand"
+	"time"
+
+	"github.com/vineetjain1712/auction-simulator/internal/auction"
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+// EnglishBids plays out an English (ascending) auction on auc between the
+// pool's bidders, without waiting, and returns the bids in timestamp order,
+// ready for Auction.RunSequential
+// Interested bidders open as in SequentialBids. Whenever a bidder is
+// outbid, it re-bids the high bid plus increment, rounded up to the tick
+// size (see BidderConfig.TickSize), after its patience (see
+// BidderConfig.PatienceMinMs), as long as that stays within its valuation,
+// net of shipping, and before the auction's timeout. Like SequentialBids,
+// the outcome depends only on the bidders' seeds and the auction ID.
+func (p *Pool) EnglishBids(auc *auction.Auction, increment float64) []models.Bid {
+	start := auc.Clock.Now()
+	deadline := start.Add(auc.Timeout)
+
+	var (
+		queue   englishQueue
+		bidders = make(map[int]*englishBidder)
+	)
+	for _, b := range p.bidders {
+		opening, ok := b.SequentialBid(auc, start)
+		if !ok {
+			continue
+		}
+		_, maxBid := b.bidRange(auc.Item, auc.VisibleReserve())
+		bidder := &englishBidder{
+			Bidder:    b,
+			rand:      rand.New(rand.NewSource(b.seed*37 + int64(auc.ID))),
+			opening:   opening,
+			maxBid:    b.limit(auc.Item, maxBid),
+			scheduled: true,
+		}
+		bidders[b.ID] = bidder
+		heap.Push(&queue, englishTurn{at: opening.Timestamp, bidder: bidder, order: len(queue)})
+	}
+
+	var bids []models.Bid
+	var high *models.Bid // Leading bid
+	for turns := len(queue); queue.Len() > 0; turns++ {
+		turn := heap.Pop(&queue).(englishTurn)
+		bidder := turn.bidder
+		bidder.scheduled = false
+		if high != nil && high.BidderID == bidder.ID {
+			continue
+		}
+
+		amount := bidder.opening.Amount
+		if high != nil && amount < high.Amount+increment {
+			// Raise to the next tick at or above the minimum raise
+			amount = bidder.quantize(auc.Item, high.Amount+increment, high.Amount+increment)
+		}
+		if amount > bidder.maxBid || (high != nil && amount < high.Amount+increment) || !turn.at.Before(deadline) {
+			// Priced out (or capped below the next raise), or too late:
+			// the bidder drops out
+			bidder.out = true
+			continue
+		}
+
+		bid := bidder.opening
+		bid.Amount = amount
+		bid.Timestamp = turn.at
+		bids = append(bids, bid)
+		high = &bid
+
+		// Everyone else still in is now outbid
+		for _, other := range bidders {
+			if other.ID == bid.BidderID || other.scheduled || other.out {
+				continue
+			}
+			other.scheduled = true
+			heap.Push(&queue, englishTurn{at: turn.at.Add(other.patience()), bidder: other, order: turns})
+		}
+	}
+	return bids
+}
+
+// englishBidder is a bidder's state in one English auction
+type englishBidder struct {
+	*Bidder
+	rand      *rand.Rand // Patience draws, specific to the auction
+	opening   models.Bid // The bidder's first bid
+	maxBid    float64    // Most the bidder will bid
+	scheduled bool       // A turn is queued for the bidder
+	out       bool       // The bidder has dropped out
+}
+
+// patience draws how long the bidder takes to respond to being outbid
+func (b *englishBidder) patience() time.Duration {
+	minMs, maxMs := b.config.PatienceMinMs, b.config.PatienceMaxMs
+	return time.Duration(minMs+b.rand.Intn(maxMs-minMs+1)) * time.Millisecond
+}
+
+// englishTurn is a bidder's chance to bid at a point in simulated time
+type englishTurn struct {
+	at     time.Time
+	bidder *englishBidder
+	order  int // Breaks ties in at, earliest queued first
+}
+
+// englishQueue is a min-heap of turns by time
+type englishQueue []englishTurn
+
+func (q englishQueue) Len() int { return len(q) }
+func (q englishQueue) Less(i, j int) bool {
+	if !q[i].at.Equal(q[j].at) {
+		return q[i].at.Before(q[j].at)
+	}
+	if q[i].order != q[j].order {
+		return q[i].order < q[j].order
+	}
+	return q[i].bidder.ID < q[j].bidder.ID
+}
+func (q englishQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+func (q *englishQueue) Push(x any)   { *q = append(*q, x.(englishTurn)) }
+func (q *englishQueue) Pop() any {
+	old := *q
+	turn := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return turn
+}
@@ -0,0 +1,61 @@
+package bidder
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/vineetjain1712/auction-simulator/config"
+	"github.com/vineetjain1712/auction-simulator/internal/auction"
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+func TestPatienceSlowsEnglishAuctions(t *testing.T) {
+	// settle runs an English auction between 20 seeded bidders with the
+	// given patience and returns how long it took from the first bid to
+	// the last
+	settle := func(minMs, maxMs int) time.Duration {
+		cfg := config.DefaultConfig()
+		cfg.Bidder.TotalBidders = 20
+		cfg.Bidder.BidProbability = 1.0
+		cfg.Bidder.BidDelayMinMs = 0
+		cfg.Bidder.BidDelayMaxMs = 100
+		cfg.Bidder.PatienceMinMs = minMs
+		cfg.Bidder.PatienceMaxMs = maxMs
+		pool := NewPool(&cfg.Bidder, WithSeed(42))
+
+		auc := auction.NewAuction(1, models.AuctionItem{ID: 1, Name: "Lamp", BasePrice: 100}, 24*time.Hour)
+		auc.Output = io.Discard
+		bids := pool.EnglishBids(auc, 5)
+		if len(bids) < 2 {
+			t.Fatalf("Expected a bidding war, got %d bids", len(bids))
+		}
+
+		for i := 1; i < len(bids); i++ {
+			if bids[i].Amount <= bids[i-1].Amount || bids[i].Timestamp.Before(bids[i-1].Timestamp) {
+				t.Fatalf("Bid %d (%.2f at %v) does not raise bid %d (%.2f at %v)", i,
+					bids[i].Amount, bids[i].Timestamp, i-1, bids[i-1].Amount, bids[i-1].Timestamp)
+			}
+		}
+
+		// The last bid standing wins
+		result := auc.RunSequential(context.Background(), bids)
+		if last := bids[len(bids)-1]; result.WinningBid == nil || result.WinningBid.BidderID != last.BidderID {
+			t.Errorf("Expected the last bidder #%d to win, got %+v", last.BidderID, result.WinningBid)
+		}
+
+		return bids[len(bids)-1].Timestamp.Sub(bids[0].Timestamp)
+	}
+
+	impatient := settle(10, 50)
+	patient := settle(500, 1000)
+	if impatient >= patient {
+		t.Errorf("Expected impatient bidders to settle faster: %v vs %v for patient ones", impatient, patient)
+	}
+
+	// The same seed settles the same way
+	if again := settle(10, 50); again != impatient {
+		t.Errorf("Expected a repeatable settle time, got %v then %v", impatient, again)
+	}
+}
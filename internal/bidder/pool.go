@@ -3,69 +3,256 @@ package bidder
 import (
 	"context"
 	"fmt"
+	"io"
+	"log/slog"
+	"sort"
 	"sync"
 
 	"github.com/vineetjain1712/auction-simulator/config"
 	"github.com/vineetjain1712/auction-simulator/internal/auction"
+	"github.com/vineetjain1712/auction-simulator/internal/clock"
+	"github.com/vineetjain1712/auction-simulator/internal/logging"
+	"github.com/vineetjain1712/auction-simulator/internal/models"
 )
 
 // Pool manages a collection of bidders
 type Pool struct {
 	bidders []*Bidder
 	config  *config.BidderConfig
+
+	// Output receives console progress lines (io.Discard to silence them)
+	Output io.Writer
+	// Logger receives structured progress logs
+	Logger *slog.Logger
+
+	// Launched, if set, is called by ParticipateInAllAuctions once it has
+	// launched every bidder-auction pair, or stopped launching them
+	Launched func()
+}
+
+// PoolOption configures a Pool created by NewPool
+type PoolOption func(*poolOptions)
+
+// poolOptions holds the settings applied by PoolOptions
+type poolOptions struct {
+	seed      int64            // 0 = time-based
+	segmenter func(int) string // Bidder ID -> segment (nil = no segments)
+	workers   int              // Goroutines constructing bidders (< 2 = serial)
+}
+
+// WithSeed seeds the pool's bidders from seed, so the same seed produces
+// the same bidders (0 keeps time-based seeding)
+func WithSeed(seed int64) PoolOption {
+	return func(o *poolOptions) {
+		o.seed = seed
+	}
+}
+
+// WithSegmenter assigns each bidder the segment returned by segmenter for
+// its ID
+func WithSegmenter(segmenter func(bidderID int) string) PoolOption {
+	return func(o *poolOptions) {
+		o.segmenter = segmenter
+	}
+}
+
+// WithWorkers constructs the pool's bidders on n goroutines, which helps
+// with very large pools
+// The pool is the same for any n: each bidder's seed depends only on the
+// pool seed and its ID, never on construction order. Any segmenter is then
+// called concurrently.
+func WithWorkers(n int) PoolOption {
+	return func(o *poolOptions) {
+		o.workers = n
+	}
 }
 
 // NewPool creates a pool of bidders
-func NewPool(cfg *config.BidderConfig) *Pool {
+func NewPool(cfg *config.BidderConfig, opts ...PoolOption) *Pool {
+	var options poolOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
 	bidders := make([]*Bidder, cfg.TotalBidders)
 
-	for i := 0; i < cfg.TotalBidders; i++ {
-		bidders[i] = NewBidder(i+1, cfg)
+	// newBidders fills bidders[lo:hi]
+	newBidders := func(lo, hi int) {
+		for i := lo; i < hi; i++ {
+			if options.seed != 0 {
+				bidders[i] = NewBidderWithSeed(i+1, cfg, options.seed+int64(i+1))
+			} else {
+				bidders[i] = NewBidder(i+1, cfg)
+			}
+			if options.segmenter != nil {
+				bidders[i].Segment = options.segmenter(i + 1)
+			}
+		}
 	}
 
+	workers := min(options.workers, len(bidders))
+	if workers < 2 {
+		newBidders(0, len(bidders))
+	} else {
+		// Each worker fills its own contiguous chunk of the slice
+		var wg sync.WaitGroup
+		chunk := (len(bidders) + workers - 1) / workers
+		for lo := 0; lo < len(bidders); lo += chunk {
+			wg.Add(1)
+			go func(lo, hi int) {
+				defer wg.Done()
+				newBidders(lo, hi)
+			}(lo, min(lo+chunk, len(bidders)))
+		}
+		wg.Wait()
+	}
+
+	return newPool(bidders, cfg)
+}
+
+// NewPoolFromBidders creates a pool of the given bidders, e.g. with custom
+// budgets or segments, instead of generating them from cfg
+// Returns an error if a bidder is nil or bidder IDs are not unique.
+func NewPoolFromBidders(bidders []*Bidder, cfg *config.BidderConfig) (*Pool, error) {
+	pool := newPool(bidders, cfg)
+	if err := pool.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid bidders: %w", err)
+	}
+	return pool, nil
+}
+
+// newPool creates a pool of bidders with the default output and logger
+func newPool(bidders []*Bidder, cfg *config.BidderConfig) *Pool {
 	return &Pool{
 		bidders: bidders,
 		config:  cfg,
+		Output:  io.Discard,
+		Logger:  logging.Discard(),
 	}
 }
 
+// Validate checks that the pool has no nil bidders and no duplicate bidder IDs.
+// Duplicate IDs would silently merge the bids and wins of different bidders.
+func (p *Pool) Validate() error {
+	seen := make(map[int]int, len(p.bidders)) // bidderID -> index in pool
+
+	for i, b := range p.bidders {
+		if b == nil {
+			return fmt.Errorf("bidder at index %d is nil", i)
+		}
+		if first, ok := seen[b.ID]; ok {
+			return fmt.Errorf("duplicate bidder ID %d at indexes %d and %d", b.ID, first, i)
+		}
+		seen[b.ID] = i
+	}
+
+	return nil
+}
+
 // ParticipateInAllAuctions makes all bidders participate in all auctions
 // Each bidder can bid on multiple auctions
-func (p *Pool) ParticipateInAllAuctions(ctx context.Context, auctions []*auction.Auction) {
-	fmt.Printf("👥 Activating %d bidders for %d auctions\n",
+// Returns an error without starting any bidder if the pool is invalid
+// If ctx is cancelled, no further bidder-auction pairs are launched; the
+// pairs already running are waited for and the context error is returned
+func (p *Pool) ParticipateInAllAuctions(ctx context.Context, auctions []*auction.Auction) error {
+	if err := p.Validate(); err != nil {
+		return fmt.Errorf("invalid bidder pool: %w", err)
+	}
+
+	fmt.Fprintf(p.Output, "👥 Activating %d bidders for %d auctions\n",
 		len(p.bidders), len(auctions))
+	p.Logger.InfoContext(ctx, "activating bidders",
+		"bidders", len(p.bidders), "auctions", len(auctions))
 
 	var wg sync.WaitGroup
+	launched := 0
 
 	// For each bidder
+launch:
 	for _, bidder := range p.bidders {
 		// For each auction
 		for _, auc := range auctions {
+			// Stop scheduling pairs as soon as the run is cancelled
+			if ctx.Err() != nil {
+				break launch
+			}
+
 			wg.Add(1)
+			launched++
+
+			// A simulated clock waits until the bidder is thinking, so the
+			// auction can't time out before the bidder has started
+			release := clock.Hold(auc.Clock)
 
 			// Launch goroutine for this bidder-auction pair
 			go func(b *Bidder, auction *auction.Auction) {
 				defer wg.Done()
+				defer release()
 
 				// Create a context with auction timeout
-				auctionCtx, cancel := context.WithTimeout(ctx, auction.Timeout)
+				auctionCtx, cancel := clock.WithTimeout(ctx, auction.Clock, auction.Timeout)
 				defer cancel()
 
-				// Bidder participates in this auction
-				b.ParticipateInAuction(
-					auctionCtx,
-					auction.ID,
-					auction.Item,
-					auction.GetBidChannel(),
-				)
+				// Bidder participates in this auction, logging to the pool
+				b.participateLogged(auctionCtx, p.Logger, auction, release)
 			}(bidder, auc)
 		}
 	}
 
+	if p.Launched != nil {
+		p.Launched()
+	}
+
 	// Wait for all bidder-auction interactions to complete
 	wg.Wait()
 
-	fmt.Println("✅ All bidders have finished participating")
+	if total := len(p.bidders) * len(auctions); launched < total {
+		return fmt.Errorf("stopped after launching %d of %d bidder-auction pairs: %w",
+			launched, total, ctx.Err())
+	}
+
+	fmt.Fprintln(p.Output, "✅ All bidders have finished participating")
+	p.Logger.InfoContext(ctx, "all bidders finished")
+	return nil
+}
+
+// SequentialBids collects the bids of every bidder on auc, visiting bidders
+// in pool order on the calling goroutine
+// Bids are returned in order of their (simulated) timestamps, ties keeping
+// pool order, so the result is exactly repeatable for seeded bidders
+func (p *Pool) SequentialBids(auc *auction.Auction) []models.Bid {
+	start := auc.Clock.Now()
+
+	var bids []models.Bid
+	for _, b := range p.bidders {
+		if bid, ok := b.SequentialBid(auc, start); ok {
+			bids = append(bids, bid)
+		}
+	}
+
+	sort.SliceStable(bids, func(i, j int) bool {
+		return bids[i].Timestamp.Before(bids[j].Timestamp)
+	})
+	return bids
+}
+
+// Dropouts returns how many bids the pool's bidders decided on but never
+// sent because they dropped out
+func (p *Pool) Dropouts() int {
+	total := 0
+	for _, b := range p.bidders {
+		total += b.Dropouts()
+	}
+	return total
+}
+
+// Seeds returns the seed of every bidder in the pool, by bidder ID
+func (p *Pool) Seeds() map[int]int64 {
+	seeds := make(map[int]int64, len(p.bidders))
+	for _, b := range p.bidders {
+		seeds[b.ID] = b.Seed()
+	}
+	return seeds
 }
 
 // GetBidders returns all bidders in the pool
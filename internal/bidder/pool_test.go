@@ -0,0 +1,339 @@
+package bidder
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vineetjain1712/auction-simulator/config"
+	"github.com/vineetjain1712/auction-simulator/internal/auction"
+	"github.com/vineetjain1712/auction-simulator/internal/logging"
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+func TestPoolValidate(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Bidder.TotalBidders = 5
+
+	pool := NewPool(&cfg.Bidder)
+	if err := pool.Validate(); err != nil {
+		t.Errorf("Expected generated pool to be valid, got %v", err)
+	}
+}
+
+func TestPoolValidateDuplicateID(t *testing.T) {
+	cfg := config.DefaultConfig()
+
+	pool := &Pool{
+		bidders: []*Bidder{
+			NewBidder(1, &cfg.Bidder),
+			NewBidder(42, &cfg.Bidder),
+			NewBidder(42, &cfg.Bidder),
+		},
+		config: &cfg.Bidder,
+	}
+
+	err := pool.Validate()
+	if err == nil {
+		t.Fatal("Expected an error for duplicate bidder IDs")
+	}
+	if !strings.Contains(err.Error(), "42") {
+		t.Errorf("Expected error to name bidder 42, got %q", err)
+	}
+
+	// Participation must refuse to start with an invalid pool
+	if err := pool.ParticipateInAllAuctions(context.Background(), nil); err == nil {
+		t.Error("Expected ParticipateInAllAuctions to reject an invalid pool")
+	}
+}
+
+func TestPoolValidateNilBidder(t *testing.T) {
+	cfg := config.DefaultConfig()
+
+	pool := &Pool{
+		bidders: []*Bidder{NewBidder(1, &cfg.Bidder), nil},
+		config:  &cfg.Bidder,
+	}
+
+	if err := pool.Validate(); err == nil {
+		t.Error("Expected an error for a nil bidder")
+	}
+}
+
+func TestNewPoolFromBidders(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Bidder.BidProbability = 1.0
+	cfg.Bidder.BidDelayMinMs = 0
+	cfg.Bidder.BidDelayMaxMs = 0
+
+	bidders := []*Bidder{
+		NewBidderWithSeed(7, &cfg.Bidder, 1),
+		NewBidderWithSeed(11, &cfg.Bidder, 2),
+	}
+	bidders[1].Segment = "dealer"
+
+	if _, err := NewPoolFromBidders(append(bidders, NewBidder(7, &cfg.Bidder)), &cfg.Bidder); err == nil {
+		t.Error("Expected an error for duplicate bidder IDs")
+	}
+
+	pool, err := NewPoolFromBidders(bidders, &cfg.Bidder)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pool.Output = io.Discard
+
+	auctions := benchmarkAuctions(3)
+	if err := pool.ParticipateInAllAuctions(context.Background(), auctions); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, b := range pool.GetBidders() {
+		if b != bidders[i] {
+			t.Errorf("Bidder at index %d is not the supplied bidder #%d", i, bidders[i].ID)
+		}
+	}
+	for _, auc := range auctions {
+		bids := auc.GetAllBids()
+		if len(bids) != 2 {
+			t.Errorf("Auction %d: expected a bid from each of the 2 bidders, got %d", auc.ID, len(bids))
+		}
+		for _, bid := range bids {
+			if bid.BidderID != 7 && bid.BidderID != 11 {
+				t.Errorf("Auction %d: unexpected bid from bidder #%d", auc.ID, bid.BidderID)
+			}
+		}
+	}
+}
+
+func TestPoolSegmenter(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Bidder.TotalBidders = 4
+
+	pool := NewPool(&cfg.Bidder, WithSegmenter(func(bidderID int) string {
+		if bidderID%2 == 0 {
+			return "dealer"
+		}
+		return "retail"
+	}))
+
+	expected := []string{"retail", "dealer", "retail", "dealer"}
+	for i, b := range pool.GetBidders() {
+		if b.Segment != expected[i] {
+			t.Errorf("Bidder #%d: expected segment %q, got %q", b.ID, expected[i], b.Segment)
+		}
+	}
+}
+
+func TestParticipateStopsLaunchingOnCancel(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Bidder.BidProbability = 1.0 // Every launched pair would bid
+	cfg.Bidder.BidDelayMinMs = 0
+	cfg.Bidder.BidDelayMaxMs = 0
+	pool := NewPool(&cfg.Bidder, WithSeed(42))
+	pool.Output = io.Discard
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	auctions := benchmarkAuctions(40)
+	err := pool.ParticipateInAllAuctions(ctx, auctions)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected a cancellation error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "launching 0 of 4000") {
+		t.Errorf("Expected no pairs to be launched, got %v", err)
+	}
+
+	for _, auc := range auctions {
+		auc.Output = io.Discard
+		if result := auc.RunSequential(context.Background(), nil); result.TotalBids != 0 {
+			t.Fatalf("Auction #%d: expected no bids after cancellation, got %d", auc.ID, result.TotalBids)
+		}
+	}
+}
+
+func TestPoolIdenticalAcrossWorkers(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Bidder.TotalBidders = 103 // Not a multiple of the worker counts
+	segmenter := func(bidderID int) string { return fmt.Sprintf("segment-%d", bidderID%3) }
+	item := models.AuctionItem{ID: 1, Name: "Lamp", BasePrice: 100}
+
+	serial := NewPool(&cfg.Bidder, WithSeed(42), WithSegmenter(segmenter))
+	for _, workers := range []int{2, 4, 8, 1000} {
+		parallel := NewPool(&cfg.Bidder, WithSeed(42), WithSegmenter(segmenter), WithWorkers(workers))
+		if parallel.GetBidderCount() != serial.GetBidderCount() {
+			t.Fatalf("%d workers: expected %d bidders, got %d",
+				workers, serial.GetBidderCount(), parallel.GetBidderCount())
+		}
+
+		for i, want := range serial.GetBidders() {
+			got := parallel.GetBidders()[i]
+			if got.ID != want.ID || got.seed != want.seed || got.Segment != want.Segment {
+				t.Fatalf("%d workers: bidder at index %d is #%d (seed %d, %q), expected #%d (seed %d, %q)",
+					workers, i, got.ID, got.seed, got.Segment, want.ID, want.seed, want.Segment)
+			}
+			if got.Valuation(item) != want.Valuation(item) {
+				t.Fatalf("%d workers: bidder #%d values the item differently", workers, got.ID)
+			}
+		}
+	}
+}
+
+func TestParticipationDebugLogs(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Bidder.TotalBidders = 1
+	cfg.Bidder.BidProbability = 1.0
+	cfg.Bidder.BidDelayMinMs = 0
+	cfg.Bidder.BidDelayMaxMs = 0
+	pool := NewPool(&cfg.Bidder, WithSeed(42))
+	pool.Output = io.Discard
+	var logs bytes.Buffer
+	pool.Logger = logging.New(&logs, "debug")
+
+	if err := pool.ParticipateInAllAuctions(context.Background(), benchmarkAuctions(1)); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, msg := range []string{"bidder decided to bid", "bid delay chosen", "bid sent"} {
+		found := false
+		for _, line := range strings.Split(logs.String(), "\n") {
+			if strings.Contains(line, msg) {
+				found = true
+				if !strings.Contains(line, "bidder_id=1") || !strings.Contains(line, "auction_id=1") {
+					t.Errorf("Expected bidder and auction IDs on %q", line)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("Expected a %q debug line, got:\n%s", msg, logs.String())
+		}
+	}
+}
+
+// benchmarkAuctions creates auctions that accept bids without running, so
+// the pool benchmarks measure bidding alone
+func benchmarkAuctions(n int) []*auction.Auction {
+	auctions := make([]*auction.Auction, n)
+	generator := auction.NewItemGeneratorWithSeed(42)
+	for i := range auctions {
+		auctions[i] = auction.NewAuction(i+1, generator.GenerateItem(i+1), time.Minute)
+		auctions[i].CollectionMode = config.CollectViaMutex
+	}
+	return auctions
+}
+
+// BenchmarkParticipateInAllAuctions benchmarks concurrent participation:
+// one goroutine per bidder-auction pair
+func BenchmarkParticipateInAllAuctions(b *testing.B) {
+	cfg := config.DefaultConfig()
+	cfg.Bidder.BidDelayMinMs = 0
+	cfg.Bidder.BidDelayMaxMs = 0
+	pool := NewPool(&cfg.Bidder, WithSeed(42))
+	pool.Output = io.Discard
+
+	b.ReportAllocs()
+	for b.Loop() {
+		pool.ParticipateInAllAuctions(context.Background(), benchmarkAuctions(40))
+	}
+}
+
+// BenchmarkNewPool benchmarks constructing a large pool serially and on
+// several workers
+func BenchmarkNewPool(b *testing.B) {
+	cfg := config.DefaultConfig()
+	cfg.Bidder.TotalBidders = 100_000
+
+	for _, workers := range []int{1, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				NewPool(&cfg.Bidder, WithSeed(42), WithWorkers(workers))
+			}
+		})
+	}
+}
+
+// BenchmarkSequentialBids benchmarks deterministic participation: bidders
+// visited in order on one goroutine per auction
+func BenchmarkSequentialBids(b *testing.B) {
+	cfg := config.DefaultConfig()
+	pool := NewPool(&cfg.Bidder, WithSeed(42))
+
+	b.ReportAllocs()
+	for b.Loop() {
+		for _, auc := range benchmarkAuctions(40) {
+			pool.SequentialBids(auc)
+		}
+	}
+}
+
+func TestProbabilitySpread(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Bidder.TotalBidders = 1000
+	cfg.Bidder.BidProbability = 0.4
+	cfg.Bidder.ProbabilitySpread = 0.2
+
+	pool := NewPool(&cfg.Bidder, WithSeed(42))
+
+	distinct := make(map[float64]bool)
+	sum := 0.0
+	for _, b := range pool.GetBidders() {
+		if b.BidProbability < 0.2 || b.BidProbability > 0.6 {
+			t.Fatalf("Bidder #%d: probability %.3f outside 0.4 ± 0.2", b.ID, b.BidProbability)
+		}
+		distinct[b.BidProbability] = true
+		sum += b.BidProbability
+	}
+	if len(distinct) < 900 {
+		t.Errorf("Expected varied probabilities, got %d distinct values", len(distinct))
+	}
+	if mean := sum / float64(pool.GetBidderCount()); mean < 0.38 || mean > 0.42 {
+		t.Errorf("Expected probabilities centered near 0.4, got mean %.3f", mean)
+	}
+
+	// Deterministic for a seed, whatever the construction order
+	again := NewPool(&cfg.Bidder, WithSeed(42), WithWorkers(4))
+	for i, b := range pool.GetBidders() {
+		if again.GetBidders()[i].BidProbability != b.BidProbability {
+			t.Fatalf("Bidder #%d: probability differs between identically seeded pools", b.ID)
+		}
+	}
+}
+
+func TestBidderSeeds(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Bidder.TotalBidders = 50
+
+	seeds := NewPool(&cfg.Bidder, WithSeed(1000)).Seeds()
+	if len(seeds) != cfg.Bidder.TotalBidders {
+		t.Fatalf("Expected %d seeds, got %d", cfg.Bidder.TotalBidders, len(seeds))
+	}
+
+	distinct := make(map[int64]bool)
+	for id, seed := range seeds {
+		if seed != 1000+int64(id) {
+			t.Errorf("Bidder %d: expected seed %d from the base seed and ID, got %d", id, 1000+id, seed)
+		}
+		distinct[seed] = true
+	}
+	if len(distinct) != len(seeds) {
+		t.Errorf("Expected distinct seeds, got %d for %d bidders", len(distinct), len(seeds))
+	}
+
+	again := NewPool(&cfg.Bidder, WithSeed(1000), WithWorkers(4))
+	for _, b := range again.GetBidders() {
+		if b.Seed() != seeds[b.ID] {
+			t.Errorf("Bidder %d: seed %d not reproduced, got %d", b.ID, seeds[b.ID], b.Seed())
+		}
+		// The recorded seed replays the bidder's valuations in isolation
+		item := models.AuctionItem{ID: 3, BasePrice: 100}
+		if replay := NewBidderWithSeed(b.ID, &cfg.Bidder, b.Seed()); replay.Valuation(item) != b.Valuation(item) {
+			t.Errorf("Bidder %d: replayed valuation differs", b.ID)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Package clock abstracts the passage of time for auctions and bidders, so a
+// simulation can run on the wall clock or on simulated time that advances as
+// fast as the simulation can process it.
+package clock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock tells the time and schedules timers
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a single-shot timer created by a Clock
+type Timer interface {
+	// C returns the channel the time is sent on when the timer fires
+	C() <-chan time.Time
+	// Stop prevents the timer from firing; it returns false if the timer
+	// had already fired or been stopped
+	Stop() bool
+}
+
+// Real returns the wall clock
+func Real() Clock {
+	return realClock{}
+}
+
+// realClock is Clock over the time package
+type realClock struct{}
+
+func (realClock) Now() time.Time                         { return time.Now() }
+func (realClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (realClock) NewTimer(d time.Duration) Timer         { return realTimer{time.NewTimer(d)} }
+
+// realTimer is Timer over a time.Timer
+type realTimer struct {
+	t *time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time { return t.t.C }
+func (t realTimer) Stop() bool          { return t.t.Stop() }
+
+// Since returns the time elapsed on c since t
+func Since(c Clock, t time.Time) time.Duration {
+	return c.Now().Sub(t)
+}
+
+// WithTimeout is context.WithTimeout with the timeout measured on c
+// On a simulated clock the returned context has no deadline and ends with
+// context.Canceled; its cause is context.DeadlineExceeded.
+func WithTimeout(parent context.Context, c Clock, d time.Duration) (context.Context, context.CancelFunc) {
+	if _, ok := c.(realClock); ok {
+		return context.WithTimeout(parent, d)
+	}
+
+	ctx, cancel := context.WithCancelCause(parent)
+	timer := c.NewTimer(d)
+	go func() {
+		select {
+		case <-timer.C():
+			cancel(context.DeadlineExceeded)
+		case <-ctx.Done():
+			timer.Stop()
+		}
+	}()
+	return ctx, func() { cancel(context.Canceled) }
+}
+
+// Hold keeps c from advancing on its own until the returned release
+// function is called, for clocks that advance themselves (see Fake.Run)
+// Release may be called more than once. On other clocks it does nothing.
+func Hold(c Clock) (release func()) {
+	f, ok := c.(*Fake)
+	if !ok {
+		return func() {}
+	}
+	f.hold()
+	return sync.OnceFunc(f.release)
+}
@@ -0,0 +1,187 @@
+package clock
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Fake is a simulated clock: time only moves when advanced, and timers fire
+// as it passes their deadlines
+// Advance and AdvanceToNext move it by hand; Run moves it from timer to
+// timer whenever the simulation has gone quiet.
+type Fake struct {
+	mu       sync.Mutex
+	now      time.Time
+	timers   []*fakeTimer // Pending timers
+	holds    int          // Outstanding Hold calls; Run doesn't advance while > 0
+	activity uint64       // Bumped on every use, so Run can tell when it's idle
+}
+
+// NewFake returns a simulated clock reading start
+func NewFake(start time.Time) *Fake {
+	return &Fake{now: start}
+}
+
+// fakeTimer is a Timer on a Fake
+type fakeTimer struct {
+	f  *Fake
+	at time.Time
+	c  chan time.Time
+}
+
+// Now returns the simulated time
+func (f *Fake) Now() time.Time {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.activity++
+	return f.now
+}
+
+// After returns a channel that receives the simulated time once d has passed
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	return f.NewTimer(d).C()
+}
+
+// NewTimer returns a timer that fires once d has passed on f
+// A timer for d <= 0 fires immediately.
+func (f *Fake) NewTimer(d time.Duration) Timer {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.activity++
+
+	t := &fakeTimer{f: f, at: f.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		t.c <- f.now
+		return t
+	}
+	f.timers = append(f.timers, t)
+	return t
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *fakeTimer) Stop() bool {
+	f := t.f
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.activity++
+
+	for i, pending := range f.timers {
+		if pending == t {
+			f.timers = append(f.timers[:i], f.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Advance moves f forward by d, firing every timer due by then
+func (f *Fake) Advance(d time.Duration) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.advanceTo(f.now.Add(d))
+}
+
+// AdvanceToNext moves f forward to the earliest pending timer and fires
+// every timer due then
+// Returns false, leaving f unchanged, if no timer is pending.
+func (f *Fake) AdvanceToNext() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.timers) == 0 {
+		return false
+	}
+
+	next := f.timers[0].at
+	for _, t := range f.timers[1:] {
+		if t.at.Before(next) {
+			next = t.at
+		}
+	}
+	f.advanceTo(maxTime(f.now, next))
+	return true
+}
+
+// advanceTo sets the time to now and fires due timers in deadline order
+// f.mu must be held.
+func (f *Fake) advanceTo(now time.Time) {
+	f.now = now
+	f.activity++
+
+	var due, pending []*fakeTimer
+	for _, t := range f.timers {
+		if t.at.After(now) {
+			pending = append(pending, t)
+		} else {
+			due = append(due, t)
+		}
+	}
+	f.timers = pending
+
+	sort.SliceStable(due, func(i, j int) bool { return due[i].at.Before(due[j].at) })
+	for _, t := range due {
+		t.c <- now
+	}
+}
+
+// Pending returns the number of timers that have not yet fired or been
+// stopped
+func (f *Fake) Pending() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.timers)
+}
+
+// Run advances f to each next timer in turn, once nothing has used the
+// clock for idle (wall-clock) time and no Hold is outstanding, until ctx
+// ends
+// Idle should be long enough for the goroutines woken by one step to react
+// before the next.
+func (f *Fake) Run(ctx context.Context, idle time.Duration) {
+	ticker := time.NewTicker(idle)
+	defer ticker.Stop()
+
+	var seen uint64
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		f.mu.Lock()
+		quiet := f.activity == seen && f.holds == 0
+		seen = f.activity
+		f.mu.Unlock()
+
+		if quiet {
+			f.AdvanceToNext()
+		}
+	}
+}
+
+func (f *Fake) hold() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.holds++
+	f.activity++
+}
+
+func (f *Fake) release() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.holds--
+	f.activity++
+}
+
+// maxTime returns the later of t and u
+func maxTime(t, u time.Time) time.Time {
+	if t.After(u) {
+		return t
+	}
+	return u
+}
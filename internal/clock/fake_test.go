@@ -0,0 +1,86 @@
+package clock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestFakeFiresTimersWhenAdvanced(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := NewFake(start)
+
+	early := f.NewTimer(time.Second)
+	late := f.NewTimer(time.Minute)
+	stopped := f.NewTimer(time.Second)
+	if !stopped.Stop() {
+		t.Fatal("Expected a pending timer to stop")
+	}
+
+	f.Advance(30 * time.Second)
+	select {
+	case at := <-early.C():
+		if !at.Equal(start.Add(30 * time.Second)) {
+			t.Errorf("Expected the timer to fire at the advanced time, got %v", at)
+		}
+	default:
+		t.Fatal("Expected the due timer to fire")
+	}
+	select {
+	case <-late.C():
+		t.Fatal("Expected the later timer not to fire yet")
+	case <-stopped.C():
+		t.Fatal("Expected the stopped timer never to fire")
+	default:
+	}
+
+	if !f.AdvanceToNext() {
+		t.Fatal("Expected a pending timer to advance to")
+	}
+	if got := f.Now(); !got.Equal(start.Add(time.Minute)) {
+		t.Errorf("Expected to advance to the next timer at %v, got %v", start.Add(time.Minute), got)
+	}
+	<-late.C()
+	if f.AdvanceToNext() {
+		t.Error("Expected nothing left to advance to")
+	}
+}
+
+func TestWithTimeoutOnRunningFake(t *testing.T) {
+	f := NewFake(time.Now())
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+	go f.Run(ctx, 100*time.Microsecond)
+
+	start := time.Now()
+	timeoutCtx, cancel := WithTimeout(context.Background(), f, 24*time.Hour)
+	defer cancel()
+	<-timeoutCtx.Done()
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected a simulated day to pass quickly, took %v", elapsed)
+	}
+	if cause := context.Cause(timeoutCtx); !errors.Is(cause, context.DeadlineExceeded) {
+		t.Errorf("Expected the timeout cause to be DeadlineExceeded, got %v", cause)
+	}
+}
+
+func TestHoldStopsRun(t *testing.T) {
+	f := NewFake(time.Now())
+	release := Hold(f)
+	ctx, stop := context.WithCancel(context.Background())
+	defer stop()
+	go f.Run(ctx, 100*time.Microsecond)
+
+	timer := f.NewTimer(time.Hour)
+	select {
+	case <-timer.C():
+		t.Fatal("Expected a held clock not to advance")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	release()
+	release() // Releasing twice is harmless
+	<-timer.C()
+}
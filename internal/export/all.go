@@ -0,0 +1,119 @@
+package export
+
+import (
+	"context"
+	"time"
+
+	"github.com/vineetjain1712/auction-simulator/config"
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+	"github.com/vineetjain1712/auction-simulator/internal/stats"
+)
+
+// ExportedFile is the outcome of writing one export format
+type ExportedFile struct {
+	Format string // Manifest key, e.g. "json" or "histogram_bids"
+	Path   string // Written file ("" if Err is set)
+	Err    error
+}
+
+// ExportSummary lists what ExportAll produced, in export order
+type ExportSummary struct {
+	Files []ExportedFile
+}
+
+// Path returns the file written for format, if it was written
+func (s ExportSummary) Path(format string) (string, bool) {
+	for _, file := range s.Files {
+		if file.Format == format && file.Err == nil {
+			return file.Path, true
+		}
+	}
+	return "", false
+}
+
+// Failed returns the formats that could not be written
+func (s ExportSummary) Failed() []ExportedFile {
+	var failed []ExportedFile
+	for _, file := range s.Files {
+		if file.Err != nil {
+			failed = append(failed, file)
+		}
+	}
+	return failed
+}
+
+// ExportAll writes every export of a run: the results as JSON and CSV, the
+// summary, resource metrics, histograms, the leaderboard most and least
+// contested auctions, the configuration with the seed actually used (so
+// loading it reproduces the run), and last a manifest of the files written
+// Runs that recorded bidder seeds also get per-bidder statistics.
+// With Archive, the zip of ExportArchive is written before the configuration.
+// A failed format doesn't stop the others; its error is in the summary.
+func (e *Exporter) ExportAll(cfg *config.Config, result models.SimulationResult, statistics stats.Statistics, statsReport string, leaderboard int) ExportSummary {
+	return e.ExportAllContext(context.Background(), cfg, result, statistics, statsReport, leaderboard)
+}
+
+// ExportAllContext is ExportAll, giving up once ctx ends: the JSON and CSV
+// results are abandoned without leaving a file behind, and every format not
+// yet written fails with ctx.Err()
+func (e *Exporter) ExportAllContext(ctx context.Context, cfg *config.Config, result models.SimulationResult, statistics stats.Statistics, statsReport string, leaderboard int) ExportSummary {
+	var summary ExportSummary
+	record := func(format, path string, err error) {
+		summary.Files = append(summary.Files, ExportedFile{Format: format, Path: path, Err: err})
+	}
+	write := func(format string, export func() (string, error)) {
+		if err := ctx.Err(); err != nil {
+			record(format, "", err)
+			return
+		}
+		path, err := export()
+		record(format, path, err)
+	}
+
+	write("json", func() (string, error) { return e.ExportToJSONContext(ctx, result) })
+	write("csv", func() (string, error) { return e.ExportToCSVContext(ctx, result) })
+	write("summary", func() (string, error) { return e.ExportSummary(result, statsReport) })
+	write("resources", func() (string, error) { return e.ExportResourceMetrics(result) })
+
+	histograms, err := []string{"", ""}, ctx.Err()
+	if err == nil {
+		if histograms, err = e.ExportHistograms(statistics); err != nil {
+			histograms = []string{"", ""}
+		}
+	}
+	record("histogram_bids", histograms[0], err)
+	record("histogram_win_amounts", histograms[1], err)
+
+	write("top_auctions", func() (string, error) { return e.ExportTopAuctions(result, leaderboard) })
+	write("bottom_auctions", func() (string, error) { return e.ExportBottomAuctions(result, leaderboard) })
+
+	if result.BidderSeeds != nil {
+		write("bidder_stats", func() (string, error) { return e.ExportBidderStats(result) })
+	}
+
+	if e.Archive {
+		write("archive", func() (string, error) { return e.ExportArchive(result, statistics, statsReport) })
+	}
+
+	runCfg := *cfg
+	runCfg.System.Seed = result.Seed
+	write("config", func() (string, error) { return e.ExportConfig(&runCfg) })
+
+	manifest := Manifest{
+		Generated: time.Now(),
+		Seed:      result.Seed,
+		Files:     make(map[string]string),
+	}
+	for _, file := range summary.Files {
+		switch {
+		case file.Err != nil:
+		case file.Format == "config":
+			manifest.Config = file.Path
+		default:
+			manifest.Files[file.Format] = file.Path
+		}
+	}
+	write("manifest", func() (string, error) { return e.ExportManifest(manifest) })
+
+	return summary
+}
@@ -0,0 +1,94 @@
+package export
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/vineetjain1712/auction-simulator/config"
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+	"github.com/vineetjain1712/auction-simulator/internal/stats"
+)
+
+func TestExportAll(t *testing.T) {
+	result := models.SimulationResult{Seed: 42, TotalDuration: time.Second}
+	summary := NewExporter(t.TempDir()).ExportAll(config.DefaultConfig(), result, stats.Statistics{}, "report", 5)
+
+	if failed := summary.Failed(); len(failed) != 0 {
+		t.Fatalf("Expected every export to succeed, got %+v", failed)
+	}
+
+	formats := []string{
+		"json", "csv", "summary", "resources", "histogram_bids", "histogram_win_amounts",
+		"top_auctions", "bottom_auctions", "config", "manifest",
+	}
+	if len(summary.Files) != len(formats) {
+		t.Fatalf("Expected %d files, got %+v", len(formats), summary.Files)
+	}
+	for i, format := range formats {
+		file := summary.Files[i]
+		if file.Format != format {
+			t.Errorf("File %d: expected format %q, got %q", i, format, file.Format)
+		}
+		if _, err := os.Stat(file.Path); err != nil {
+			t.Errorf("Format %s: %v", format, err)
+		}
+	}
+
+	// The manifest lists the same files
+	path, _ := summary.Path("manifest")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		t.Fatal(err)
+	}
+	if want, _ := summary.Path("config"); manifest.Config != want {
+		t.Errorf("Expected manifest config %s, got %s", want, manifest.Config)
+	}
+	for _, format := range formats[:len(formats)-2] {
+		if want, _ := summary.Path(format); manifest.Files[format] != want {
+			t.Errorf("Expected manifest %s file %s, got %s", format, want, manifest.Files[format])
+		}
+	}
+}
+
+func TestExportAllReportsFailures(t *testing.T) {
+	// A file where the output directory should be makes every write fail
+	dir := t.TempDir() + "/blocked"
+	if err := os.WriteFile(dir, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	summary := NewExporter(dir).ExportAll(config.DefaultConfig(), models.SimulationResult{}, stats.Statistics{}, "", 5)
+	if len(summary.Failed()) != len(summary.Files) || len(summary.Files) == 0 {
+		t.Errorf("Expected every export to fail, got %+v", summary.Files)
+	}
+	if _, ok := summary.Path("json"); ok {
+		t.Error("Expected no path for a failed export")
+	}
+}
+
+func TestExportAllContextCancelled(t *testing.T) {
+	dir := t.TempDir()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	summary := NewExporter(dir).ExportAllContext(ctx, config.DefaultConfig(), models.SimulationResult{}, stats.Statistics{}, "", 5)
+	if len(summary.Files) == 0 {
+		t.Fatal("Expected every format in the summary")
+	}
+	for _, file := range summary.Files {
+		if !errors.Is(file.Err, context.Canceled) {
+			t.Errorf("Format %s: expected context.Canceled, got %v", file.Format, file.Err)
+		}
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("Expected no files after cancellation, got %d", len(entries))
+	}
+}
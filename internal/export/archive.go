@@ -0,0 +1,93 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+	"github.com/vineetjain1712/auction-simulator/internal/stats"
+)
+
+// ExportArchive exports the results as JSON and CSV, the summary with
+// statsReport, the resource metrics and the histograms of statistics into
+// a single archive_<timestamp>.zip, e.g. for one CI artifact upload
+// Entries are named by format without timestamps, e.g. simulation.json,
+// and listed in a manifest.json entry. Returns the archive's path.
+func (e *Exporter) ExportArchive(result models.SimulationResult, statistics stats.Statistics, statsReport string) (string, error) {
+	if err := os.MkdirAll(e.outputDir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	type entry struct {
+		format, name string
+		data         []byte
+	}
+	var entries []entry
+
+	data, err := e.marshalJSON(result)
+	if err != nil {
+		return "", err
+	}
+	entries = append(entries, entry{"json", "simulation.json", data})
+	if data, err = e.resultsCSV(context.Background(), result, nil); err != nil {
+		return "", err
+	}
+	entries = append(entries, entry{"csv", "simulation.csv", data})
+	entries = append(entries, entry{"summary", "summary.txt", e.summaryText(result, statsReport)})
+	if data, err = e.resourcesCSV(result); err != nil {
+		return "", err
+	}
+	entries = append(entries, entry{"resources", "resources.csv", data})
+	histograms, err := histogramCSVs(statistics)
+	if err != nil {
+		return "", err
+	}
+	for _, h := range histograms {
+		entries = append(entries, entry{h.prefix, h.prefix + ".csv", h.data})
+	}
+
+	manifest := Manifest{
+		Generated: time.Now(),
+		Seed:      result.Seed,
+		Files:     make(map[string]string, len(entries)),
+	}
+	for _, entry := range entries {
+		manifest.Files[entry.format] = entry.name
+	}
+	if data, err = e.marshalJSON(manifest); err != nil {
+		return "", err
+	}
+	entries = append(entries, entry{"manifest", "manifest.json", data})
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, entry := range entries {
+		w, err := archive.CreateHeader(&zip.FileHeader{
+			Name:     entry.name,
+			Method:   zip.Deflate,
+			Modified: manifest.Generated,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to add %s to archive: %w", entry.name, err)
+		}
+		if _, err := w.Write(entry.data); err != nil {
+			return "", fmt.Errorf("failed to add %s to archive: %w", entry.name, err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return "", fmt.Errorf("failed to write archive: %w", err)
+	}
+
+	timestamp := manifest.Generated.Format("20060102_150405")
+	filename := filepath.Join(e.outputDir, fmt.Sprintf("archive_%s.zip", timestamp))
+	filename, err = e.save(context.Background(), filename, buf.Bytes())
+	if err != nil {
+		return "", fmt.Errorf("failed to write archive: %w", err)
+	}
+	return filename, nil
+}
@@ -0,0 +1,70 @@
+package export
+
+import (
+	"archive/zip"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+	"github.com/vineetjain1712/auction-simulator/internal/stats"
+)
+
+func TestExportArchive(t *testing.T) {
+	result := models.SimulationResult{
+		Seed:          7,
+		TotalAuctions: 1,
+		TotalDuration: time.Second,
+		AuctionResults: []models.AuctionResult{
+			{AuctionID: 1, Item: models.AuctionItem{Name: "Lamp"}, Status: models.StatusNoBids},
+		},
+	}
+	statistics := stats.NewAnalyzer().Analyze(result)
+
+	filename, err := NewExporter(t.TempDir()).ExportArchive(result, statistics, "report")
+	if err != nil {
+		t.Fatalf("Archive export failed: %v", err)
+	}
+
+	archive, err := zip.OpenReader(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer archive.Close()
+
+	expected := []string{
+		"simulation.json", "simulation.csv", "summary.txt", "resources.csv",
+		"histogram_bids.csv", "histogram_win_amounts.csv", "manifest.json",
+	}
+	if len(archive.File) != len(expected) {
+		t.Fatalf("Expected %d entries, got %d", len(expected), len(archive.File))
+	}
+	contents := make(map[string][]byte)
+	for i, file := range archive.File {
+		if file.Name != expected[i] {
+			t.Errorf("Entry %d: expected %s, got %s", i, expected[i], file.Name)
+		}
+		r, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(data) == 0 {
+			t.Errorf("Entry %s is empty", file.Name)
+		}
+		contents[file.Name] = data
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(contents["manifest.json"], &manifest); err != nil {
+		t.Fatal(err)
+	}
+	if manifest.Seed != result.Seed || manifest.Files["csv"] != "simulation.csv" || len(manifest.Files) != len(expected)-1 {
+		t.Errorf("Expected the manifest to list the other entries, got %+v", manifest)
+	}
+}
@@ -0,0 +1,78 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+// ExportBidderStats exports the bids, wins and spending of every bidder in
+// result, with the seed each bidder used if recorded, so a single bidder's
+// decisions can be replayed in isolation (see bidder.NewBidderWithSeed)
+func (e *Exporter) ExportBidderStats(result models.SimulationResult) (string, error) {
+	if err := os.MkdirAll(e.outputDir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	timestamp := time.Now().Format("20060102_150405")
+	filename := filepath.Join(e.outputDir, fmt.Sprintf("bidder_stats_%s.csv", timestamp))
+
+	data, err := e.bidderStatsCSV(result)
+	if err != nil {
+		return "", err
+	}
+	filename, err = e.save(context.Background(), filename, data)
+	if err != nil {
+		return "", fmt.Errorf("failed to write bidder stats CSV: %w", err)
+	}
+
+	return filename, nil
+}
+
+// bidderStatsCSV renders models.BidderStatsOf(result) as CSV rows
+// The Seed column is empty for bidders without a recorded seed.
+func (e *Exporter) bidderStatsCSV(result models.SimulationResult) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := e.newCSVWriter(&buf)
+
+	header := []string{
+		"BidderID",
+		"Seed",
+		"TotalBids",
+		"AuctionsWon",
+		e.Currency.Header("TotalSpent"),
+		e.Currency.Header("AverageWinBid"),
+	}
+	if err := writer.Write(header); err != nil {
+		return nil, fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, stats := range models.BidderStatsOf(result) {
+		seed := ""
+		if _, ok := result.BidderSeeds[stats.BidderID]; ok {
+			seed = fmt.Sprintf("%d", stats.Seed)
+		}
+		row := []string{
+			fmt.Sprintf("%d", stats.BidderID),
+			seed,
+			fmt.Sprintf("%d", stats.TotalBids),
+			fmt.Sprintf("%d", stats.AuctionsWon),
+			e.Currency.FormatNumber(stats.TotalSpent),
+			e.Currency.FormatNumber(stats.AverageWinBid),
+		}
+		if err := writer.Write(row); err != nil {
+			return nil, fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write bidder stats CSV: %w", err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,44 @@
+package export
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+func TestExportBidderStats(t *testing.T) {
+	result := models.SimulationResult{
+		BidderSeeds: map[int]int64{1: 101, 2: 102, 3: 103},
+		AuctionResults: []models.AuctionResult{
+			{AuctionID: 1, WinningBid: &models.Bid{BidderID: 2, Amount: 120},
+				Bids: []models.Bid{{BidderID: 1, Amount: 90}, {BidderID: 2, Amount: 120}}},
+			{AuctionID: 2, WinningBid: &models.Bid{BidderID: 2, Amount: 80},
+				Bids: []models.Bid{{BidderID: 2, Amount: 80}}},
+			{AuctionID: 3, Bids: []models.Bid{{BidderID: 4, Amount: 10}}},
+		},
+	}
+
+	path, err := NewExporter(t.TempDir()).ExportBidderStats(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Bidder 3 never bid but has a seed; bidder 4 bid without a recorded one
+	expected := "BidderID,Seed,TotalBids,AuctionsWon,TotalSpent,AverageWinBid\n" +
+		"1,101,1,0,0.00,0.00\n" +
+		"2,102,2,2,200.00,100.00\n" +
+		"3,103,0,0,0.00,0.00\n" +
+		"4,,1,0,0.00,0.00\n"
+	if got := string(data); got != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, got)
+	}
+	if !strings.Contains(path, "bidder_stats_") {
+		t.Errorf("Unexpected file name %s", path)
+	}
+}
@@ -0,0 +1,65 @@
+package export
+
+import (
+	"context"
+	"crypto/sha256"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// save writes data to the named file like writeContext and returns its path
+// With Dedup, if an earlier export of the same kind in the output directory
+// already holds exactly data, nothing is written and that file's path is
+// returned instead.
+func (e *Exporter) save(ctx context.Context, name string, data []byte) (string, error) {
+	if e.Dedup {
+		if existing, ok := e.findDuplicate(name, data); ok {
+			return existing, ctx.Err()
+		}
+	}
+	if err := e.writeContext(ctx, name, data); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// findDuplicate returns an export next to name with the same prefix and
+// extension, but any timestamp, whose content hashes the same as data
+// Unreadable files are skipped.
+func (e *Exporter) findDuplicate(name string, data []byte) (string, bool) {
+	dir, base := filepath.Split(name)
+	match := outputName.FindStringSubmatch(base)
+	if match == nil {
+		return "", false
+	}
+	ext := filepath.Ext(base)
+	prefix := strings.TrimSuffix(base, "_"+match[1]+ext)
+
+	entries, err := os.ReadDir(filepath.Clean(dir))
+	if err != nil {
+		return "", false
+	}
+
+	sum := sha256.Sum256(data)
+	for _, entry := range entries {
+		other := entry.Name()
+		m := outputName.FindStringSubmatch(other)
+		if entry.IsDir() || m == nil || other != prefix+"_"+m[1]+ext {
+			continue
+		}
+		// Only files of the same size can match
+		if info, err := entry.Info(); err != nil || info.Size() != int64(len(data)) {
+			continue
+		}
+		path := filepath.Join(dir, other)
+		content, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if sha256.Sum256(content) == sum {
+			return path, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,50 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDedupReusesIdenticalExport(t *testing.T) {
+	// exportTwice exports the same result twice, aging the first export so
+	// the second gets a different timestamp, and returns both paths and the
+	// number of simulation JSON files left
+	exportTwice := func(dedup bool) (first, second string, files int) {
+		dir := t.TempDir()
+		exporter := NewExporter(dir)
+		exporter.Dedup = dedup
+		result := sampleResult()
+
+		path, err := exporter.ExportToJSON(result)
+		if err != nil {
+			t.Fatal(err)
+		}
+		first = filepath.Join(dir, "simulation_20000101_000000.json")
+		if err := os.Rename(path, first); err != nil {
+			t.Fatal(err)
+		}
+
+		if second, err = exporter.ExportToJSON(result); err != nil {
+			t.Fatal(err)
+		}
+
+		matches, err := filepath.Glob(filepath.Join(dir, "simulation_*.json"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return first, second, len(matches)
+	}
+
+	first, second, files := exportTwice(true)
+	if files != 1 {
+		t.Errorf("Expected 1 file with dedup, got %d", files)
+	}
+	if second != first {
+		t.Errorf("Expected the second export to return the existing %s, got %s", first, second)
+	}
+
+	if _, _, files := exportTwice(false); files != 2 {
+		t.Errorf("Expected 2 files without dedup, got %d", files)
+	}
+}
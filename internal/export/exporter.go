@@ -1,30 +1,115 @@
 package export
 
 import (
+	"bytes"
+	"context"
 	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"time"
 
+	"github.com/vineetjain1712/auction-simulator/config"
+	"github.com/vineetjain1712/auction-simulator/internal/ascii"
 	"github.com/vineetjain1712/auction-simulator/internal/models"
 )
 
 // Exporter handles exporting simulation results
 type Exporter struct {
 	outputDir string
+
+	// Currency formats amounts and annotates amount columns in CSV exports
+	Currency config.Currency
+	// CSV sets the dialect of CSV exports
+	CSV CSVOptions
+	// MinifyJSON writes JSON exports without indentation, for machine
+	// consumption (about half the size)
+	MinifyJSON bool
+
+	// MaxAttempts is how many times each file write is attempted before
+	// giving up (values below 1 mean a single attempt)
+	MaxAttempts int
+	// RetryBackoff is the wait before the first retry; it doubles on each
+	// further retry
+	RetryBackoff time.Duration
+
+	// Dedup skips writing an export whose content is identical to an
+	// earlier export of the same kind in the output directory, returning
+	// that file's path instead, so repeated runs don't pile up copies
+	Dedup bool
+
+	// ASCII renders text exports such as the summary in plain ASCII (see
+	// package ascii)
+	ASCII bool
+
+	// Archive makes ExportAll also bundle its exports into one zip file
+	// (see ExportArchive)
+	Archive bool
+
+	// writeFile performs a single write attempt (os.WriteFile by default)
+	writeFile func(name string, data []byte, perm os.FileMode) error
+}
+
+// CSVOptions sets the CSV dialect, e.g. semicolons and a BOM for Excel in
+// European locales
+type CSVOptions struct {
+	Delimiter rune // Field separator (0 = ',')
+	BOM       bool // Start files with a UTF-8 byte order mark
+}
+
+// utf8BOM is the UTF-8 encoding of U+FEFF, which tells Excel the file is UTF-8
+const utf8BOM = "\uFEFF"
+
+// newCSVWriter returns a CSV writer to buf in the exporter's dialect,
+// writing the BOM first if enabled
+func (e *Exporter) newCSVWriter(buf *bytes.Buffer) *csv.Writer {
+	if e.CSV.BOM {
+		buf.WriteString(utf8BOM)
+	}
+	writer := csv.NewWriter(buf)
+	if e.CSV.Delimiter != 0 {
+		writer.Comma = e.CSV.Delimiter
+	}
+	return writer
 }
 
 // NewExporter creates a new exporter
+// Writes are attempted once; set MaxAttempts to retry transient failures
 func NewExporter(outputDir string) *Exporter {
 	return &Exporter{
-		outputDir: outputDir,
+		outputDir:    outputDir,
+		Currency:     config.DefaultCurrency,
+		MaxAttempts:  1,
+		RetryBackoff: 100 * time.Millisecond,
+		writeFile:    os.WriteFile,
 	}
 }
 
 // ExportToJSON exports simulation results to JSON file
 func (e *Exporter) ExportToJSON(result models.SimulationResult) (string, error) {
+	return e.ExportToJSONContext(context.Background(), result)
+}
+
+// ExportToJSONContext is ExportToJSON, returning ctx.Err() without leaving a
+// file behind if ctx ends first
+func (e *Exporter) ExportToJSONContext(ctx context.Context, result models.SimulationResult) (string, error) {
+	return e.writeJSONContext(ctx, "simulation", result)
+}
+
+// writeJSON marshals v, indented unless MinifyJSON is set, to
+// <prefix>_<timestamp>.json in the output directory and returns the file path
+func (e *Exporter) writeJSON(prefix string, v any) (string, error) {
+	return e.writeJSONContext(context.Background(), prefix, v)
+}
+
+// writeJSONContext is writeJSON, giving up with ctx.Err() once ctx ends
+func (e *Exporter) writeJSONContext(ctx context.Context, prefix string, v any) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
 	// Create output directory if it doesn't exist
 	if err := os.MkdirAll(e.outputDir, 0o755); err != nil {
 		return "", fmt.Errorf("failed to create output directory: %w", err)
@@ -32,24 +117,253 @@ func (e *Exporter) ExportToJSON(result models.SimulationResult) (string, error)
 
 	// Generate filename with timestamp
 	timestamp := time.Now().Format("20060102_150405")
-	filename := filepath.Join(e.outputDir, fmt.Sprintf("simulation_%s.json", timestamp))
+	filename := filepath.Join(e.outputDir, fmt.Sprintf("%s_%s.json", prefix, timestamp))
 
-	// Marshal to JSON with indentation
-	data, err := json.MarshalIndent(result, "", "  ")
+	data, err := e.marshalJSON(v)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal JSON: %w", err)
+		return "", err
 	}
 
 	// Write to file
-	if err := os.WriteFile(filename, data, 0o644); err != nil {
+	filename, err = e.save(ctx, filename, data)
+	if err != nil {
 		return "", fmt.Errorf("failed to write JSON file: %w", err)
 	}
 
 	return filename, nil
 }
 
+// marshalJSON marshals v, with indentation for human readers unless
+// MinifyJSON is set
+func (e *Exporter) marshalJSON(v any) ([]byte, error) {
+	var data []byte
+	var err error
+	if e.MinifyJSON {
+		data, err = json.Marshal(v)
+	} else {
+		data, err = json.MarshalIndent(v, "", "  ")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	return data, nil
+}
+
+// CompactAuction is the reduced per-auction view written by ExportToCompactJSON.
+// It keeps the outcome of an auction and drops the bulky item attributes.
+type CompactAuction struct {
+	AuctionID      int
+	ItemName       string
+	ItemCategory   models.Category
+	Status         models.AuctionStatus
+	TotalBids      int
+	WinnerBidderID *int     // nil if the auction had no winner
+	WinningAmount  *float64 // nil if the auction had no winner
+	Duration       time.Duration
+}
+
+// CompactSimulation is the reduced view of a SimulationResult written by
+// ExportToCompactJSON.
+type CompactSimulation struct {
+	TotalAuctions      int
+	TotalDuration      time.Duration
+	StartTime          time.Time
+	EndTime            time.Time
+	SuccessfulAuctions int
+	FailedAuctions     int
+	TotalBids          int
+	AuctionResults     []CompactAuction
+}
+
+// NewCompactSimulation builds the compact view of a simulation result
+func NewCompactSimulation(result models.SimulationResult) CompactSimulation {
+	compact := CompactSimulation{
+		TotalAuctions:      result.TotalAuctions,
+		TotalDuration:      result.TotalDuration,
+		StartTime:          result.StartTime,
+		EndTime:            result.EndTime,
+		SuccessfulAuctions: result.SuccessfulAuctions,
+		FailedAuctions:     result.FailedAuctions,
+		TotalBids:          result.TotalBids,
+		AuctionResults:     make([]CompactAuction, 0, len(result.AuctionResults)),
+	}
+
+	for _, auctionResult := range result.AuctionResults {
+		entry := CompactAuction{
+			AuctionID:    auctionResult.AuctionID,
+			ItemName:     auctionResult.Item.Name,
+			ItemCategory: auctionResult.Item.Category,
+			Status:       auctionResult.Status,
+			TotalBids:    auctionResult.TotalBids,
+			Duration:     auctionResult.Duration,
+		}
+		if auctionResult.WinningBid != nil {
+			bidderID := auctionResult.WinningBid.BidderID
+			amount := auctionResult.WinningBid.Amount
+			entry.WinnerBidderID = &bidderID
+			entry.WinningAmount = &amount
+		}
+		compact.AuctionResults = append(compact.AuctionResults, entry)
+	}
+
+	return compact
+}
+
+// ExportToCompactJSON exports simulation results to a JSON file without the
+// full item attributes of each auction
+func (e *Exporter) ExportToCompactJSON(result models.SimulationResult) (string, error) {
+	return e.writeJSON("simulation_compact", NewCompactSimulation(result))
+}
+
+// ExportBidLog exports every bid of a run with its auction, for replaying
+// winner determination with auction.ReplayBidLog
+func (e *Exporter) ExportBidLog(result models.SimulationResult) (string, error) {
+	return e.writeJSON("bid_log", models.NewBidLog(result))
+}
+
+// ExportBidDecisions exports a bid decision log, e.g. from an
+// auction.DecisionRecorder, to a JSON file
+func (e *Exporter) ExportBidDecisions(decisions []models.BidDecision) (string, error) {
+	return e.writeJSON("bid_decisions", decisions)
+}
+
+// ExportConfig exports the effective configuration of a run, so the run can
+// be reproduced from its outputs
+func (e *Exporter) ExportConfig(cfg *config.Config) (string, error) {
+	return e.writeJSON("config", cfg)
+}
+
+// Manifest pairs the outputs of one run with the configuration that produced them
+type Manifest struct {
+	Generated time.Time
+	Seed      int64             // Seed of the run, to reproduce it
+	Config    string            // Path of the exported configuration
+	Files     map[string]string // Export format -> file path
+}
+
+// ExportManifest exports the manifest of a run
+func (e *Exporter) ExportManifest(manifest Manifest) (string, error) {
+	return e.writeJSON("manifest", manifest)
+}
+
+// ExportItems exports an item catalog, without running any auctions, to
+// items_<timestamp>.json and items_<timestamp>.csv
+// Returns the paths of the JSON and CSV files
+func (e *Exporter) ExportItems(items []models.AuctionItem) ([]string, error) {
+	jsonFile, err := e.writeJSON("items", items)
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp := time.Now().Format("20060102_150405")
+	csvFile := filepath.Join(e.outputDir, fmt.Sprintf("items_%s.csv", timestamp))
+
+	var buf bytes.Buffer
+	writer := e.newCSVWriter(&buf)
+
+	// Write header, one column per item attribute
+	header := []string{
+		"ID", "Name", "Category", "Brand", "Condition",
+		"Color", "Size", "Weight", "Material", "YearMade",
+		"Origin", "Rarity", e.Currency.Header("BasePrice"), "Description", "Features",
+		"Warranty", "ShipWeight", "Dimensions", "Certification", "Rating",
+	}
+	if err := writer.Write(header); err != nil {
+		return nil, fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	// Write rows
+	for _, item := range items {
+		row := []string{
+			fmt.Sprintf("%d", item.ID),
+			item.Name,
+			string(item.Category),
+			item.Brand,
+			item.Condition,
+			item.Color,
+			item.Size,
+			fmt.Sprintf("%.2f", item.Weight),
+			item.Material,
+			fmt.Sprintf("%d", item.YearMade),
+			item.Origin,
+			item.Rarity,
+			e.Currency.FormatNumber(item.BasePrice),
+			item.Description,
+			item.Features,
+			fmt.Sprintf("%d", item.Warranty),
+			fmt.Sprintf("%.2f", item.ShipWeight),
+			item.Dimensions,
+			item.Certification,
+			fmt.Sprintf("%.1f", item.Rating),
+		}
+		if err := writer.Write(row); err != nil {
+			return nil, fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write item CSV: %w", err)
+	}
+	csvFile, err = e.save(context.Background(), csvFile, buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to write item CSV: %w", err)
+	}
+
+	return []string{jsonFile, csvFile}, nil
+}
+
+// OnlySuccessful keeps auctions that produced a winner, for ExportFilteredCSV
+func OnlySuccessful(result models.AuctionResult) bool {
+	return models.HasWinner(result)
+}
+
+// OnlyFailed keeps auctions without a winner (no bids, reserve not met, ...),
+// for ExportFilteredCSV
+func OnlyFailed(result models.AuctionResult) bool {
+	return !models.HasWinner(result)
+}
+
 // ExportToCSV exports auction results to CSV file
 func (e *Exporter) ExportToCSV(result models.SimulationResult) (string, error) {
+	return e.writeResultsCSV(context.Background(), "simulation", result, nil)
+}
+
+// ExportToCSVContext is ExportToCSV, checking ctx between rows and
+// returning ctx.Err() without leaving a file behind if ctx ends first
+func (e *Exporter) ExportToCSVContext(ctx context.Context, result models.SimulationResult) (string, error) {
+	return e.writeResultsCSV(ctx, "simulation", result, nil)
+}
+
+// ExportFilteredCSV exports the auction results matching keep, e.g.
+// OnlyFailed, to a CSV file in the same format as ExportToCSV
+// The file is named <name>_auctions_<timestamp>.csv, so exports under
+// different filters, such as "failed" and "successful", do not overwrite
+// one another. name may only hold lowercase letters and underscores.
+func (e *Exporter) ExportFilteredCSV(result models.SimulationResult, name string, keep func(models.AuctionResult) bool) (string, error) {
+	return e.ExportFilteredCSVContext(context.Background(), result, name, keep)
+}
+
+// ExportFilteredCSVContext is ExportFilteredCSV with the cancellation of
+// ExportToCSVContext
+func (e *Exporter) ExportFilteredCSVContext(ctx context.Context, result models.SimulationResult, name string, keep func(models.AuctionResult) bool) (string, error) {
+	if !filterName.MatchString(name) {
+		return "", fmt.Errorf("invalid filter name %q: use lowercase letters and underscores", name)
+	}
+	return e.writeResultsCSV(ctx, name+"_auctions", result, keep)
+}
+
+// filterName matches the names ExportFilteredCSV accepts, which keep its
+// files recognizable to Rotate and Dedup
+var filterName = regexp.MustCompile(`^[a-z_]+$`)
+
+// writeResultsCSV writes the auction results matching keep (nil = all) to
+// <prefix>_<timestamp>.csv in the output directory and returns the file path
+func (e *Exporter) writeResultsCSV(ctx context.Context, prefix string, result models.SimulationResult, keep func(models.AuctionResult) bool) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+
 	// Create output directory if it doesn't exist
 	if err := os.MkdirAll(e.outputDir, 0o755); err != nil {
 		return "", fmt.Errorf("failed to create output directory: %w", err)
@@ -57,42 +371,61 @@ func (e *Exporter) ExportToCSV(result models.SimulationResult) (string, error) {
 
 	// Generate filename with timestamp
 	timestamp := time.Now().Format("20060102_150405")
-	filename := filepath.Join(e.outputDir, fmt.Sprintf("simulation_%s.csv", timestamp))
+	filename := filepath.Join(e.outputDir, fmt.Sprintf("%s_%s.csv", prefix, timestamp))
 
-	// Create file
-	file, err := os.Create(filename)
+	// Build the file in memory so it is written in a single (retried) step
+	data, err := e.resultsCSV(ctx, result, keep)
 	if err != nil {
-		return "", fmt.Errorf("failed to create CSV file: %w", err)
+		return "", err
+	}
+	filename, err = e.save(ctx, filename, data)
+	if err != nil {
+		return "", fmt.Errorf("failed to write CSV file: %w", err)
 	}
-	defer file.Close()
 
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
+	return filename, nil
+}
+
+// resultsCSV renders the auction results matching keep (nil = all) as CSV,
+// checking ctx between rows
+func (e *Exporter) resultsCSV(ctx context.Context, result models.SimulationResult, keep func(models.AuctionResult) bool) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := e.newCSVWriter(&buf)
 
 	// Write header
 	header := []string{
 		"AuctionID",
 		"ItemName",
 		"ItemCategory",
-		"BasePrice",
+		e.Currency.Header("BasePrice"),
 		"Status",
 		"TotalBids",
 		"WinnerBidderID",
-		"WinningAmount",
+		e.Currency.Header("WinningAmount"),
 		"Duration_ms",
+		"RunnerUpBidderID",
+		e.Currency.Header("RunnerUpAmount"),
 	}
 	if err := writer.Write(header); err != nil {
-		return "", fmt.Errorf("failed to write CSV header: %w", err)
+		return nil, fmt.Errorf("failed to write CSV header: %w", err)
 	}
 
 	// Write rows
 	for _, auctionResult := range result.AuctionResults {
+		// Nothing is on disk yet, so a cancelled export leaves no file
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if keep != nil && !keep(auctionResult) {
+			continue
+		}
+
 		row := []string{
 			fmt.Sprintf("%d", auctionResult.AuctionID),
 			auctionResult.Item.Name,
-			auctionResult.Item.Category,
-			fmt.Sprintf("%.2f", auctionResult.Item.BasePrice),
-			auctionResult.Status,
+			string(auctionResult.Item.Category),
+			e.Currency.FormatNumber(auctionResult.Item.BasePrice),
+			auctionResult.Status.String(),
 			fmt.Sprintf("%d", auctionResult.TotalBids),
 		}
 
@@ -100,7 +433,7 @@ func (e *Exporter) ExportToCSV(result models.SimulationResult) (string, error) {
 		if auctionResult.WinningBid != nil {
 			row = append(row,
 				fmt.Sprintf("%d", auctionResult.WinningBid.BidderID),
-				fmt.Sprintf("%.2f", auctionResult.WinningBid.Amount),
+				e.Currency.FormatNumber(auctionResult.WinningBid.Amount),
 			)
 		} else {
 			row = append(row, "N/A", "N/A")
@@ -109,12 +442,26 @@ func (e *Exporter) ExportToCSV(result models.SimulationResult) (string, error) {
 		// Add duration
 		row = append(row, fmt.Sprintf("%d", auctionResult.Duration.Milliseconds()))
 
+		// Add runner-up info
+		if auctionResult.RunnerUp != nil {
+			row = append(row,
+				fmt.Sprintf("%d", auctionResult.RunnerUp.BidderID),
+				e.Currency.FormatNumber(auctionResult.RunnerUp.Amount),
+			)
+		} else {
+			row = append(row, "N/A", "N/A")
+		}
+
 		if err := writer.Write(row); err != nil {
-			return "", fmt.Errorf("failed to write CSV row: %w", err)
+			return nil, fmt.Errorf("failed to write CSV row: %w", err)
 		}
 	}
 
-	return filename, nil
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to write CSV file: %w", err)
+	}
+	return buf.Bytes(), nil
 }
 
 // ExportSummary exports a summary text file
@@ -128,7 +475,18 @@ func (e *Exporter) ExportSummary(result models.SimulationResult, statsReport str
 	timestamp := time.Now().Format("20060102_150405")
 	filename := filepath.Join(e.outputDir, fmt.Sprintf("summary_%s.txt", timestamp))
 
-	// Create summary content
+	// Write to file
+	filename, err := e.save(context.Background(), filename, e.summaryText(result, statsReport))
+	if err != nil {
+		return "", fmt.Errorf("failed to write summary file: %w", err)
+	}
+
+	return filename, nil
+}
+
+// summaryText renders the summary text of result, ending with statsReport
+// With ASCII set it is rendered in plain ASCII.
+func (e *Exporter) summaryText(result models.SimulationResult, statsReport string) []byte {
 	summary := fmt.Sprintf("AUCTION SIMULATION SUMMARY\n")
 	summary += fmt.Sprintf("Generated: %s\n", time.Now().Format("2006-01-02 15:04:05"))
 	summary += fmt.Sprintf("═══════════════════════════════════════════════════════\n\n")
@@ -142,16 +500,15 @@ func (e *Exporter) ExportSummary(result models.SimulationResult, statsReport str
 	summary += fmt.Sprintf("  Total Auctions: %d\n", result.TotalAuctions)
 	summary += fmt.Sprintf("  Successful: %d\n", result.SuccessfulAuctions)
 	summary += fmt.Sprintf("  Failed: %d\n", result.FailedAuctions)
-	summary += fmt.Sprintf("  Total Bids: %d\n\n", result.TotalBids)
+	summary += fmt.Sprintf("  Total Bids: %d\n", result.TotalBids)
+	summary += fmt.Sprintf("  Duplicate Bids: %d\n", result.DuplicateBids)
+	summary += fmt.Sprintf("  Bidder Dropouts: %d\n\n", result.BidderDropouts)
 
 	summary += statsReport
-
-	// Write to file
-	if err := os.WriteFile(filename, []byte(summary), 0o644); err != nil {
-		return "", fmt.Errorf("failed to write summary file: %w", err)
+	if e.ASCII {
+		summary = ascii.String(summary)
 	}
-
-	return filename, nil
+	return []byte(summary)
 }
 
 // ExportResourceMetrics exports resource usage to a separate CSV
@@ -159,19 +516,28 @@ func (e *Exporter) ExportResourceMetrics(result models.SimulationResult) (string
 	if err := os.MkdirAll(e.outputDir, 0755); err != nil {
 		return "", fmt.Errorf("failed to create output directory: %w", err)
 	}
-	
+
 	timestamp := time.Now().Format("20060102_150405")
 	filename := filepath.Join(e.outputDir, fmt.Sprintf("resources_%s.csv", timestamp))
-	
-	file, err := os.Create(filename)
+
+	data, err := e.resourcesCSV(result)
 	if err != nil {
-		return "", fmt.Errorf("failed to create resource CSV: %w", err)
+		return "", err
 	}
-	defer file.Close()
-	
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
-	
+	filename, err = e.save(context.Background(), filename, data)
+	if err != nil {
+		return "", fmt.Errorf("failed to write resource CSV: %w", err)
+	}
+
+	return filename, nil
+}
+
+// resourcesCSV renders the resource usage of result as Metric, Value, Unit
+// CSV rows
+func (e *Exporter) resourcesCSV(result models.SimulationResult) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := e.newCSVWriter(&buf)
+
 	// Write header
 	header := []string{
 		"Metric",
@@ -179,9 +545,15 @@ func (e *Exporter) ExportResourceMetrics(result models.SimulationResult) (string
 		"Unit",
 	}
 	if err := writer.Write(header); err != nil {
-		return "", err
+		return nil, err
+	}
+
+	// An empty or instant run has no rate rather than an infinite one
+	bidsPerSecond := 0.0
+	if seconds := result.TotalDuration.Seconds(); seconds > 0 {
+		bidsPerSecond = float64(result.TotalBids) / seconds
 	}
-	
+
 	// Write rows
 	rows := [][]string{
 		{"CPU_Available", fmt.Sprintf("%d", result.CPUCount), "cores"},
@@ -191,15 +563,23 @@ func (e *Exporter) ExportResourceMetrics(result models.SimulationResult) (string
 		{"Peak_Memory", fmt.Sprintf("%.2f", result.PeakMemoryMB), "MB"},
 		{"Average_Memory", fmt.Sprintf("%.2f", result.AverageMemoryMB), "MB"},
 		{"Peak_Goroutines", fmt.Sprintf("%d", result.PeakGoroutines), "count"},
+		{"Peak_Concurrent_Auctions", fmt.Sprintf("%d", result.PeakConcurrentAuctions), "count"},
+		{"GC_Cycles", fmt.Sprintf("%d", result.NumGC), "count"},
+		{"GC_Pause_Total", fmt.Sprintf("%.3f", float64(result.TotalGCPause.Microseconds())/1000), "ms"},
+		{"GC_Pause_Last", fmt.Sprintf("%.3f", float64(result.LastGCPause.Microseconds())/1000), "ms"},
 		{"Duration", fmt.Sprintf("%.3f", result.TotalDuration.Seconds()), "seconds"},
-		{"Bids_Per_Second", fmt.Sprintf("%.1f", float64(result.TotalBids)/result.TotalDuration.Seconds()), "bids/s"},
+		{"Bids_Per_Second", fmt.Sprintf("%.1f", bidsPerSecond), "bids/s"},
 	}
-	
+
 	for _, row := range rows {
 		if err := writer.Write(row); err != nil {
-			return "", err
+			return nil, err
 		}
 	}
-	
-	return filename, nil
-}
\ No newline at end of file
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
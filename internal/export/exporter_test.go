@@ -0,0 +1,491 @@
+package export
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vineetjain1712/auction-simulator/config"
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+// sampleResult builds a small simulation result with one successful and one failed auction
+func sampleResult() models.SimulationResult {
+	start := time.Now()
+	item := models.AuctionItem{
+		ID:            1,
+		Name:          "Sony Electronics 1",
+		Category:      "Electronics",
+		Brand:         "Sony",
+		Condition:     "New",
+		Color:         "Black",
+		Size:          "Medium",
+		Weight:        2.5,
+		Material:      "Metal",
+		YearMade:      2020,
+		Origin:        "Japan",
+		Rarity:        "Rare",
+		BasePrice:     100.0,
+		Description:   "High quality Electronics from Sony",
+		Features:      "Premium Electronics with excellent quality",
+		Warranty:      12,
+		ShipWeight:    3.0,
+		Dimensions:    "10.0x20.0x30.0",
+		Certification: "CE",
+		Rating:        8.5,
+	}
+	other := item
+	other.ID = 2
+	other.Name = "Sony Electronics 2"
+
+	return models.SimulationResult{
+		TotalAuctions: 2,
+		TotalDuration: time.Second,
+		StartTime:     start,
+		EndTime:       start.Add(time.Second),
+		AuctionResults: []models.AuctionResult{
+			{
+				AuctionID:  1,
+				Item:       item,
+				WinningBid: &models.Bid{BidderID: 7, AuctionID: 1, Amount: 150.0, Timestamp: start},
+				TotalBids:  3,
+				Duration:   time.Second,
+				StartTime:  start,
+				EndTime:    start.Add(time.Second),
+				Status:     "completed",
+			},
+			{
+				AuctionID: 2,
+				Item:      other,
+				Duration:  time.Second,
+				StartTime: start,
+				EndTime:   start.Add(time.Second),
+				Status:    "no_bids",
+			},
+		},
+		SuccessfulAuctions: 1,
+		FailedAuctions:     1,
+		TotalBids:          3,
+	}
+}
+
+func TestExportToCompactJSON(t *testing.T) {
+	exporter := NewExporter(t.TempDir())
+	result := sampleResult()
+
+	fullFile, err := exporter.ExportToJSON(result)
+	if err != nil {
+		t.Fatalf("full export failed: %v", err)
+	}
+	compactFile, err := exporter.ExportToCompactJSON(result)
+	if err != nil {
+		t.Fatalf("compact export failed: %v", err)
+	}
+
+	fullInfo, err := os.Stat(fullFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	compactInfo, err := os.Stat(compactFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if compactInfo.Size() >= fullInfo.Size() {
+		t.Errorf("Expected compact export (%d bytes) to be smaller than full export (%d bytes)",
+			compactInfo.Size(), fullInfo.Size())
+	}
+
+	data, err := os.ReadFile(compactFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded struct {
+		AuctionResults []map[string]any
+	}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to decode compact export: %v", err)
+	}
+	if len(decoded.AuctionResults) != 2 {
+		t.Fatalf("Expected 2 auctions, got %d", len(decoded.AuctionResults))
+	}
+
+	expected := []string{
+		"AuctionID", "Duration", "ItemCategory", "ItemName",
+		"Status", "TotalBids", "WinnerBidderID", "WinningAmount",
+	}
+	for _, auction := range decoded.AuctionResults {
+		keys := make([]string, 0, len(auction))
+		for key := range auction {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		if len(keys) != len(expected) {
+			t.Fatalf("Expected fields %v, got %v", expected, keys)
+		}
+		for i := range keys {
+			if keys[i] != expected[i] {
+				t.Fatalf("Expected fields %v, got %v", expected, keys)
+			}
+		}
+	}
+
+	winner := decoded.AuctionResults[0]
+	if winner["WinnerBidderID"] != float64(7) || winner["WinningAmount"] != 150.0 {
+		t.Errorf("Unexpected winner fields: %v", winner)
+	}
+	if decoded.AuctionResults[1]["WinnerBidderID"] != nil {
+		t.Errorf("Expected null winner for auction without bids, got %v", decoded.AuctionResults[1]["WinnerBidderID"])
+	}
+}
+
+func TestExportConfigRoundTrip(t *testing.T) {
+	exporter := NewExporter(t.TempDir())
+
+	cfg := config.DefaultConfig()
+	cfg.Auction.TotalAuctions = 7
+	cfg.Bidder.BidRate = 2.5
+	cfg.System.Quiet = true
+
+	filename, err := exporter.ExportConfig(cfg)
+	if err != nil {
+		t.Fatalf("config export failed: %v", err)
+	}
+	if !strings.HasPrefix(filepath.Base(filename), "config_") {
+		t.Errorf("Expected config_<ts>.json, got %s", filename)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded config.Config
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to decode config: %v", err)
+	}
+
+	if !reflect.DeepEqual(*cfg, decoded) {
+		t.Errorf("Config did not round-trip:\nwant %+v\ngot  %+v", *cfg, decoded)
+	}
+}
+
+func TestExportItems(t *testing.T) {
+	exporter := NewExporter(t.TempDir())
+
+	const n = 4
+	base := sampleResult().AuctionResults[0].Item
+	items := make([]models.AuctionItem, n)
+	for i := range items {
+		items[i] = base
+		items[i].ID = i + 1
+	}
+
+	files, err := exporter.ExportItems(items)
+	if err != nil {
+		t.Fatalf("item export failed: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("Expected JSON and CSV files, got %v", files)
+	}
+
+	// JSON: every item with every attribute, plus its hot designation
+	data, err := os.ReadFile(files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded []map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to decode item JSON: %v", err)
+	}
+	if len(decoded) != n {
+		t.Fatalf("Expected %d items in JSON, got %d", n, len(decoded))
+	}
+	for _, item := range decoded {
+		if _, ok := item["Hot"]; len(item) != 21 || !ok {
+			t.Errorf("Expected 20 attributes and Hot per JSON item, got %d fields", len(item))
+		}
+	}
+
+	// CSV: header plus one row per item, 20 columns each
+	file, err := os.Open(files[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read item CSV: %v", err)
+	}
+	if len(rows) != n+1 {
+		t.Fatalf("Expected header and %d rows, got %d rows", n, len(rows))
+	}
+	for i, row := range rows {
+		if len(row) != 20 {
+			t.Errorf("Row %d: expected 20 columns, got %d", i, len(row))
+		}
+	}
+	if rows[2][0] != "2" || rows[2][1] != base.Name {
+		t.Errorf("Unexpected second item row: %v", rows[2])
+	}
+}
+
+func TestExportToCSVCurrency(t *testing.T) {
+	exporter := NewExporter(t.TempDir())
+	exporter.Currency = config.Currency{Symbol: "¥", Code: "JPY", Decimals: 0}
+
+	filename, err := exporter.ExportToCSV(sampleResult())
+	if err != nil {
+		t.Fatalf("CSV export failed: %v", err)
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rows[0][3] != "BasePrice_JPY" || rows[0][7] != "WinningAmount_JPY" {
+		t.Errorf("Expected currency-annotated amount headers, got %v", rows[0])
+	}
+	if rows[1][3] != "100" || rows[1][7] != "150" {
+		t.Errorf("Expected amounts without decimals, got %v", rows[1])
+	}
+}
+
+func TestExportToCSVDialect(t *testing.T) {
+	exporter := NewExporter(t.TempDir())
+	exporter.CSV = CSVOptions{Delimiter: ';', BOM: true}
+
+	filename, err := exporter.ExportToCSV(sampleResult())
+	if err != nil {
+		t.Fatalf("CSV export failed: %v", err)
+	}
+
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	header, _, _ := strings.Cut(string(data), "\n")
+	want := "\ufeffAuctionID;ItemName;ItemCategory;BasePrice;Status;TotalBids;WinnerBidderID;WinningAmount;Duration_ms;RunnerUpBidderID;RunnerUpAmount"
+	if header != want {
+		t.Errorf("Unexpected header line:\ngot  %q\nwant %q", header, want)
+	}
+}
+
+func TestExportFilteredCSV(t *testing.T) {
+	result := sampleResult()
+	result.AuctionResults = append(result.AuctionResults, models.AuctionResult{
+		AuctionID: 3,
+		Item:      result.AuctionResults[0].Item,
+		TotalBids: 2,
+		Status:    "reserve_not_met",
+	})
+
+	exporter := NewExporter(t.TempDir())
+	files := make(map[string]bool)
+	for _, tc := range []struct {
+		name string
+		keep func(models.AuctionResult) bool
+		want []string
+	}{
+		{"failed", OnlyFailed, []string{"2", "3"}},
+		{"successful", OnlySuccessful, []string{"1"}},
+	} {
+		filename, err := exporter.ExportFilteredCSV(result, tc.name, tc.keep)
+		if err != nil {
+			t.Fatalf("%s: filtered CSV export failed: %v", tc.name, err)
+		}
+		if files[filename] {
+			t.Errorf("%s: expected its own file, got %s again", tc.name, filename)
+		}
+		files[filename] = true
+
+		file, err := os.Open(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows, err := csv.NewReader(file).ReadAll()
+		file.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var ids []string
+		for _, row := range rows[1:] {
+			ids = append(ids, row[0])
+		}
+		if !reflect.DeepEqual(ids, tc.want) {
+			t.Errorf("%s: expected auctions %v, got %v", tc.name, tc.want, ids)
+		}
+	}
+
+	if _, err := exporter.ExportFilteredCSV(result, "Failed/", OnlyFailed); err == nil {
+		t.Error("Expected an invalid filter name to be rejected")
+	}
+}
+
+func TestExportToJSONMinified(t *testing.T) {
+	result := sampleResult()
+
+	indented := NewExporter(t.TempDir())
+	minified := NewExporter(t.TempDir())
+	minified.MinifyJSON = true
+
+	decode := func(exporter *Exporter) (int, models.SimulationResult) {
+		filename, err := exporter.ExportToJSON(result)
+		if err != nil {
+			t.Fatalf("JSON export failed: %v", err)
+		}
+		data, err := os.ReadFile(filename)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var decoded models.SimulationResult
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			t.Fatalf("failed to decode JSON: %v", err)
+		}
+		return len(data), decoded
+	}
+
+	indentedSize, fromIndented := decode(indented)
+	minifiedSize, fromMinified := decode(minified)
+
+	if minifiedSize >= indentedSize {
+		t.Errorf("Expected minified JSON (%d bytes) to be smaller than indented JSON (%d bytes)",
+			minifiedSize, indentedSize)
+	}
+	if !reflect.DeepEqual(fromIndented, fromMinified) {
+		t.Error("Expected minified and indented JSON to decode to the same result")
+	}
+}
+
+func TestExportBidDecisions(t *testing.T) {
+	decisions := []models.BidDecision{
+		{AuctionID: 1, BidderID: 1, Amount: 150, Accepted: true, Reason: models.ReasonAccepted},
+		{AuctionID: 1, BidderID: 2, Amount: 80, Reason: models.ReasonTooLow},
+	}
+
+	filename, err := NewExporter(t.TempDir()).ExportBidDecisions(decisions)
+	if err != nil {
+		t.Fatalf("Bid decision export failed: %v", err)
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded []models.BidDecision
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("failed to decode JSON: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, decisions) {
+		t.Errorf("Expected %+v, got %+v", decisions, decoded)
+	}
+	if !strings.Contains(string(data), `"Reason": "too_low"`) {
+		t.Error("Expected reasons exported as their string values")
+	}
+}
+
+func TestExportCSVCancelled(t *testing.T) {
+	// A large result, cancelled part way through its rows
+	result := sampleResult()
+	template := result.AuctionResults[0]
+	result.AuctionResults = nil
+	for id := 1; id <= 10_000; id++ {
+		r := template
+		r.AuctionID = id
+		result.AuctionResults = append(result.AuctionResults, r)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	rows := 0
+	cancelHalfway := func(models.AuctionResult) bool {
+		if rows++; rows == 5_000 {
+			cancel()
+		}
+		return true
+	}
+
+	dir := t.TempDir()
+	_, err := NewExporter(dir).ExportFilteredCSVContext(ctx, result, "all", cancelHalfway)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected a cancellation error, got %v", err)
+	}
+	if rows >= len(result.AuctionResults) {
+		t.Errorf("Expected the export to stop early, visited all %d rows", rows)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Expected no partial files, found %d", len(entries))
+	}
+
+	// An already cancelled context writes nothing, JSON included
+	if _, err := NewExporter(dir).ExportToJSONContext(ctx, result); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected JSON export to be cancelled, got %v", err)
+	}
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("Expected no files after a cancelled JSON export, found %d", len(entries))
+	}
+}
+
+func TestExportSummaryASCII(t *testing.T) {
+	exporter := NewExporter(t.TempDir())
+	exporter.ASCII = true
+
+	path, err := exporter.ExportSummary(sampleResult(), "\n📈 DETAILED STATISTICS\n═══\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, c := range data {
+		if c >= 0x80 {
+			t.Fatalf("Expected only ASCII, got byte %#x at %d in:\n%s", c, i, data)
+		}
+	}
+}
+
+func TestExportResourceMetricsInstantRun(t *testing.T) {
+	path, err := NewExporter(t.TempDir()).ExportResourceMetrics(models.SimulationResult{TotalBids: 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, row := range rows {
+		if row[0] == "Bids_Per_Second" {
+			if row[1] != "0.0" {
+				t.Errorf("Expected 0 bids/s for a run without duration, got %q", row[1])
+			}
+			return
+		}
+	}
+	t.Error("Expected a Bids_Per_Second row")
+}
@@ -0,0 +1,44 @@
+package export
+
+import (
+	"fmt"
+	"os"
+)
+
+// OutputDir returns the directory exports are written to
+func (e *Exporter) OutputDir() string {
+	return e.outputDir
+}
+
+// PrepareOutputDir makes sure exports can be written to the output
+// directory, creating it if needed. If it can't be used, e.g. it is
+// read-only, exports switch to a new temporary directory instead so a run's
+// results are not lost.
+// Returns the directory exports now go to and whether it is the fallback.
+func (e *Exporter) PrepareOutputDir() (dir string, fallback bool, err error) {
+	probeErr := probeDir(e.outputDir)
+	if probeErr == nil {
+		return e.outputDir, false, nil
+	}
+
+	tempDir, err := os.MkdirTemp("", "auction-simulator-*")
+	if err != nil {
+		return "", false, fmt.Errorf("output directory %s is unusable (%v) and no fallback could be created: %w",
+			e.outputDir, probeErr, err)
+	}
+	e.outputDir = tempDir
+	return tempDir, true, nil
+}
+
+// probeDir creates dir if needed and checks that files can be written to it
+func probeDir(dir string) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(dir, ".probe-*")
+	if err != nil {
+		return err
+	}
+	f.Close()
+	return os.Remove(f.Name())
+}
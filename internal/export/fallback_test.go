@@ -0,0 +1,54 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+func TestPrepareOutputDirFallsBack(t *testing.T) {
+	// A directory can't be created under a regular file, whatever the
+	// permissions of the user running the test
+	blocker := filepath.Join(t.TempDir(), "blocker")
+	if err := os.WriteFile(blocker, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	unusable := filepath.Join(blocker, "output")
+
+	exporter := NewExporter(unusable)
+	dir, fallback, err := exporter.PrepareOutputDir()
+	if err != nil {
+		t.Fatalf("Expected a fallback directory, got %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	if !fallback || dir == unusable {
+		t.Fatalf("Expected a fallback instead of %s, got %s (fallback=%v)", unusable, dir, fallback)
+	}
+	if exporter.OutputDir() != dir {
+		t.Errorf("Expected exports to go to %s, got %s", dir, exporter.OutputDir())
+	}
+
+	filename, err := exporter.ExportToJSON(models.SimulationResult{})
+	if err != nil {
+		t.Fatalf("Expected export to the fallback to succeed, got %v", err)
+	}
+	if !strings.HasPrefix(filename, dir) {
+		t.Errorf("Expected %s in the fallback directory %s", filename, dir)
+	}
+}
+
+func TestPrepareOutputDirKeepsUsableDir(t *testing.T) {
+	want := filepath.Join(t.TempDir(), "output")
+
+	dir, fallback, err := NewExporter(want).PrepareOutputDir()
+	if err != nil || fallback || dir != want {
+		t.Errorf("Expected %s to be used as is, got %s (fallback=%v, err=%v)", want, dir, fallback, err)
+	}
+	if entries, _ := os.ReadDir(want); len(entries) != 0 {
+		t.Errorf("Expected the probe to leave no files, got %d", len(entries))
+	}
+}
@@ -0,0 +1,79 @@
+package export
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/vineetjain1712/auction-simulator/internal/stats"
+)
+
+// ExportHistograms exports the bid-count and winning-amount histograms of
+// statistics as two-column CSV files (Bucket, Count) ready for plotting
+// tools such as gnuplot or matplotlib. Each bucket is labelled with its
+// lower bound. The files are always plain comma-separated without a BOM,
+// whatever the CSV dialect, so plotting tools read them as is.
+func (e *Exporter) ExportHistograms(statistics stats.Statistics) ([]string, error) {
+	if err := os.MkdirAll(e.outputDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	histograms, err := histogramCSVs(statistics)
+	if err != nil {
+		return nil, err
+	}
+
+	timestamp := time.Now().Format("20060102_150405")
+	files := make([]string, 0, len(histograms))
+	for _, h := range histograms {
+		filename := filepath.Join(e.outputDir, fmt.Sprintf("%s_%s.csv", h.prefix, timestamp))
+		filename, err := e.save(context.Background(), filename, h.data)
+		if err != nil {
+			return files, fmt.Errorf("failed to write histogram CSV: %w", err)
+		}
+		files = append(files, filename)
+	}
+
+	return files, nil
+}
+
+// histogramCSV is a rendered histogram and the file name prefix it is
+// exported under
+type histogramCSV struct {
+	prefix string
+	data   []byte
+}
+
+// histogramCSVs renders the bid-count and winning-amount histograms of
+// statistics, in that order
+func histogramCSVs(statistics stats.Statistics) ([]histogramCSV, error) {
+	histograms := []struct {
+		prefix  string
+		format  string
+		buckets []stats.HistogramBucket
+	}{
+		{"histogram_bids", "%.0f", statistics.BidCountHistogram},
+		{"histogram_win_amounts", "%.2f", statistics.WinAmountHistogram},
+	}
+
+	rendered := make([]histogramCSV, 0, len(histograms))
+	for _, h := range histograms {
+		var buf bytes.Buffer
+		writer := csv.NewWriter(&buf)
+		writer.Write([]string{"Bucket", "Count"})
+		for _, bucket := range h.buckets {
+			writer.Write([]string{fmt.Sprintf(h.format, bucket.Lower), fmt.Sprintf("%d", bucket.Count)})
+		}
+
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			return nil, fmt.Errorf("failed to write histogram CSV: %w", err)
+		}
+		rendered = append(rendered, histogramCSV{h.prefix, buf.Bytes()})
+	}
+	return rendered, nil
+}
@@ -0,0 +1,77 @@
+package export
+
+import (
+	"encoding/csv"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+	"github.com/vineetjain1712/auction-simulator/internal/stats"
+)
+
+func TestExportHistograms(t *testing.T) {
+	result := models.SimulationResult{}
+	won := 0
+	for id := 1; id <= 30; id++ {
+		r := models.AuctionResult{AuctionID: id, TotalBids: id % 7}
+		if r.TotalBids > 0 {
+			r.WinningBid = &models.Bid{BidderID: 1, Amount: float64(100 + 10*id)}
+			won++
+		}
+		result.AuctionResults = append(result.AuctionResults, r)
+	}
+	result.TotalAuctions = len(result.AuctionResults)
+
+	files, err := NewExporter(t.TempDir()).ExportHistograms(stats.NewAnalyzer().Analyze(result))
+	if err != nil {
+		t.Fatalf("Histogram export failed: %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("Expected 2 histogram files, got %d", len(files))
+	}
+
+	// Bid counts 0-6 get one bucket each; amounts fill 10 buckets
+	expected := []struct {
+		name    string
+		buckets int
+		total   int
+	}{
+		{"histogram_bids", 7, 30},
+		{"histogram_win_amounts", 10, won},
+	}
+	for i, want := range expected {
+		if !strings.Contains(files[i], want.name) {
+			t.Errorf("Expected file %d to be a %s file, got %s", i, want.name, files[i])
+		}
+
+		f, err := os.Open(files[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+		records, err := csv.NewReader(f).ReadAll()
+		f.Close()
+		if err != nil {
+			t.Fatalf("%s: failed to parse CSV: %v", want.name, err)
+		}
+
+		if len(records[0]) != 2 || records[0][0] != "Bucket" || records[0][1] != "Count" {
+			t.Errorf("%s: unexpected header %v", want.name, records[0])
+		}
+		if len(records)-1 != want.buckets {
+			t.Errorf("%s: expected %d bucket rows, got %d", want.name, want.buckets, len(records)-1)
+		}
+		sum := 0
+		for _, record := range records[1:] {
+			count, err := strconv.Atoi(record[1])
+			if err != nil {
+				t.Fatalf("%s: bad count %q", want.name, record[1])
+			}
+			sum += count
+		}
+		if sum != want.total {
+			t.Errorf("%s: expected counts summing to %d, got %d", want.name, want.total, sum)
+		}
+	}
+}
@@ -0,0 +1,22 @@
+package export
+
+import (
+	"context"
+
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+	"github.com/vineetjain1712/auction-simulator/internal/stats"
+)
+
+// ExportTopAuctions exports the n auctions of result with the most bids,
+// most first, in the columns of ExportToCSV
+func (e *Exporter) ExportTopAuctions(result models.SimulationResult, n int) (string, error) {
+	result.AuctionResults = stats.TopAuctionsByBids(result.AuctionResults, n)
+	return e.writeResultsCSV(context.Background(), "top_auctions", result, nil)
+}
+
+// ExportBottomAuctions exports the n auctions of result with the fewest
+// bids, fewest first, in the columns of ExportToCSV
+func (e *Exporter) ExportBottomAuctions(result models.SimulationResult, n int) (string, error) {
+	result.AuctionResults = stats.BottomAuctionsByBids(result.AuctionResults, n)
+	return e.writeResultsCSV(context.Background(), "bottom_auctions", result, nil)
+}
@@ -0,0 +1,52 @@
+package export
+
+import (
+	"encoding/csv"
+	"os"
+	"strconv"
+	"testing"
+
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+func TestExportTopAuctions(t *testing.T) {
+	result := models.SimulationResult{}
+	for id, bids := range []int{3, 9, 0, 7, 9, 1, 5} {
+		result.AuctionResults = append(result.AuctionResults,
+			models.AuctionResult{AuctionID: id + 1, TotalBids: bids, Status: models.StatusNoBids})
+	}
+
+	filename, err := NewExporter(t.TempDir()).ExportTopAuctions(result, 4)
+	if err != nil {
+		t.Fatalf("Top auctions export failed: %v", err)
+	}
+
+	f, err := os.Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Most bids first; the two 9-bid auctions keep their original order
+	expected := []struct{ id, bids int }{{2, 9}, {5, 9}, {4, 7}, {7, 5}}
+	if len(records) != len(expected)+1 {
+		t.Fatalf("Expected a header and %d rows, got %d records", len(expected), len(records))
+	}
+	for i, want := range expected {
+		row := records[i+1]
+		id, _ := strconv.Atoi(row[0])
+		bids, _ := strconv.Atoi(row[5])
+		if id != want.id || bids != want.bids {
+			t.Errorf("Row %d: expected auction #%d with %d bids, got #%d with %d", i+1, want.id, want.bids, id, bids)
+		}
+	}
+
+	// The input keeps its order
+	if result.AuctionResults[0].AuctionID != 1 {
+		t.Error("Expected the export not to reorder the result")
+	}
+}
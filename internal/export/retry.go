@@ -0,0 +1,66 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+// writeContext writes data to the named file, retrying failed attempts with
+// exponential backoff up to MaxAttempts
+// On persistent failure it returns the last error wrapped with the number of
+// attempts made. It gives up with ctx.Err() once ctx ends: before the first
+// attempt or while backing off, in which case any partial file left by a
+// failed attempt is removed.
+func (e *Exporter) writeContext(ctx context.Context, name string, data []byte) error {
+	writeFile := e.writeFile
+	if writeFile == nil {
+		writeFile = os.WriteFile
+	}
+	attempts := max(e.MaxAttempts, 1)
+	backoff := e.RetryBackoff
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	var err error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if err = writeFile(name, data, 0o644); err == nil {
+			return nil
+		}
+		if attempt < attempts {
+			if err := sleepContext(ctx, backoff); err != nil {
+				removePartial(name)
+				return err
+			}
+			backoff *= 2
+		}
+	}
+
+	if attempts == 1 {
+		return err
+	}
+	return fmt.Errorf("giving up after %d attempts: %w", attempts, err)
+}
+
+// sleepContext waits for d, returning ctx.Err() if ctx ends first
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// removePartial deletes any file a failed write attempt left half written
+// Errors are ignored: usually there is no such file, and otherwise the
+// caller reports the cancellation anyway
+func removePartial(name string) {
+	os.Remove(name)
+}
@@ -0,0 +1,60 @@
+package export
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+// flakyWriter fails the first failures writes, then writes normally
+type flakyWriter struct {
+	failures int
+	calls    int
+}
+
+func (f *flakyWriter) writeFile(name string, data []byte, perm os.FileMode) error {
+	f.calls++
+	if f.calls <= f.failures {
+		return errors.New("transient write error")
+	}
+	return os.WriteFile(name, data, perm)
+}
+
+func TestWriteRetriesTransientFailures(t *testing.T) {
+	flaky := &flakyWriter{failures: 2}
+	exporter := NewExporter(t.TempDir())
+	exporter.MaxAttempts = 3
+	exporter.RetryBackoff = 0
+	exporter.writeFile = flaky.writeFile
+
+	filename, err := exporter.ExportToCSV(sampleResult())
+	if err != nil {
+		t.Fatalf("Expected export to succeed within 3 attempts, got %v", err)
+	}
+	if flaky.calls != 3 {
+		t.Errorf("Expected 3 write attempts, got %d", flaky.calls)
+	}
+	if _, err := os.Stat(filename); err != nil {
+		t.Errorf("Expected exported file to exist: %v", err)
+	}
+}
+
+func TestWriteGivesUpAfterMaxAttempts(t *testing.T) {
+	flaky := &flakyWriter{failures: 5}
+	exporter := NewExporter(t.TempDir())
+	exporter.MaxAttempts = 3
+	exporter.RetryBackoff = 0
+	exporter.writeFile = flaky.writeFile
+
+	_, err := exporter.ExportToJSON(sampleResult())
+	if err == nil {
+		t.Fatal("Expected export to fail")
+	}
+	if flaky.calls != 3 {
+		t.Errorf("Expected 3 write attempts, got %d", flaky.calls)
+	}
+	if !strings.Contains(err.Error(), "3 attempts") || !strings.Contains(err.Error(), "transient write error") {
+		t.Errorf("Expected error to report attempts and the last error, got %q", err)
+	}
+}
@@ -0,0 +1,99 @@
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// outputName matches exported file names, capturing their timestamp,
+// e.g. "simulation_20240102_150405.csv"
+var outputName = regexp.MustCompile(`^[a-z_]+_(\d{8}_\d{6})\.[a-z]+$`)
+
+// Rotate deletes all but the keep most recent output sets from the output
+// directory, to bound disk use over long sweeps
+// An output set is every exported file sharing one timestamp. Files not
+// named like exports are left alone, and so are older files a kept set's
+// manifest still lists, such as exports reused by Dedup. Call it after a
+// run's exports have succeeded; keep must be at least 1.
+func (e *Exporter) Rotate(keep int) error {
+	if keep < 1 {
+		return fmt.Errorf("rotation must keep at least 1 output set, got %d", keep)
+	}
+
+	entries, err := os.ReadDir(e.outputDir)
+	if err != nil {
+		return fmt.Errorf("failed to list output directory: %w", err)
+	}
+
+	sets := make(map[string][]string) // timestamp -> file names
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		if match := outputName.FindStringSubmatch(entry.Name()); match != nil {
+			sets[match[1]] = append(sets[match[1]], entry.Name())
+		}
+	}
+	if len(sets) <= keep {
+		return nil
+	}
+
+	// Timestamps sort chronologically as strings; newest first
+	timestamps := make([]string, 0, len(sets))
+	for timestamp := range sets {
+		timestamps = append(timestamps, timestamp)
+	}
+	sort.Sort(sort.Reverse(sort.StringSlice(timestamps)))
+
+	referenced := make(map[string]bool)
+	for _, timestamp := range timestamps[:keep] {
+		for _, name := range sets[timestamp] {
+			for _, path := range e.manifestFiles(name) {
+				referenced[filepath.Base(path)] = true
+			}
+		}
+	}
+
+	for _, timestamp := range timestamps[keep:] {
+		for _, name := range sets[timestamp] {
+			if referenced[name] {
+				continue
+			}
+			if err := os.Remove(filepath.Join(e.outputDir, name)); err != nil {
+				return fmt.Errorf("failed to remove old output: %w", err)
+			}
+		}
+	}
+	return nil
+}
+
+// manifestFiles returns the paths listed by the output file name if it is
+// a manifest, including the configuration (nil for other files, or a
+// manifest that can't be read)
+func (e *Exporter) manifestFiles(name string) []string {
+	if !strings.HasPrefix(name, "manifest_") || filepath.Ext(name) != ".json" {
+		return nil
+	}
+	data, err := os.ReadFile(filepath.Join(e.outputDir, name))
+	if err != nil {
+		return nil
+	}
+	var manifest Manifest
+	if json.Unmarshal(data, &manifest) != nil {
+		return nil
+	}
+
+	paths := make([]string, 0, len(manifest.Files)+1)
+	if manifest.Config != "" {
+		paths = append(paths, manifest.Config)
+	}
+	for _, path := range manifest.Files {
+		paths = append(paths, path)
+	}
+	return paths
+}
@@ -0,0 +1,92 @@
+package export
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestRotateKeepsNewestSets(t *testing.T) {
+	dir := t.TempDir()
+	files := []string{
+		"simulation_20240101_120000.json",
+		"simulation_20240101_120000.csv",
+		"simulation_20240102_090000.json",
+		"summary_20240102_090000.txt",
+		"simulation_20240103_180000.json",
+		"resources_20240103_180000.csv",
+		"simulation_20240104_070000.json",
+		"notes.txt", // Not an export: never rotated
+	}
+	for _, name := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	exporter := NewExporter(dir)
+	if err := exporter.Rotate(2); err != nil {
+		t.Fatalf("rotation failed: %v", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var remaining []string
+	for _, entry := range entries {
+		remaining = append(remaining, entry.Name())
+	}
+	sort.Strings(remaining)
+
+	expected := []string{
+		"notes.txt",
+		"resources_20240103_180000.csv",
+		"simulation_20240103_180000.json",
+		"simulation_20240104_070000.json",
+	}
+	if !reflect.DeepEqual(remaining, expected) {
+		t.Errorf("Expected %v to survive rotation, got %v", expected, remaining)
+	}
+
+	if err := exporter.Rotate(0); err == nil {
+		t.Error("Expected an error when keeping no output sets")
+	}
+}
+
+func TestRotateKeepsFilesListedByKeptManifests(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{
+		"simulation_20240101_120000.json", // Reused by the newest set through Dedup
+		"summary_20240101_120000.txt",
+		"summary_20240102_090000.txt",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	manifest := Manifest{Files: map[string]string{
+		"json":    filepath.Join(dir, "simulation_20240101_120000.json"),
+		"summary": filepath.Join(dir, "summary_20240102_090000.txt"),
+	}}
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "manifest_20240102_090000.json"), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := NewExporter(dir).Rotate(1); err != nil {
+		t.Fatalf("rotation failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "simulation_20240101_120000.json")); err != nil {
+		t.Errorf("Expected the file the kept manifest lists to survive: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "summary_20240101_120000.txt")); !os.IsNotExist(err) {
+		t.Errorf("Expected the unreferenced old file to be removed, got %v", err)
+	}
+}
@@ -0,0 +1,60 @@
+package export
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+// Stdout is the output path that sends results to standard output, through
+// WriteResult, instead of to files
+const Stdout = "-"
+
+// Format selects what WriteResult writes
+type Format string
+
+const (
+	// FormatJSON is the simulation result as written by ExportToJSON
+	FormatJSON Format = "json"
+	// FormatCSV is the auction table as written by ExportToCSV
+	FormatCSV Format = "csv"
+)
+
+// ParseFormat returns the Format named s
+func ParseFormat(s string) (Format, error) {
+	switch format := Format(s); format {
+	case FormatJSON, FormatCSV:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown export format %q (want %q or %q)", s, FormatJSON, FormatCSV)
+	}
+}
+
+// WriteResult writes result in format to w, with the content the matching
+// file export would have, e.g. to pipe it into other tools
+func (e *Exporter) WriteResult(w io.Writer, format Format, result models.SimulationResult) error {
+	var data []byte
+	var err error
+	switch format {
+	case FormatJSON:
+		data, err = e.marshalJSON(result)
+	case FormatCSV:
+		data, err = e.resultsCSV(context.Background(), result, nil)
+	default:
+		return fmt.Errorf("unknown export format %q", format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if format == FormatJSON {
+		// End with a newline, like other line-oriented output
+		data = append(data, '\n')
+	}
+	if _, err := w.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s output: %w", format, err)
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+func TestWriteResult(t *testing.T) {
+	dir := t.TempDir()
+	exporter := NewExporter(dir)
+	result := sampleResult()
+
+	var out bytes.Buffer
+	if err := exporter.WriteResult(&out, FormatJSON, result); err != nil {
+		t.Fatal(err)
+	}
+	var decoded models.SimulationResult
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("Expected JSON output, got %v", err)
+	}
+	if len(decoded.AuctionResults) != len(result.AuctionResults) {
+		t.Errorf("Expected %d auctions in JSON output, got %d", len(result.AuctionResults), len(decoded.AuctionResults))
+	}
+
+	out.Reset()
+	if err := exporter.WriteResult(&out, FormatCSV, result); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatalf("Expected CSV output, got %v", err)
+	}
+	if len(rows) != len(result.AuctionResults)+1 || rows[0][0] != "AuctionID" {
+		t.Errorf("Expected a header and %d rows, got %v", len(result.AuctionResults), rows)
+	}
+
+	// Nothing goes to the output directory
+	if entries, _ := os.ReadDir(dir); len(entries) != 0 {
+		t.Errorf("Expected no files written, got %d", len(entries))
+	}
+
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
@@ -0,0 +1,47 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+// runIDKey is the context key of the run ID
+type runIDKey struct{}
+
+// RunIDAttr is the log attribute key carrying the run ID
+const RunIDAttr = "run_id"
+
+// WithRunID returns a copy of ctx carrying the given run ID
+// Loggers created by New add it to every record logged with that context
+func WithRunID(ctx context.Context, runID string) context.Context {
+	return context.WithValue(ctx, runIDKey{}, runID)
+}
+
+// RunID returns the run ID carried by ctx, if any
+func RunID(ctx context.Context) (string, bool) {
+	runID, ok := ctx.Value(runIDKey{}).(string)
+	return runID, ok
+}
+
+// contextHandler adds the run ID from the record's context to each record
+type contextHandler struct {
+	slog.Handler
+}
+
+// Handle adds the run ID, if any, before passing the record on
+func (h contextHandler) Handle(ctx context.Context, record slog.Record) error {
+	if runID, ok := RunID(ctx); ok {
+		record.AddAttrs(slog.String(RunIDAttr, runID))
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+// WithAttrs keeps the run ID handling on derived handlers
+func (h contextHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return contextHandler{h.Handler.WithAttrs(attrs)}
+}
+
+// WithGroup keeps the run ID handling on derived handlers
+func (h contextHandler) WithGroup(name string) slog.Handler {
+	return contextHandler{h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,27 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestRunIDAddedToRecords(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, "info").With("component", "test")
+
+	logger.InfoContext(WithRunID(context.Background(), "abc123"), "with run")
+	logger.InfoContext(context.Background(), "without run")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected 2 log lines, got %d: %q", len(lines), buf.String())
+	}
+	if !strings.Contains(lines[0], "run_id=abc123") {
+		t.Errorf("Expected run ID on first line, got %q", lines[0])
+	}
+	if strings.Contains(lines[1], "run_id=") {
+		t.Errorf("Expected no run ID on second line, got %q", lines[1])
+	}
+}
@@ -0,0 +1,36 @@
+// Package logging provides the structured logger used by the auction simulator.
+package logging
+
+import (
+	"io"
+	"log/slog"
+	"strings"
+)
+
+// New creates a text logger writing to w at the given level
+// ("debug", "info", "warn", "error"); unknown levels default to info
+// Records logged with a context carrying a run ID include it
+func New(w io.Writer, level string) *slog.Logger {
+	return slog.New(contextHandler{slog.NewTextHandler(w, &slog.HandlerOptions{
+		Level: ParseLevel(level),
+	})})
+}
+
+// ParseLevel converts a configured level name to a slog level
+func ParseLevel(level string) slog.Level {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// Discard returns a logger that drops everything
+func Discard() *slog.Logger {
+	return slog.New(slog.DiscardHandler)
+}
@@ -0,0 +1,43 @@
+package models
+
+import "sort"
+
+// BidderStatsOf tallies the bids and wins of every bidder in result, in
+// bidder ID order
+// Bidders with a recorded seed (see SimulationResult.BidderSeeds) are
+// listed even if they never bid.
+func BidderStatsOf(result SimulationResult) []BidderStats {
+	byID := make(map[int]*BidderStats, len(result.BidderSeeds))
+	get := func(id int) *BidderStats {
+		stats := byID[id]
+		if stats == nil {
+			stats = &BidderStats{BidderID: id, Seed: result.BidderSeeds[id]}
+			byID[id] = stats
+		}
+		return stats
+	}
+
+	for id := range result.BidderSeeds {
+		get(id)
+	}
+	for _, auctionResult := range result.AuctionResults {
+		for _, bid := range auctionResult.Bids {
+			get(bid.BidderID).TotalBids++
+		}
+		if winner := auctionResult.WinningBid; winner != nil {
+			stats := get(winner.BidderID)
+			stats.AuctionsWon++
+			stats.TotalSpent += winner.Amount
+		}
+	}
+
+	all := make([]BidderStats, 0, len(byID))
+	for _, stats := range byID {
+		if stats.AuctionsWon > 0 {
+			stats.AverageWinBid = stats.TotalSpent / float64(stats.AuctionsWon)
+		}
+		all = append(all, *stats)
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].BidderID < all[j].BidderID })
+	return all
+}
@@ -0,0 +1,36 @@
+package models
+
+import "time"
+
+// BidLog records the bids of a run with the auctions they went to, enough
+// to replay winner determination without re-simulating
+type BidLog struct {
+	Auctions []BidLogAuction // Every auction of the run, bids or not
+	Bids     []Bid           // All bids, in auction order then arrival order
+}
+
+// BidLogAuction is an auction of a BidLog
+type BidLogAuction struct {
+	AuctionID int
+	Item      AuctionItem
+	StartTime time.Time // Together with the timeout, decides which bids were late
+	EndTime   time.Time
+}
+
+// NewBidLog builds the bid log of a simulation result
+func NewBidLog(result SimulationResult) BidLog {
+	log := BidLog{
+		Auctions: make([]BidLogAuction, 0, len(result.AuctionResults)),
+		Bids:     make([]Bid, 0, result.TotalBids),
+	}
+	for _, auctionResult := range result.AuctionResults {
+		log.Auctions = append(log.Auctions, BidLogAuction{
+			AuctionID: auctionResult.AuctionID,
+			Item:      auctionResult.Item,
+			StartTime: auctionResult.StartTime,
+			EndTime:   auctionResult.EndTime,
+		})
+		log.Bids = append(log.Bids, auctionResult.Bids...)
+	}
+	return log
+}
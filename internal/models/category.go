@@ -0,0 +1,36 @@
+package models
+
+// Category is an item category
+type Category string
+
+// Known item categories
+const (
+	CategoryElectronics  Category = "Electronics"
+	CategoryArt          Category = "Art"
+	CategoryCollectibles Category = "Collectibles"
+	CategoryJewelry      Category = "Jewelry"
+	CategoryFurniture    Category = "Furniture"
+	CategoryBooks        Category = "Books"
+	CategoryClothing     Category = "Clothing"
+)
+
+// Categories lists every known category
+var Categories = []Category{
+	CategoryElectronics,
+	CategoryArt,
+	CategoryCollectibles,
+	CategoryJewelry,
+	CategoryFurniture,
+	CategoryBooks,
+	CategoryClothing,
+}
+
+// Valid reports whether c is one of the known categories
+func (c Category) Valid() bool {
+	for _, known := range Categories {
+		if c == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,51 @@
+package models
+
+import "time"
+
+// CompactResult is a slim AuctionResult for very large runs: IDs, amounts,
+// status and counts only. The item and the bids are dropped; look the item
+// up by ItemID (e.g. from the auction) when it is needed.
+type CompactResult struct {
+	AuctionID      int
+	ItemID         int
+	Status         AuctionStatus
+	Won            bool    // Whether the auction produced a winner
+	WinnerBidderID int     // 0 if the auction had no winner
+	WinningAmount  float64 // 0 if the auction had no winner
+	Revenue        float64 // What the auction took in (see Revenue)
+	TotalBids      int
+	LateBids       int
+	Duration       time.Duration
+}
+
+// NewCompactResult builds the compact form of result
+func NewCompactResult(result AuctionResult) CompactResult {
+	compact := CompactResult{
+		AuctionID: result.AuctionID,
+		ItemID:    result.Item.ID,
+		Status:    result.Status,
+		TotalBids: result.TotalBids,
+		LateBids:  result.LateBids,
+		Duration:  result.Duration,
+	}
+	if result.WinningBid != nil {
+		compact.Won = true
+		compact.WinnerBidderID = result.WinningBid.BidderID
+		compact.WinningAmount = result.WinningBid.Amount
+		compact.Revenue = Revenue(result)
+	}
+	return compact
+}
+
+// CompactSimulationResult is a SimulationResult holding CompactResults
+// Resource metrics are not included.
+type CompactSimulationResult struct {
+	TotalAuctions      int             // Number of auctions run
+	TotalDuration      time.Duration   // Total time from start to finish
+	StartTime          time.Time       // First auction start time
+	EndTime            time.Time       // Last auction end time
+	AuctionResults     []CompactResult // Compact results of all auctions
+	SuccessfulAuctions int             // Auctions counted as successful
+	FailedAuctions     int             // Auctions not counted as successful
+	TotalBids          int             // Total bids across all auctions
+}
@@ -0,0 +1,41 @@
+package models
+
+import "time"
+
+// DecisionReason explains how an auction treated an incoming bid
+// It marshals to JSON as its string value, e.g. "too_low".
+type DecisionReason string
+
+const (
+	// ReasonAccepted means the bid was accepted and can win
+	ReasonAccepted DecisionReason = "accepted"
+	// ReasonBuyNow means the bid was accepted and reached the buy-now price
+	ReasonBuyNow DecisionReason = "buy_now"
+	// ReasonInvalid means the amount was not positive and finite
+	ReasonInvalid DecisionReason = "invalid"
+	// ReasonTooLow means the amount was below the reserve, so it cannot win
+	ReasonTooLow DecisionReason = "too_low"
+	// ReasonLate means the bid was placed or received after the deadline
+	ReasonLate DecisionReason = "late"
+	// ReasonClosed means the auction had already closed and dropped the bid
+	ReasonClosed DecisionReason = "closed"
+	// ReasonDuplicate means the bid repeated an earlier bid exactly and
+	// was dropped (see AuctionConfig.DedupBids)
+	ReasonDuplicate DecisionReason = "duplicate"
+	// ReasonRepeatInRound means the bidder had already bid in the current
+	// round of a round-based auction
+	ReasonRepeatInRound DecisionReason = "repeat_in_round"
+	// ReasonNotStarted means the auction did not start in time to take a
+	// remote bid placed before it started
+	ReasonNotStarted DecisionReason = "not_started"
+)
+
+// BidDecision records how an auction treated one incoming bid
+type BidDecision struct {
+	AuctionID  int            // Which auction
+	BidderID   int            // Who placed the bid
+	Amount     float64        // Bid amount
+	ReceivedAt time.Time      // When the auction received the bid
+	Accepted   bool           // Whether the bid could win
+	Reason     DecisionReason // Why it was accepted or rejected
+}
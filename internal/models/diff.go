@@ -0,0 +1,79 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+)
+
+// DiffResults lists the outcome differences between two simulation results,
+// in human-readable form, for regression tests on seeded runs
+// Only outcomes are compared: totals, and each auction's status, bid count,
+// winner and winning amount, matched by auction ID. Timings and resource
+// metrics vary between runs and are ignored. Returns nil if a and b agree.
+func DiffResults(a, b SimulationResult) []string {
+	var diffs []string
+	diff := func(format string, args ...any) {
+		diffs = append(diffs, fmt.Sprintf(format, args...))
+	}
+
+	if a.TotalAuctions != b.TotalAuctions {
+		diff("total auctions: %d != %d", a.TotalAuctions, b.TotalAuctions)
+	}
+	if a.SuccessfulAuctions != b.SuccessfulAuctions {
+		diff("successful auctions: %d != %d", a.SuccessfulAuctions, b.SuccessfulAuctions)
+	}
+	if a.FailedAuctions != b.FailedAuctions {
+		diff("failed auctions: %d != %d", a.FailedAuctions, b.FailedAuctions)
+	}
+	if a.TotalBids != b.TotalBids {
+		diff("total bids: %d != %d", a.TotalBids, b.TotalBids)
+	}
+
+	byID := make(map[int]AuctionResult, len(b.AuctionResults))
+	for _, result := range b.AuctionResults {
+		byID[result.AuctionID] = result
+	}
+
+	for _, ra := range a.AuctionResults {
+		rb, ok := byID[ra.AuctionID]
+		if !ok {
+			diff("auction %d: only in first result", ra.AuctionID)
+			continue
+		}
+		delete(byID, ra.AuctionID)
+
+		if ra.Status != rb.Status {
+			diff("auction %d: status %q != %q", ra.AuctionID, ra.Status, rb.Status)
+		}
+		if ra.TotalBids != rb.TotalBids {
+			diff("auction %d: bids %d != %d", ra.AuctionID, ra.TotalBids, rb.TotalBids)
+		}
+
+		switch wa, wb := ra.WinningBid, rb.WinningBid; {
+		case wa == nil && wb == nil:
+		case wa == nil:
+			diff("auction %d: no winner != bidder %d", ra.AuctionID, wb.BidderID)
+		case wb == nil:
+			diff("auction %d: bidder %d != no winner", ra.AuctionID, wa.BidderID)
+		default:
+			if wa.BidderID != wb.BidderID {
+				diff("auction %d: winner bidder %d != %d", ra.AuctionID, wa.BidderID, wb.BidderID)
+			}
+			if wa.Amount != wb.Amount {
+				diff("auction %d: winning amount %v != %v", ra.AuctionID, wa.Amount, wb.Amount)
+			}
+		}
+	}
+
+	// Auctions left over only appear in b, reported in ID order
+	missing := make([]int, 0, len(byID))
+	for id := range byID {
+		missing = append(missing, id)
+	}
+	sort.Ints(missing)
+	for _, id := range missing {
+		diff("auction %d: only in second result", id)
+	}
+
+	return diffs
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestDiffResults(t *testing.T) {
+	result := SimulationResult{
+		TotalAuctions:      3,
+		SuccessfulAuctions: 2,
+		FailedAuctions:     1,
+		TotalBids:          5,
+		AuctionResults: []AuctionResult{
+			{AuctionID: 1, Status: "completed", TotalBids: 3, WinningBid: &Bid{BidderID: 7, Amount: 120}},
+			{AuctionID: 2, Status: "completed", TotalBids: 2, WinningBid: &Bid{BidderID: 4, Amount: 80}},
+			{AuctionID: 3, Status: "no_bids"},
+		},
+	}
+
+	if diffs := DiffResults(result, result); len(diffs) != 0 {
+		t.Errorf("Expected no differences comparing a result to itself, got %q", diffs)
+	}
+
+	mutated := result
+	mutated.TotalBids = 6
+	mutated.AuctionResults = slices.Clone(result.AuctionResults)
+	mutated.AuctionResults[0].WinningBid = &Bid{BidderID: 9, Amount: 125}
+	mutated.AuctionResults[1].WinningBid = nil
+	mutated.AuctionResults[1].Status = "reserve_not_met"
+	mutated.AuctionResults[2] = AuctionResult{AuctionID: 4, Status: "no_bids"}
+
+	want := []string{
+		"total bids: 5 != 6",
+		"auction 1: winner bidder 7 != 9",
+		"auction 1: winning amount 120 != 125",
+		`auction 2: status "completed" != "reserve_not_met"`,
+		"auction 2: bidder 4 != no winner",
+		"auction 3: only in first result",
+		"auction 4: only in second result",
+	}
+	if diffs := DiffResults(result, mutated); !slices.Equal(diffs, want) {
+		t.Errorf("Unexpected differences:\ngot  %q\nwant %q", diffs, want)
+	}
+}
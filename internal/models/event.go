@@ -0,0 +1,20 @@
+package models
+
+// EventKind names what happened in an AuctionEvent
+type EventKind string
+
+const (
+	// EventBidReceived means an open auction stored a bid
+	EventBidReceived EventKind = "bid_received"
+	// EventAuctionClosed means an auction closed and decided its result
+	EventAuctionClosed EventKind = "auction_closed"
+)
+
+// AuctionEvent reports progress of a running auction, e.g. to a live
+// dashboard
+type AuctionEvent struct {
+	Kind      EventKind
+	AuctionID int
+	Bid       Bid            // The bid received (EventBidReceived only)
+	Result    *AuctionResult // The auction's result (EventAuctionClosed only)
+}
@@ -0,0 +1,122 @@
+package models
+
+// MergeResults combines the results of a simulation sharded across
+// processes into one
+// Auction results are concatenated in shard order and totals summed. The
+// run spans the earliest start to the latest end. Per-process metrics
+// (peaks, limits, CPUs, concurrent auctions) take the largest shard's value;
+// GC counts and pauses are summed, and the average memory is averaged over
+// shards. Seed is kept only if every shard used the same one. Bidder seeds
+// are unioned; a bidder ID recorded by several shards keeps the first
+// shard's seed.
+// Auction IDs are kept as they are; use MergeResultsRekeyed if shards
+// number their auctions independently.
+func MergeResults(results ...SimulationResult) SimulationResult {
+	var merged SimulationResult
+	if len(results) == 0 {
+		return merged
+	}
+
+	merged.Seed = results[0].Seed
+	var totalAuctionResults int
+	var averageMemory float64
+	for _, r := range results {
+		totalAuctionResults += len(r.AuctionResults)
+	}
+	if totalAuctionResults > 0 {
+		merged.AuctionResults = make([]AuctionResult, 0, totalAuctionResults)
+	}
+
+	for _, r := range results {
+		merged.AuctionResults = append(merged.AuctionResults, r.AuctionResults...)
+		merged.TotalAuctions += r.TotalAuctions
+		merged.SuccessfulAuctions += r.SuccessfulAuctions
+		merged.FailedAuctions += r.FailedAuctions
+		merged.TotalBids += r.TotalBids
+		merged.BidderDropouts += r.BidderDropouts
+		merged.DuplicateBids += r.DuplicateBids
+		if r.Seed != merged.Seed {
+			merged.Seed = 0
+		}
+		for id, seed := range r.BidderSeeds {
+			if merged.BidderSeeds == nil {
+				merged.BidderSeeds = make(map[int]int64, len(r.BidderSeeds))
+			}
+			if _, ok := merged.BidderSeeds[id]; !ok {
+				merged.BidderSeeds[id] = seed
+			}
+		}
+
+		if !r.StartTime.IsZero() && (merged.StartTime.IsZero() || r.StartTime.Before(merged.StartTime)) {
+			merged.StartTime = r.StartTime
+			merged.InitialMemoryMB = r.InitialMemoryMB
+		}
+		if r.EndTime.After(merged.EndTime) {
+			merged.EndTime = r.EndTime
+			merged.FinalMemoryMB = r.FinalMemoryMB
+			merged.LastGCPause = r.LastGCPause
+		}
+
+		merged.PeakConcurrentAuctions = max(merged.PeakConcurrentAuctions, r.PeakConcurrentAuctions)
+		merged.CPUCount = max(merged.CPUCount, r.CPUCount)
+		merged.MemoryLimitMB = max(merged.MemoryLimitMB, r.MemoryLimitMB)
+		merged.CPUUsed = max(merged.CPUUsed, r.CPUUsed)
+		merged.PeakMemoryMB = max(merged.PeakMemoryMB, r.PeakMemoryMB)
+		merged.PeakGoroutines = max(merged.PeakGoroutines, r.PeakGoroutines)
+		merged.NumGC += r.NumGC
+		merged.TotalGCPause += r.TotalGCPause
+		averageMemory += r.AverageMemoryMB
+	}
+
+	merged.AverageMemoryMB = averageMemory / float64(len(results))
+	if !merged.StartTime.IsZero() {
+		merged.TotalDuration = merged.EndTime.Sub(merged.StartTime)
+	} else {
+		// No timestamps to go by: shards are assumed to have overlapped
+		for _, r := range results {
+			merged.TotalDuration = max(merged.TotalDuration, r.TotalDuration)
+		}
+	}
+	return merged
+}
+
+// MergeResultsRekeyed is MergeResults for shards whose auction IDs may
+// overlap: each shard's IDs are offset past the largest ID of the shards
+// before it, so shards numbered from 1 continue one another's numbering
+// Bids are re-keyed along with their auctions; the inputs are not modified.
+func MergeResultsRekeyed(results ...SimulationResult) SimulationResult {
+	rekeyed := make([]SimulationResult, len(results))
+	offset := 0
+	for i, r := range results {
+		rekeyed[i] = r
+		rekeyed[i].AuctionResults = make([]AuctionResult, len(r.AuctionResults))
+		largest := 0
+		for j, result := range r.AuctionResults {
+			largest = max(largest, result.AuctionID)
+			rekeyed[i].AuctionResults[j] = rekeyAuction(result, result.AuctionID+offset)
+		}
+		offset += largest
+	}
+	return MergeResults(rekeyed...)
+}
+
+// rekeyAuction returns a copy of result, and of its bids, under auction id
+func rekeyAuction(result AuctionResult, id int) AuctionResult {
+	result.AuctionID = id
+	if result.Bids != nil {
+		bids := make([]Bid, len(result.Bids))
+		for i, bid := range result.Bids {
+			bid.AuctionID = id
+			bids[i] = bid
+		}
+		result.Bids = bids
+	}
+	for _, bid := range []**Bid{&result.WinningBid, &result.RunnerUp} {
+		if *bid != nil {
+			copied := **bid
+			copied.AuctionID = id
+			*bid = &copied
+		}
+	}
+	return result
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"maps"
+	"testing"
+	"time"
+)
+
+func TestMergeResults(t *testing.T) {
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	first := SimulationResult{
+		TotalAuctions:      2,
+		SuccessfulAuctions: 1,
+		FailedAuctions:     1,
+		TotalBids:          3,
+		StartTime:          start,
+		EndTime:            start.Add(4 * time.Second),
+		TotalDuration:      4 * time.Second,
+		Seed:               42,
+		BidderSeeds:        map[int]int64{7: 107, 8: 108},
+		PeakMemoryMB:       120,
+		PeakGoroutines:     300,
+		NumGC:              2,
+		AuctionResults: []AuctionResult{
+			{AuctionID: 1, TotalBids: 3, WinningBid: &Bid{AuctionID: 1, BidderID: 7, Amount: 120},
+				Bids: []Bid{{AuctionID: 1, BidderID: 7, Amount: 120}}},
+			{AuctionID: 2},
+		},
+	}
+	second := SimulationResult{
+		TotalAuctions:      1,
+		SuccessfulAuctions: 1,
+		TotalBids:          2,
+		StartTime:          start.Add(-time.Second),
+		EndTime:            start.Add(3 * time.Second),
+		TotalDuration:      4 * time.Second,
+		Seed:               42,
+		BidderSeeds:        map[int]int64{4: 104, 8: 208},
+		PeakMemoryMB:       90,
+		PeakGoroutines:     450,
+		NumGC:              3,
+		AuctionResults: []AuctionResult{
+			{AuctionID: 1, TotalBids: 2, WinningBid: &Bid{AuctionID: 1, BidderID: 4, Amount: 80},
+				Bids: []Bid{{AuctionID: 1, BidderID: 4, Amount: 80}}},
+		},
+	}
+
+	merged := MergeResults(first, second)
+	if merged.TotalAuctions != 3 || merged.SuccessfulAuctions != 2 || merged.FailedAuctions != 1 || merged.TotalBids != 5 {
+		t.Errorf("Expected summed totals 3/2/1/5, got %d/%d/%d/%d",
+			merged.TotalAuctions, merged.SuccessfulAuctions, merged.FailedAuctions, merged.TotalBids)
+	}
+	if len(merged.AuctionResults) != 3 {
+		t.Fatalf("Expected 3 auction results, got %d", len(merged.AuctionResults))
+	}
+	if !merged.StartTime.Equal(second.StartTime) || !merged.EndTime.Equal(first.EndTime) {
+		t.Errorf("Expected the run to span %v to %v, got %v to %v",
+			second.StartTime, first.EndTime, merged.StartTime, merged.EndTime)
+	}
+	if merged.TotalDuration != 5*time.Second {
+		t.Errorf("Expected a total duration of 5s, got %v", merged.TotalDuration)
+	}
+	if merged.PeakMemoryMB != 120 || merged.PeakGoroutines != 450 || merged.NumGC != 5 {
+		t.Errorf("Expected peaks 120MB/450 and 5 GCs, got %.0fMB/%d and %d",
+			merged.PeakMemoryMB, merged.PeakGoroutines, merged.NumGC)
+	}
+	if merged.Seed != 42 {
+		t.Errorf("Expected the shared seed kept, got %d", merged.Seed)
+	}
+	if want := map[int]int64{4: 104, 7: 107, 8: 108}; !maps.Equal(merged.BidderSeeds, want) {
+		t.Errorf("Expected bidder seeds %v, got %v", want, merged.BidderSeeds)
+	}
+
+	rekeyed := MergeResultsRekeyed(first, second)
+	ids := []int{1, 2, 3}
+	for i, result := range rekeyed.AuctionResults {
+		if result.AuctionID != ids[i] {
+			t.Errorf("Auction %d: expected ID %d, got %d", i, ids[i], result.AuctionID)
+		}
+	}
+	if last := rekeyed.AuctionResults[2]; last.WinningBid.AuctionID != 3 || last.Bids[0].AuctionID != 3 {
+		t.Errorf("Expected the bids re-keyed to auction 3, got %+v", last)
+	}
+	if second.AuctionResults[0].AuctionID != 1 || second.AuctionResults[0].WinningBid.AuctionID != 1 {
+		t.Error("Expected the inputs left unchanged")
+	}
+
+	second.Seed = 7
+	if seed := MergeResults(first, second).Seed; seed != 0 {
+		t.Errorf("Expected no seed for shards with different seeds, got %d", seed)
+	}
+}
@@ -0,0 +1,35 @@
+package models
+
+// AuctionStatus is the outcome of an auction
+// It marshals to JSON as its string value, e.g. "completed".
+type AuctionStatus string
+
+const (
+	// StatusCompleted means the auction produced a winner
+	StatusCompleted AuctionStatus = "completed"
+	// StatusBuyNow means a bid reached the buy-now price and won
+	StatusBuyNow AuctionStatus = "buy_now"
+	// StatusNoBids means no valid bid competed
+	StatusNoBids AuctionStatus = "no_bids"
+	// StatusMissedWindow means bidders wanted to bid but every bid arrived too late
+	StatusMissedWindow AuctionStatus = "missed_window"
+	// StatusNoWinner means a custom winner rule picked none of the bids
+	StatusNoWinner AuctionStatus = "no_winner"
+	// StatusReserveNotMet means the best bid was below the reserve price
+	StatusReserveNotMet AuctionStatus = "reserve_not_met"
+	// StatusOverBudget means every bidder who could have won had already
+	// spent its budget on other auctions
+	StatusOverBudget AuctionStatus = "over_budget"
+)
+
+// IsSuccess reports whether the status is a successful outcome, i.e. one
+// with a winner
+// Results may carry other statuses with a winner; HasWinner judges those.
+func (s AuctionStatus) IsSuccess() bool {
+	return s == StatusCompleted || s == StatusBuyNow
+}
+
+// String returns the status value, e.g. "completed"
+func (s AuctionStatus) String() string {
+	return string(s)
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestAuctionStatusIsSuccess(t *testing.T) {
+	expected := map[AuctionStatus]bool{
+		StatusCompleted:     true,
+		StatusBuyNow:        true,
+		StatusNoBids:        false,
+		StatusMissedWindow:  false,
+		StatusNoWinner:      false,
+		StatusReserveNotMet: false,
+		StatusOverBudget:    false,
+	}
+	for status, want := range expected {
+		if got := status.IsSuccess(); got != want {
+			t.Errorf("%s: expected IsSuccess %v, got %v", status, want, got)
+		}
+	}
+}
+
+func TestAuctionStatusJSONRoundTrip(t *testing.T) {
+	result := AuctionResult{AuctionID: 1, Status: StatusReserveNotMet}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"Status":"reserve_not_met"`) {
+		t.Errorf("Expected the status as its string value, got %s", data)
+	}
+
+	var decoded AuctionResult
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Status != StatusReserveNotMet {
+		t.Errorf("Expected %q after round trip, got %q", StatusReserveNotMet, decoded.Status)
+	}
+}
@@ -1,38 +1,74 @@
 // Package models defines domain types used by the auction simulator.
 package models
 
-import "time"
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+)
 
 // AuctionItem represents an item being auctioned with 20 attributes
 type AuctionItem struct {
-	ID          int     // Unique identifier
-	Name        string  // Item name
-	Category    string  // Category (Electronics, Art, etc.)
-	Brand       string  // Brand name
-	Condition   string  // New, Used, Refurbished
-	Color       string  // Primary color
-	Size        string  // Size (Small, Medium, Large, XL)
-	Weight      float64 // Weight in kg
-	Material    string  // Primary material
-	YearMade    int     // Manufacturing year
-	Origin      string  // Country of origin
-	Rarity      string  // Common, Rare, Ultra-Rare
-	BasePrice   float64 // Starting/reserve price
-	Description string  // Item description
-	Features    string  // Key features
-	Warranty    int     // Warranty in months
-	ShipWeight  float64 // Shipping weight
-	Dimensions  string  // L x W x H in cm
-	Certification string // Any certifications
-	Rating      float64 // Quality rating (1-10)
+	ID            int      // Unique identifier
+	Name          string   // Item name
+	Category      Category // Category (Electronics, Art, etc.)
+	Brand         string   // Brand name
+	Condition     string   // New, Used, Refurbished
+	Color         string   // Primary color
+	Size          string   // Size (Small, Medium, Large, XL)
+	Weight        float64  // Weight in kg
+	Material      string   // Primary material
+	YearMade      int      // Manufacturing year
+	Origin        string   // Country of origin
+	Rarity        string   // Common, Rare, Ultra-Rare
+	BasePrice     float64  // Starting/reserve price
+	Description   string   // Item description
+	Features      string   // Key features
+	Warranty      int      // Warranty in months
+	ShipWeight    float64  // Shipping weight
+	Dimensions    string   // L x W x H in cm
+	Certification string   // Any certifications
+	Rating        float64  // Quality rating (1-10)
+
+	Hot bool // Attracts extra bidder interest (see BidderConfig.HotBidMultiplier)
+
+	// Fingerprint identifies the item across runs by its defining
+	// attributes, whatever its ID (see ComputeFingerprint)
+	Fingerprint string `json:",omitempty"`
+}
+
+// ComputeFingerprint returns a stable hash of the item's defining
+// attributes, as 16 hex digits
+// The ID and the Name, Description and Features derived from it are left
+// out, as is Hot, so the same item gets the same fingerprint in any run
+// or position.
+func (i AuctionItem) ComputeFingerprint() string {
+	h := sha256.New()
+	for _, attr := range []string{
+		string(i.Category), i.Brand, i.Condition, i.Color, i.Size,
+		strconv.FormatFloat(i.Weight, 'g', -1, 64), i.Material, strconv.Itoa(i.YearMade),
+		i.Origin, i.Rarity, strconv.FormatFloat(i.BasePrice, 'g', -1, 64),
+		strconv.Itoa(i.Warranty), strconv.FormatFloat(i.ShipWeight, 'g', -1, 64),
+		i.Dimensions, i.Certification, strconv.FormatFloat(i.Rating, 'g', -1, 64),
+	} {
+		// Length-prefixed, so attribute boundaries can't shift
+		fmt.Fprintf(h, "%d:%s;", len(attr), attr)
+	}
+	return hex.EncodeToString(h.Sum(nil)[:8])
 }
 
 // Bid represents a bid placed by a bidder
 type Bid struct {
-	BidderID  int       // Who placed the bid
-	AuctionID int       // Which auction
-	Amount    float64   // Bid amount
-	Timestamp time.Time // When the bid was placed
+	BidderID   int       // Who placed the bid
+	AuctionID  int       // Which auction
+	Amount     float64   // Bid amount
+	Timestamp  time.Time // When the bid was placed (bidder clock)
+	ReceivedAt time.Time // When the auction received the bid (auction clock)
+	Valuation  float64   // Bidder's private valuation of the item (0 if unknown)
+	Segment    string    // Bidder's market segment, e.g. "retail" ("" if unassigned)
+	Round      int       `json:",omitempty"` // Round the bid was taken in (0 outside round-based auctions)
 }
 
 // AuctionResult represents the outcome of an auction
@@ -40,39 +76,87 @@ type AuctionResult struct {
 	AuctionID     int           // Auction identifier
 	Item          AuctionItem   // The item that was auctioned
 	WinningBid    *Bid          // Winning bid (nil if no bids)
+	RunnerUp      *Bid          // Highest bid from another bidder than the winner (nil if none)
+	Bids          []Bid         // All bids received, in arrival order
 	TotalBids     int           // Total number of bids received
+	LateBids      int           // Bids placed or received after the deadline
+	DuplicateBids int           // Exact-duplicate bids dropped rather than counted in TotalBids
 	Duration      time.Duration // How long the auction ran
 	StartTime     time.Time     // When auction started
 	EndTime       time.Time     // When auction ended
-	Status        string        // "completed", "no_bids", "timeout"
+	Status        AuctionStatus // Outcome, e.g. StatusCompleted or StatusNoBids
+
+	// Duration split into the time spent collecting bids and the time spent
+	// determining the winner afterwards; they sum to Duration
+	CollectionDuration    time.Duration
+	DeterminationDuration time.Duration
+
+	// Shares maps bidder ID to its fraction of the item in
+	// proportional-share auctions (nil otherwise)
+	Shares map[int]float64 `json:",omitempty"`
+}
+
+// SuccessPredicate reports whether an auction result counts as successful
+type SuccessPredicate func(result AuctionResult) bool
+
+// HasWinner is the default success predicate: an auction succeeded if it
+// produced a winner, whatever its status (e.g. StatusCompleted or
+// StatusBuyNow). Statuses without a winner, such as StatusNoBids,
+// StatusMissedWindow or StatusReserveNotMet, fail.
+func HasWinner(result AuctionResult) bool {
+	return result.WinningBid != nil
+}
+
+// Revenue returns what result took in: the winning amount, or in a
+// proportional-share auction the sum of the bids every share holder pays
+// (0 without a winner)
+func Revenue(result AuctionResult) float64 {
+	if result.WinningBid == nil {
+		return 0
+	}
+	if share := result.Shares[result.WinningBid.BidderID]; share > 0 {
+		return result.WinningBid.Amount / share
+	}
+	return result.WinningBid.Amount
 }
 
 // BidderStats represents statistics for a bidder
 type BidderStats struct {
-	BidderID       int // Bidder identifier
-	TotalBids      int // How many bids placed
-	AuctionsWon    int // How many auctions won
-	TotalSpent     float64 // Total amount spent
-	AverageWinBid  float64 // Average winning bid amount
+	BidderID      int     // Bidder identifier
+	TotalBids     int     // How many bids placed
+	AuctionsWon   int     // How many auctions won
+	TotalSpent    float64 // Total amount spent
+	AverageWinBid float64 // Average winning bid amount
+	Seed          int64   // Seed the bidder's decisions derive from (0 if not recorded)
 }
 
 // SimulationResult represents the overall simulation results
 type SimulationResult struct {
-	TotalAuctions      int                  // Number of auctions run
-	TotalDuration      time.Duration        // Total time from start to finish
-	StartTime          time.Time            // First auction start time
-	EndTime            time.Time            // Last auction end time
-	AuctionResults     []AuctionResult      // Results of all auctions
-	SuccessfulAuctions int                  // Auctions with at least one bid
-	FailedAuctions     int                  // Auctions with no bids
-	TotalBids          int                  // Total bids across all auctions
-	
+	TotalAuctions      int             // Number of auctions run
+	TotalDuration      time.Duration   // Total time from start to finish
+	StartTime          time.Time       // First auction start time
+	EndTime            time.Time       // Last auction end time
+	AuctionResults     []AuctionResult // Results of all auctions
+	SuccessfulAuctions int             // Auctions with at least one bid
+	FailedAuctions     int             // Auctions with no bids
+	TotalBids          int             // Total bids across all auctions
+	BidderDropouts     int             // Bids decided on but never sent (see BidderConfig.DropoutProbability)
+	DuplicateBids      int             // Exact-duplicate bids dropped across all auctions
+	Seed               int64           // Seed the run used, time-derived unless configured; rerun with it to reproduce
+	BidderSeeds        map[int]int64   `json:",omitempty"` // Seed of each bidder by ID, if recorded (see ReportConfig.BidderSeeds)
+
+	PeakConcurrentAuctions int // Most auctions running at once; below TotalAuctions when launches are staggered
+
 	// Resource metrics
-	CPUCount           int                  // Number of CPUs available
-	CPUUsed            int                  // Number of CPUs used (GOMAXPROCS)
-	InitialMemoryMB    float64              // Memory at start
-	FinalMemoryMB      float64              // Memory at end
-	PeakMemoryMB       float64              // Peak memory usage
-	AverageMemoryMB    float64              // Average memory usage
-	PeakGoroutines     int                  // Maximum concurrent goroutines
-}
\ No newline at end of file
+	CPUCount        int           // Number of CPUs available, within any container CPU quota
+	MemoryLimitMB   float64       // Container memory limit in MB (0 = unlimited)
+	CPUUsed         int           // Number of CPUs used (GOMAXPROCS)
+	InitialMemoryMB float64       // Memory at start
+	FinalMemoryMB   float64       // Memory at end
+	PeakMemoryMB    float64       // Peak memory usage
+	AverageMemoryMB float64       // Average memory usage
+	PeakGoroutines  int           // Maximum concurrent goroutines
+	NumGC           int           // GC cycles during the run
+	TotalGCPause    time.Duration // GC pause time during the run
+	LastGCPause     time.Duration // Pause of the last GC cycle during the run
+}
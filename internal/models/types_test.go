@@ -25,6 +25,46 @@ func TestAuctionItemCreation(t *testing.T) {
 	}
 }
 
+// TestItemFingerprint tests that fingerprints follow the defining attributes
+func TestItemFingerprint(t *testing.T) {
+	item := AuctionItem{
+		ID:        1,
+		Name:      "Canon Electronics 1",
+		Category:  "Electronics",
+		Brand:     "Canon",
+		Condition: "Used",
+		BasePrice: 100.0,
+		Rating:    7.5,
+	}
+
+	// The same item under another ID, name or interest level
+	same := item
+	same.ID = 42
+	same.Name = "Canon Electronics 42"
+	same.Hot = true
+	if item.ComputeFingerprint() != same.ComputeFingerprint() {
+		t.Error("Expected identical attributes to yield identical fingerprints")
+	}
+	if n := len(item.ComputeFingerprint()); n != 16 {
+		t.Errorf("Expected a 16 digit fingerprint, got %d digits", n)
+	}
+
+	for name, change := range map[string]func(*AuctionItem){
+		"brand":      func(i *AuctionItem) { i.Brand = "Nikon" },
+		"condition":  func(i *AuctionItem) { i.Condition = "New" },
+		"base price": func(i *AuctionItem) { i.BasePrice = 100.01 },
+		"rating":     func(i *AuctionItem) { i.Rating = 7.6 },
+		// Attribute boundaries matter, not just their concatenation
+		"shifted": func(i *AuctionItem) { i.Brand, i.Condition = "CanonU", "sed" },
+	} {
+		other := item
+		change(&other)
+		if other.ComputeFingerprint() == item.ComputeFingerprint() {
+			t.Errorf("Expected a different %s to change the fingerprint", name)
+		}
+	}
+}
+
 // TestBidCreation tests creating a bid
 func TestBidCreation(t *testing.T) {
 	bid := Bid{
@@ -55,3 +95,14 @@ func TestAuctionResult(t *testing.T) {
 		t.Errorf("Expected status 'completed', got '%s'", result.Status)
 	}
 }
+
+// TestCategoryValid tests category validation
+func TestCategoryValid(t *testing.T) {
+	if !CategoryElectronics.Valid() {
+		t.Error("Expected Electronics to be a valid category")
+	}
+
+	if Category("Electronix").Valid() {
+		t.Error("Expected misspelled category to be invalid")
+	}
+}
@@ -0,0 +1,100 @@
+package monitor
+
+import (
+	"bufio"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// cgroupRoot is where the cgroup v2 hierarchy is mounted on Linux
+const cgroupRoot = "/sys/fs/cgroup"
+
+// Limits are the CPU and memory limits of the process's container, as set
+// by its cgroup
+type Limits struct {
+	CPUQuota      float64 // CPU time allowed, in cores (0 = unlimited)
+	MemoryLimitMB float64 // Memory allowed in MB (0 = unlimited)
+}
+
+// detectLimits returns the cgroup v2 limits of the process
+// Outside Linux, or without cgroup v2, there are no limits. Limits are
+// read once and cached.
+var detectLimits = sync.OnceValue(func() Limits {
+	if runtime.GOOS != "linux" {
+		return Limits{}
+	}
+	return readCgroupLimits(filepath.Join(cgroupRoot, selfCgroup("/proc/self/cgroup")))
+})
+
+// EffectiveCPUs returns how many CPUs the process can actually use: the
+// cgroup CPU quota rounded up, but at least 1 and at most runtime.NumCPU()
+func EffectiveCPUs() int {
+	return effectiveCPUs(detectLimits(), runtime.NumCPU())
+}
+
+// effectiveCPUs is EffectiveCPUs for limits on a machine with numCPU cores
+func effectiveCPUs(limits Limits, numCPU int) int {
+	if limits.CPUQuota <= 0 {
+		return numCPU
+	}
+	return clampCPUs(int(math.Ceil(limits.CPUQuota)), numCPU)
+}
+
+// selfCgroup returns the cgroup v2 path of the process from its
+// /proc/<pid>/cgroup file ("/" if unknown)
+func selfCgroup(procFile string) string {
+	f, err := os.Open(procFile)
+	if err != nil {
+		return "/"
+	}
+	defer f.Close()
+
+	// The v2 entry has hierarchy ID 0 and no controllers, e.g. "0::/app"
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if path, ok := strings.CutPrefix(scanner.Text(), "0::"); ok {
+			return path
+		}
+	}
+	return "/"
+}
+
+// readCgroupLimits reads the limits of the cgroup v2 directory dir
+// Missing or unreadable files, and "max", mean no limit.
+func readCgroupLimits(dir string) Limits {
+	var limits Limits
+
+	// cpu.max is "<quota> <period>" in microseconds, e.g. "150000 100000"
+	// for 1.5 cores, or "max 100000"
+	if fields := readFields(filepath.Join(dir, "cpu.max")); len(fields) == 2 {
+		quota, errQuota := strconv.ParseFloat(fields[0], 64)
+		period, errPeriod := strconv.ParseFloat(fields[1], 64)
+		if errQuota == nil && errPeriod == nil && quota > 0 && period > 0 {
+			limits.CPUQuota = quota / period
+		}
+	}
+
+	// memory.max is a byte count, or "max"
+	if fields := readFields(filepath.Join(dir, "memory.max")); len(fields) == 1 {
+		if bytes, err := strconv.ParseFloat(fields[0], 64); err == nil && bytes > 0 {
+			limits.MemoryLimitMB = bytes / 1024 / 1024
+		}
+	}
+
+	return limits
+}
+
+// readFields returns the whitespace-separated fields of file (none if it
+// can't be read)
+func readFields(file string) []string {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil
+	}
+	return strings.Fields(string(data))
+}
@@ -0,0 +1,84 @@
+package monitor
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+// LeaderboardEntry is one auction's standing on a Leaderboard
+type LeaderboardEntry struct {
+	AuctionID int
+	Bids      int     // Bids received so far
+	Revenue   float64 // Highest bid so far; once closed, the winning amount (0 if unsold)
+	Closed    bool
+}
+
+// Leaderboard ranks auctions by bids received, then by revenue, as their
+// events arrive, so the ranking can be read while the run is in progress
+// Feed it by setting its Record method as the simulation's OnEvent hook.
+// It is safe for concurrent use.
+type Leaderboard struct {
+	mu      sync.Mutex
+	entries map[int]*LeaderboardEntry // Auction ID -> standing
+}
+
+// NewLeaderboard creates an empty leaderboard
+func NewLeaderboard() *Leaderboard {
+	return &Leaderboard{entries: make(map[int]*LeaderboardEntry)}
+}
+
+// Record updates the leaderboard with one auction event
+func (l *Leaderboard) Record(event models.AuctionEvent) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	entry := l.entries[event.AuctionID]
+	if entry == nil {
+		entry = &LeaderboardEntry{AuctionID: event.AuctionID}
+		l.entries[event.AuctionID] = entry
+	}
+
+	switch event.Kind {
+	case models.EventBidReceived:
+		if entry.Closed {
+			return
+		}
+		entry.Bids++
+		entry.Revenue = max(entry.Revenue, event.Bid.Amount)
+	case models.EventAuctionClosed:
+		if event.Result == nil {
+			return
+		}
+		entry.Closed = true
+		entry.Bids = event.Result.TotalBids
+		entry.Revenue = 0
+		if event.Result.WinningBid != nil {
+			entry.Revenue = event.Result.WinningBid.Amount
+		}
+	}
+}
+
+// Top returns a snapshot of the n leading auctions, most bids first, ties
+// broken by higher revenue, then lower auction ID
+func (l *Leaderboard) Top(n int) []LeaderboardEntry {
+	l.mu.Lock()
+	top := make([]LeaderboardEntry, 0, len(l.entries))
+	for _, entry := range l.entries {
+		top = append(top, *entry)
+	}
+	l.mu.Unlock()
+
+	sort.Slice(top, func(i, j int) bool {
+		x, y := top[i], top[j]
+		if x.Bids != y.Bids {
+			return x.Bids > y.Bids
+		}
+		if x.Revenue != y.Revenue {
+			return x.Revenue > y.Revenue
+		}
+		return x.AuctionID < y.AuctionID
+	})
+	return top[:min(max(n, 0), len(top))]
+}
@@ -0,0 +1,85 @@
+package monitor
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+func TestLeaderboardConcurrentEvents(t *testing.T) {
+	leaderboard := NewLeaderboard()
+
+	// Auction i receives i bids of 10 * i each, from its own goroutine,
+	// while readers poll the ranking
+	const auctions = 20
+	var feeders, readers sync.WaitGroup
+	done := make(chan struct{})
+	for r := 0; r < 4; r++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				assertRanked(t, leaderboard.Top(5))
+			}
+		}()
+	}
+	for id := 1; id <= auctions; id++ {
+		feeders.Add(1)
+		go func(id int) {
+			defer feeders.Done()
+			for b := 1; b <= id; b++ {
+				leaderboard.Record(models.AuctionEvent{
+					Kind:      models.EventBidReceived,
+					AuctionID: id,
+					Bid:       models.Bid{BidderID: b, AuctionID: id, Amount: float64(10 * b)},
+				})
+			}
+		}(id)
+	}
+	feeders.Wait()
+	close(done)
+	readers.Wait()
+
+	top := leaderboard.Top(3)
+	if len(top) != 3 {
+		t.Fatalf("Expected 3 entries, got %d", len(top))
+	}
+	for i, entry := range top {
+		id := auctions - i
+		if entry.AuctionID != id || entry.Bids != id || entry.Revenue != float64(10*id) {
+			t.Errorf("Rank %d: expected auction %d with %d bids and revenue %d, got %+v", i+1, id, id, 10*id, entry)
+		}
+	}
+
+	// Closing without a sale keeps the bid count but zeroes the revenue
+	leaderboard.Record(models.AuctionEvent{
+		Kind:      models.EventAuctionClosed,
+		AuctionID: auctions,
+		Result:    &models.AuctionResult{AuctionID: auctions, TotalBids: auctions},
+	})
+	if entry := leaderboard.Top(1)[0]; entry.AuctionID != auctions || !entry.Closed || entry.Revenue != 0 {
+		t.Errorf("Expected closed unsold auction %d to lead, got %+v", auctions, entry)
+	}
+	if n := len(leaderboard.Top(100)); n != auctions {
+		t.Errorf("Expected Top to stop at the %d auctions, got %d", auctions, n)
+	}
+}
+
+// assertRanked checks that entries are ordered by bids, then revenue, then ID
+func assertRanked(t *testing.T, entries []LeaderboardEntry) {
+	t.Helper()
+	for i := 1; i < len(entries); i++ {
+		x, y := entries[i-1], entries[i]
+		if x.Bids < y.Bids || (x.Bids == y.Bids && (x.Revenue < y.Revenue ||
+			(x.Revenue == y.Revenue && x.AuctionID > y.AuctionID))) {
+			t.Errorf("Entries out of order: %+v before %+v", x, y)
+			return
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package monitor
+
+// ResourceMonitor samples resource usage over a run
+// RuntimeMonitor samples the Go runtime; other implementations can read
+// other sources, or return canned figures in tests (see Fake).
+type ResourceMonitor interface {
+	// Start begins sampling
+	Start()
+	// Stop ends sampling; it is called once per Start
+	Stop()
+	// GetStats aggregates the samples taken between Start and Stop
+	GetStats() ResourceStats
+	// GetSnapshots returns every sample taken
+	GetSnapshots() []ResourceSnapshot
+	// Latest returns the most recent sample, for live decisions while the
+	// monitor runs (the zero snapshot before Start)
+	Latest() ResourceSnapshot
+}
+
+// Fake is a ResourceMonitor returning canned figures, for tests
+type Fake struct {
+	Stats     ResourceStats
+	Snapshots []ResourceSnapshot
+
+	Started bool // Set by Start
+	Stopped bool // Set by Stop
+}
+
+func (f *Fake) Start()                           { f.Started = true }
+func (f *Fake) Stop()                            { f.Stopped = true }
+func (f *Fake) GetStats() ResourceStats          { return f.Stats }
+func (f *Fake) GetSnapshots() []ResourceSnapshot { return f.Snapshots }
+
+func (f *Fake) Latest() ResourceSnapshot {
+	if len(f.Snapshots) == 0 {
+		return ResourceSnapshot{}
+	}
+	return f.Snapshots[len(f.Snapshots)-1]
+}
@@ -1,39 +1,49 @@
 // Package monitor provides utilities to capture and report runtime resource usage
 // such as memory, CPU, and goroutine statistics for benchmarking and monitoring.
 //
-// It exposes a ResourceMonitor to collect periodic snapshots and helper functions
+// It exposes a RuntimeMonitor to collect periodic snapshots and helper functions
 // to standardize and query current resource state.
 package monitor
 
 import (
 	"fmt"
+	"log/slog"
 	"runtime"
+	"runtime/debug"
+	"sync"
 	"time"
+
+	"github.com/vineetjain1712/auction-simulator/config"
 )
 
 // ResourceSnapshot represents a point-in-time snapshot of resource usage
 type ResourceSnapshot struct {
-	Timestamp      time.Time
-	MemoryAllocMB  float64 // Currently allocated memory in MB
-	MemoryTotalMB  float64 // Total memory obtained from OS in MB
-	MemorySysMB    float64 // Total memory from system in MB
-	NumGoroutines  int     // Number of goroutines
-	NumCPU         int     // Number of CPUs available
-	GOMAXPROCS     int     // Number of CPUs being used
+	Timestamp     time.Time
+	MemoryAllocMB float64       // Currently allocated memory in MB
+	MemoryTotalMB float64       // Total memory obtained from OS in MB
+	MemorySysMB   float64       // Total memory from system in MB
+	NumGoroutines int           // Number of goroutines
+	NumCPU        int           // Number of CPUs available
+	GOMAXPROCS    int           // Number of CPUs being used
+	NumGC         uint32        // Completed GC cycles since program start
+	GCPauseTotal  time.Duration // Cumulative GC stop-the-world pause since program start
+	LastGCPause   time.Duration // Pause of the most recent GC cycle
 }
 
-// ResourceMonitor tracks system resource usage
-type ResourceMonitor struct {
+// RuntimeMonitor is the ResourceMonitor that samples the Go runtime
+type RuntimeMonitor struct {
 	snapshots     []ResourceSnapshot
 	startSnapshot ResourceSnapshot
 	stopSnapshot  ResourceSnapshot
 	interval      time.Duration
 	stopChan      chan struct{}
+	wg            sync.WaitGroup // Tracks the sampling goroutine
+	mu            sync.Mutex     // Protects snapshots
 }
 
-// NewResourceMonitor creates a new resource monitor
-func NewResourceMonitor(interval time.Duration) *ResourceMonitor {
-	return &ResourceMonitor{
+// NewRuntimeMonitor creates a new runtime monitor
+func NewRuntimeMonitor(interval time.Duration) *RuntimeMonitor {
+	return &RuntimeMonitor{
 		snapshots: make([]ResourceSnapshot, 0),
 		interval:  interval,
 		stopChan:  make(chan struct{}),
@@ -41,20 +51,22 @@ func NewResourceMonitor(interval time.Duration) *ResourceMonitor {
 }
 
 // Start begins monitoring resources at the specified interval
-func (rm *ResourceMonitor) Start() {
+func (rm *RuntimeMonitor) Start() {
 	// Take initial snapshot
 	rm.startSnapshot = rm.takeSnapshot()
-	rm.snapshots = append(rm.snapshots, rm.startSnapshot)
+	rm.appendSnapshot(rm.startSnapshot)
 	
+	rm.wg.Add(1)
 	go func() {
+		defer rm.wg.Done()
+
 		ticker := time.NewTicker(rm.interval)
 		defer ticker.Stop()
 		
 		for {
 			select {
 			case <-ticker.C:
-				snapshot := rm.takeSnapshot()
-				rm.snapshots = append(rm.snapshots, snapshot)
+				rm.appendSnapshot(rm.takeSnapshot())
 			case <-rm.stopChan:
 				return
 			}
@@ -63,30 +75,55 @@ func (rm *ResourceMonitor) Start() {
 }
 
 // Stop stops monitoring and takes a final snapshot
-func (rm *ResourceMonitor) Stop() {
+// It blocks until the sampling goroutine has exited, so the final snapshot
+// is always the last one recorded
+func (rm *RuntimeMonitor) Stop() {
 	close(rm.stopChan)
+	rm.wg.Wait()
+
 	rm.stopSnapshot = rm.takeSnapshot()
-	rm.snapshots = append(rm.snapshots, rm.stopSnapshot)
+	rm.appendSnapshot(rm.stopSnapshot)
+}
+
+// appendSnapshot records a snapshot
+func (rm *RuntimeMonitor) appendSnapshot(snapshot ResourceSnapshot) {
+	rm.mu.Lock()
+	rm.snapshots = append(rm.snapshots, snapshot)
+	rm.mu.Unlock()
 }
 
 // takeSnapshot captures current resource usage
-func (rm *ResourceMonitor) takeSnapshot() ResourceSnapshot {
+func (rm *RuntimeMonitor) takeSnapshot() ResourceSnapshot {
 	var m runtime.MemStats
 	runtime.ReadMemStats(&m)
 	
 	return ResourceSnapshot{
-		Timestamp:      time.Now(),
-		MemoryAllocMB:  float64(m.Alloc) / 1024 / 1024,
-		MemoryTotalMB:  float64(m.TotalAlloc) / 1024 / 1024,
-		MemorySysMB:    float64(m.Sys) / 1024 / 1024,
-		NumGoroutines:  runtime.NumGoroutine(),
-		NumCPU:         runtime.NumCPU(),
-		GOMAXPROCS:     runtime.GOMAXPROCS(0),
+		Timestamp:     time.Now(),
+		MemoryAllocMB: float64(m.Alloc) / 1024 / 1024,
+		MemoryTotalMB: float64(m.TotalAlloc) / 1024 / 1024,
+		MemorySysMB:   float64(m.Sys) / 1024 / 1024,
+		NumGoroutines: runtime.NumGoroutine(),
+		NumCPU:        runtime.NumCPU(),
+		GOMAXPROCS:    runtime.GOMAXPROCS(0),
+		NumGC:         m.NumGC,
+		GCPauseTotal:  time.Duration(m.PauseTotalNs),
+		LastGCPause:   lastGCPause(&m),
+	}
+}
+
+// lastGCPause returns the pause of the most recent GC cycle in m
+func lastGCPause(m *runtime.MemStats) time.Duration {
+	if m.NumGC == 0 {
+		return 0
 	}
+	return time.Duration(m.PauseNs[(m.NumGC+255)%256])
 }
 
 // GetStats returns computed statistics from all snapshots
-func (rm *ResourceMonitor) GetStats() ResourceStats {
+func (rm *RuntimeMonitor) GetStats() ResourceStats {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
 	if len(rm.snapshots) == 0 {
 		return ResourceStats{}
 	}
@@ -114,27 +151,56 @@ func (rm *ResourceMonitor) GetStats() ResourceStats {
 	stats.AverageMemoryMB = totalMemory / float64(len(rm.snapshots))
 	stats.MemoryDeltaMB = stats.FinalMemoryMB - stats.InitialMemoryMB
 	stats.PeakGoroutines = maxGoroutines
+	stats.NumGC = int(rm.stopSnapshot.NumGC - rm.startSnapshot.NumGC)
+	stats.TotalGCPause = rm.stopSnapshot.GCPauseTotal - rm.startSnapshot.GCPauseTotal
+	if stats.NumGC > 0 {
+		stats.LastGCPause = rm.stopSnapshot.LastGCPause
+	}
 	stats.NumCPU = rm.startSnapshot.NumCPU
 	stats.GOMAXPROCS = rm.startSnapshot.GOMAXPROCS
+	stats.EffectiveCPUs = EffectiveCPUs()
+	stats.MemoryLimitMB = detectLimits().MemoryLimitMB
 	
 	return stats
 }
 
+// Latest returns the most recent snapshot, for live decisions while the
+// monitor runs (the zero snapshot before Start)
+func (rm *RuntimeMonitor) Latest() ResourceSnapshot {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	if len(rm.snapshots) == 0 {
+		return ResourceSnapshot{}
+	}
+	return rm.snapshots[len(rm.snapshots)-1]
+}
+
 // GetSnapshots returns all captured snapshots
-func (rm *ResourceMonitor) GetSnapshots() []ResourceSnapshot {
-	return rm.snapshots
+func (rm *RuntimeMonitor) GetSnapshots() []ResourceSnapshot {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+
+	snapshots := make([]ResourceSnapshot, len(rm.snapshots))
+	copy(snapshots, rm.snapshots)
+	return snapshots
 }
 
 // ResourceStats contains aggregated resource statistics
 type ResourceStats struct {
-	InitialMemoryMB float64 // Memory at start
-	FinalMemoryMB   float64 // Memory at end
-	PeakMemoryMB    float64 // Maximum memory used
-	AverageMemoryMB float64 // Average memory across snapshots
-	MemoryDeltaMB   float64 // Change in memory (final - initial)
-	PeakGoroutines  int     // Maximum concurrent goroutines
-	NumCPU          int     // Total CPUs available
-	GOMAXPROCS      int     // CPUs being used
+	InitialMemoryMB float64       // Memory at start
+	FinalMemoryMB   float64       // Memory at end
+	PeakMemoryMB    float64       // Maximum memory used
+	AverageMemoryMB float64       // Average memory across snapshots
+	MemoryDeltaMB   float64       // Change in memory (final - initial)
+	PeakGoroutines  int           // Maximum concurrent goroutines
+	NumCPU          int           // Total CPUs available
+	GOMAXPROCS      int           // CPUs being used
+	EffectiveCPUs   int           // CPUs the container's CPU quota allows (NumCPU without a quota)
+	MemoryLimitMB   float64       // Container memory limit in MB (0 = unlimited)
+	NumGC           int           // GC cycles completed between start and stop
+	TotalGCPause    time.Duration // GC pause time between start and stop
+	LastGCPause     time.Duration // Pause of the last GC cycle during the run (0 if none)
 }
 
 // FormatReport generates a formatted report of resource usage
@@ -148,9 +214,15 @@ func (rs ResourceStats) FormatReport() string {
 	report += fmt.Sprintf("   ├─ Peak:        %.2f MB\n", rs.PeakMemoryMB)
 	report += fmt.Sprintf("   ├─ Average:     %.2f MB\n", rs.AverageMemoryMB)
 	report += fmt.Sprintf("   └─ Delta:       %+.2f MB\n\n", rs.MemoryDeltaMB)
+
+	report += "🗑️  Garbage Collection:\n"
+	report += fmt.Sprintf("   ├─ Cycles:      %d\n", rs.NumGC)
+	report += fmt.Sprintf("   ├─ Total Pause: %v\n", rs.TotalGCPause)
+	report += fmt.Sprintf("   └─ Last Pause:  %v\n\n", rs.LastGCPause)
 	
 	report += "⚙️  CPU & Concurrency:\n"
 	report += fmt.Sprintf("   ├─ Available CPUs:    %d\n", rs.NumCPU)
+	report += fmt.Sprintf("   ├─ Effective CPUs:    %d\n", rs.EffectiveCPUs)
 	report += fmt.Sprintf("   ├─ GOMAXPROCS:        %d\n", rs.GOMAXPROCS)
 	report += fmt.Sprintf("   └─ Peak Goroutines:   %d\n\n", rs.PeakGoroutines)
 	
@@ -165,15 +237,43 @@ func (rs ResourceStats) FormatReport() string {
 }
 
 // StandardizeResources sets consistent resource limits for benchmarking
-func StandardizeResources(maxCPUs int) {
+// maxCPUs 0 uses EffectiveCPUs; otherwise it is clamped to
+// [1, EffectiveCPUs()], so a container's CPU quota is respected. Clamping
+// is logged as a warning to logger, unless it only lowered the default
+// config.DefaultMaxCPUCores to the CPUs available
+// gcPercent, if positive, sets the GC target percentage (see
+// debug.SetGCPercent), trading CPU for lower peak memory; otherwise the
+// GC setting is left as is
+// Returns the number of CPUs actually used
+func StandardizeResources(logger *slog.Logger, maxCPUs, gcPercent int) int {
 	// Set maximum CPUs to use
-	runtime.GOMAXPROCS(maxCPUs)
+	available := EffectiveCPUs()
+	if maxCPUs == 0 {
+		maxCPUs = available
+	}
+	cpus := clampCPUs(maxCPUs, available)
+	if cpus != maxCPUs && !(maxCPUs == config.DefaultMaxCPUCores && cpus == available) {
+		logger.Warn("max CPU cores out of range, clamping",
+			"requested", maxCPUs, "using", cpus, "available", available)
+	}
+	runtime.GOMAXPROCS(cpus)
+	if gcPercent > 0 {
+		debug.SetGCPercent(gcPercent)
+	}
 	
 	// Force garbage collection to start clean
 	runtime.GC()
 	
 	// Give GC a moment to complete
 	time.Sleep(100 * time.Millisecond)
+
+	return cpus
+}
+
+// clampCPUs limits maxCPUs to between 1 and numCPU: GOMAXPROCS ignores
+// values below 1, and more than numCPU over-subscribes the machine
+func clampCPUs(maxCPUs, numCPU int) int {
+	return min(max(maxCPUs, 1), numCPU)
 }
 
 // GetCurrentResources returns current resource snapshot
@@ -182,12 +282,15 @@ func GetCurrentResources() ResourceSnapshot {
 	runtime.ReadMemStats(&m)
 	
 	return ResourceSnapshot{
-		Timestamp:      time.Now(),
-		MemoryAllocMB:  float64(m.Alloc) / 1024 / 1024,
-		MemoryTotalMB:  float64(m.TotalAlloc) / 1024 / 1024,
-		MemorySysMB:    float64(m.Sys) / 1024 / 1024,
-		NumGoroutines:  runtime.NumGoroutine(),
-		NumCPU:         runtime.NumCPU(),
-		GOMAXPROCS:     runtime.GOMAXPROCS(0),
+		Timestamp:     time.Now(),
+		MemoryAllocMB: float64(m.Alloc) / 1024 / 1024,
+		MemoryTotalMB: float64(m.TotalAlloc) / 1024 / 1024,
+		MemorySysMB:   float64(m.Sys) / 1024 / 1024,
+		NumGoroutines: runtime.NumGoroutine(),
+		NumCPU:        runtime.NumCPU(),
+		GOMAXPROCS:    runtime.GOMAXPROCS(0),
+		NumGC:         m.NumGC,
+		GCPauseTotal:  time.Duration(m.PauseTotalNs),
+		LastGCPause:   lastGCPause(&m),
 	}
 }
\ No newline at end of file
@@ -0,0 +1,196 @@
+package monitor
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vineetjain1712/auction-simulator/config"
+	"github.com/vineetjain1712/auction-simulator/internal/logging"
+)
+
+func TestStopWaitsForSampler(t *testing.T) {
+	rm := NewRuntimeMonitor(time.Millisecond)
+	rm.Start()
+
+	// Let the sampler record a few snapshots
+	time.Sleep(20 * time.Millisecond)
+	rm.Stop()
+
+	snapshots := rm.GetSnapshots()
+	if len(snapshots) < 2 {
+		t.Fatalf("Expected at least start and stop snapshots, got %d", len(snapshots))
+	}
+
+	// The stop snapshot must be the last one recorded
+	last := snapshots[len(snapshots)-1]
+	if !last.Timestamp.Equal(rm.stopSnapshot.Timestamp) {
+		t.Error("Expected the stop snapshot to be the final snapshot")
+	}
+
+	// No more snapshots may be appended once Stop has returned
+	time.Sleep(20 * time.Millisecond)
+	if after := len(rm.GetSnapshots()); after != len(snapshots) {
+		t.Errorf("Expected %d snapshots after Stop, got %d", len(snapshots), after)
+	}
+}
+
+// sink keeps allocations in TestGCStatsCounted from being optimized away
+var sink []byte
+
+func TestGCStatsCounted(t *testing.T) {
+	rm := NewRuntimeMonitor(time.Hour)
+	rm.Start()
+
+	for i := 0; i < 100; i++ {
+		sink = make([]byte, 1<<20)
+	}
+	runtime.GC()
+
+	rm.Stop()
+	stats := rm.GetStats()
+
+	if stats.NumGC < 1 {
+		t.Errorf("Expected at least one GC cycle during the run, got %d", stats.NumGC)
+	}
+	if stats.TotalGCPause < 0 {
+		t.Errorf("Expected non-negative GC pause, got %v", stats.TotalGCPause)
+	}
+	if stats.LastGCPause > stats.TotalGCPause {
+		t.Errorf("Last pause %v exceeds total pause %v", stats.LastGCPause, stats.TotalGCPause)
+	}
+}
+
+func TestClampCPUs(t *testing.T) {
+	tests := []struct {
+		name            string
+		maxCPUs, numCPU int
+		want            int
+	}{
+		{"zero", 0, 8, 1},
+		{"negative", -3, 8, 1},
+		{"over max", 16, 8, 8},
+		{"in range", 4, 8, 4},
+		{"at max", 8, 8, 8},
+	}
+
+	for _, tt := range tests {
+		if got := clampCPUs(tt.maxCPUs, tt.numCPU); got != tt.want {
+			t.Errorf("%s: clampCPUs(%d, %d) = %d, want %d", tt.name, tt.maxCPUs, tt.numCPU, got, tt.want)
+		}
+	}
+}
+
+func TestStandardizeResourcesClamps(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(0))
+
+	for _, maxCPUs := range []int{0, -1, runtime.NumCPU() + 1} {
+		used := StandardizeResources(logging.Discard(), maxCPUs, 0)
+		if used < 1 || used > runtime.NumCPU() {
+			t.Errorf("StandardizeResources(%d) used %d CPUs, outside [1, %d]", maxCPUs, used, runtime.NumCPU())
+		}
+		if got := runtime.GOMAXPROCS(0); got != used {
+			t.Errorf("StandardizeResources(%d): GOMAXPROCS is %d, expected %d", maxCPUs, got, used)
+		}
+	}
+}
+
+func TestStandardizeResourcesWarnsOnClamp(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(0))
+	// A one CPU quota clamps both requests below
+	defer func(detect func() Limits) { detectLimits = detect }(detectLimits)
+	detectLimits = func() Limits { return Limits{CPUQuota: 1} }
+
+	var logs bytes.Buffer
+	StandardizeResources(logging.New(&logs, "warn"), config.DefaultMaxCPUCores, 0)
+	if logs.Len() != 0 {
+		t.Errorf("Expected no warning when the default is clamped, got %q", logs.String())
+	}
+
+	StandardizeResources(logging.New(&logs, "warn"), 16, 0)
+	if !strings.Contains(logs.String(), "clamping") {
+		t.Errorf("Expected a warning when a requested count is clamped, got %q", logs.String())
+	}
+}
+
+func TestStandardizeResourcesGCPercent(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(0))
+	defer debug.SetGCPercent(debug.SetGCPercent(100))
+
+	StandardizeResources(logging.Discard(), 1, 50)
+	if got := debug.SetGCPercent(50); got != 50 {
+		t.Errorf("Expected GC percent 50, got %d", got)
+	}
+
+	// An unset value leaves the current setting alone
+	for _, gcPercent := range []int{0, -1} {
+		StandardizeResources(logging.Discard(), 1, gcPercent)
+		if got := debug.SetGCPercent(50); got != 50 {
+			t.Errorf("StandardizeResources(1, %d) changed the GC percent to %d", gcPercent, got)
+		}
+	}
+}
+
+func TestReadCgroupLimits(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("cpu.max", "150000 100000\n")
+	write("memory.max", "536870912\n")
+
+	limits := readCgroupLimits(dir)
+	if limits.CPUQuota != 1.5 || limits.MemoryLimitMB != 512 {
+		t.Errorf("Expected 1.5 CPUs and 512 MB, got %+v", limits)
+	}
+	// A fractional quota still needs a whole CPU, capped by the machine
+	if got := effectiveCPUs(limits, 8); got != 2 {
+		t.Errorf("Expected 2 effective CPUs under a 1.5 CPU quota, got %d", got)
+	}
+	// The detected quota sets the CPUs used by default
+	defer func(detect func() Limits) { detectLimits = detect }(detectLimits)
+	detectLimits = func() Limits { return readCgroupLimits(dir) }
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(0))
+	if want, got := min(2, runtime.NumCPU()), StandardizeResources(logging.Discard(), 0, 0); got != want {
+		t.Errorf("Expected StandardizeResources(0) to use the quota's %d CPUs, got %d", want, got)
+	}
+
+	if got := effectiveCPUs(Limits{CPUQuota: 32}, 8); got != 8 {
+		t.Errorf("Expected a quota above the machine to use its 8 CPUs, got %d", got)
+	}
+
+	// "max" and missing files mean no limit
+	write("cpu.max", "max 100000\n")
+	write("memory.max", "max\n")
+	if limits := readCgroupLimits(dir); limits != (Limits{}) {
+		t.Errorf("Expected no limits for \"max\", got %+v", limits)
+	}
+	if limits := readCgroupLimits(filepath.Join(dir, "missing")); limits != (Limits{}) {
+		t.Errorf("Expected no limits without cgroup files, got %+v", limits)
+	}
+	if got := effectiveCPUs(Limits{}, 8); got != 8 {
+		t.Errorf("Expected all 8 CPUs without a quota, got %d", got)
+	}
+}
+
+func TestSelfCgroup(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "cgroup")
+	content := "1:cpu:/\n0::/system.slice/app.service\n"
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if got := selfCgroup(file); got != "/system.slice/app.service" {
+		t.Errorf("Expected the v2 cgroup path, got %q", got)
+	}
+	if got := selfCgroup(filepath.Join(t.TempDir(), "missing")); got != "/" {
+		t.Errorf("Expected the root cgroup without a cgroup file, got %q", got)
+	}
+}
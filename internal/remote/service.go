@@ -0,0 +1,166 @@
+// Package remote lets external bidder agents place real bids in running
+// auctions over JSON-RPC, turning the simulator into a test harness for
+// bidding clients.
+//
+// A client keeps one connection open and streams Auction.PlaceBid calls
+// over it; each bid is routed to its auction by ID, checked against the
+// auction's deadline and taken in by the auction before the reply is sent.
+package remote
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+
+	"github.com/vineetjain1712/auction-simulator/internal/auction"
+	"github.com/vineetjain1712/auction-simulator/internal/clock"
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+// serviceName prefixes the RPC methods, e.g. "Auction.PlaceBid"
+const serviceName = "Auction"
+
+// BidRequest is a bid placed by a remote bidder
+type BidRequest struct {
+	AuctionID int
+	BidderID  int
+	Amount    float64
+}
+
+// BidReply tells a remote bidder what became of its bid
+type BidReply struct {
+	// Accepted is true once the auction has stored the bid; it can still
+	// be outbid
+	Accepted bool
+	// Reason is models.ReasonAccepted, or why the bid was turned away:
+	// models.ReasonInvalid, models.ReasonTooLow (below a public reserve),
+	// models.ReasonLate, models.ReasonClosed, models.ReasonNotStarted or,
+	// depending on the auction's settings, models.ReasonDuplicate or
+	// models.ReasonRepeatInRound
+	Reason models.DecisionReason
+}
+
+// BidService routes remote bids to a set of auctions
+type BidService struct {
+	auctions map[int]*auction.Auction
+	server   *rpc.Server
+}
+
+// NewBidService creates a service placing bids in auctions, by auction ID
+func NewBidService(auctions []*auction.Auction) (*BidService, error) {
+	s := &BidService{
+		auctions: make(map[int]*auction.Auction, len(auctions)),
+		server:   rpc.NewServer(),
+	}
+	for _, auc := range auctions {
+		s.auctions[auc.ID] = auc
+	}
+
+	// Only the handler's methods are published
+	if err := s.server.RegisterName(serviceName, handler{s}); err != nil {
+		return nil, fmt.Errorf("failed to register bid service: %w", err)
+	}
+	return s, nil
+}
+
+// ServeConn serves bids from one client connection until it is closed
+func (s *BidService) ServeConn(conn io.ReadWriteCloser) {
+	s.server.ServeCodec(jsonrpc.NewServerCodec(conn))
+}
+
+// Serve serves each connection accepted on listener until listener is
+// closed, returning the error that stopped it
+func (s *BidService) Serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go s.ServeConn(conn)
+	}
+}
+
+// PlaceBid validates req and delivers it to its auction, replying once the
+// auction has taken it in or turned it away
+// A bid for an auction that has not started yet waits for it to start, for
+// at most the auction's timeout. Returns an error only for an unknown
+// auction.
+func (s *BidService) PlaceBid(ctx context.Context, req BidRequest) (BidReply, error) {
+	auc, ok := s.auctions[req.AuctionID]
+	if !ok {
+		return BidReply{}, fmt.Errorf("unknown auction %d", req.AuctionID)
+	}
+
+	// Valid amounts are positive and finite, as for simulated bids
+	if !(req.Amount > 0) || math.IsInf(req.Amount, 1) {
+		return BidReply{Reason: models.ReasonInvalid}, nil
+	}
+
+	ctx, cancel := clock.WithTimeout(ctx, auc.Clock, auc.Timeout)
+	defer cancel()
+	select {
+	case <-auc.Started():
+	case <-ctx.Done():
+		return BidReply{Reason: models.ReasonNotStarted}, nil
+	}
+
+	now := auc.Clock.Now()
+	deadline, _, closed := auc.BidWindow()
+	switch {
+	case closed:
+		return BidReply{Reason: models.ReasonClosed}, nil
+	case now.After(deadline):
+		return BidReply{Reason: models.ReasonLate}, nil
+	}
+
+	bid := models.Bid{
+		BidderID:  req.BidderID,
+		AuctionID: req.AuctionID,
+		Amount:    req.Amount,
+		Timestamp: now,
+	}
+	if reason := auc.Receive(ctx, bid); reason != models.ReasonAccepted {
+		return BidReply{Reason: reason}, nil
+	}
+	return BidReply{Accepted: true, Reason: models.ReasonAccepted}, nil
+}
+
+// handler adapts BidService to net/rpc's method signatures
+type handler struct {
+	s *BidService
+}
+
+// PlaceBid is the Auction.PlaceBid RPC method
+func (h handler) PlaceBid(req BidRequest, reply *BidReply) error {
+	r, err := h.s.PlaceBid(context.Background(), req)
+	*reply = r
+	return err
+}
+
+// Client places bids with a BidService over one connection
+type Client struct {
+	rpc *rpc.Client
+}
+
+// NewClient returns a client speaking JSON-RPC over conn
+func NewClient(conn io.ReadWriteCloser) *Client {
+	return &Client{rpc: jsonrpc.NewClient(conn)}
+}
+
+// PlaceBid places req and returns the service's reply
+func (c *Client) PlaceBid(req BidRequest) (BidReply, error) {
+	var reply BidReply
+	if err := c.rpc.Call(serviceName+".PlaceBid", req, &reply); err != nil {
+		return BidReply{}, err
+	}
+	return reply, nil
+}
+
+// Close closes the connection
+func (c *Client) Close() error {
+	return c.rpc.Close()
+}
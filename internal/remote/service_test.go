@@ -0,0 +1,129 @@
+package remote
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/vineetjain1712/auction-simulator/internal/auction"
+	"github.com/vineetjain1712/auction-simulator/internal/clock"
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+func TestRemoteBidsReachAuctionResult(t *testing.T) {
+	auc := auction.NewAuction(7, models.AuctionItem{ID: 1, Name: "Lamp", BasePrice: 50}, time.Minute)
+	auc.Output = io.Discard
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan models.AuctionResult)
+	go func() {
+		done <- auc.Run(ctx)
+	}()
+
+	service, err := NewBidService([]*auction.Auction{auc})
+	if err != nil {
+		t.Fatal(err)
+	}
+	serverConn, clientConn := net.Pipe()
+	go service.ServeConn(serverConn)
+	client := NewClient(clientConn)
+	defer client.Close()
+
+	replies := []struct {
+		req  BidRequest
+		want models.DecisionReason
+	}{
+		{BidRequest{AuctionID: 7, BidderID: 1, Amount: 60}, models.ReasonAccepted},
+		{BidRequest{AuctionID: 7, BidderID: 2, Amount: 75.5}, models.ReasonAccepted},
+		{BidRequest{AuctionID: 7, BidderID: 3, Amount: -1}, models.ReasonInvalid},
+		{BidRequest{AuctionID: 7, BidderID: 1, Amount: 70}, models.ReasonAccepted},
+	}
+	accepted := 0
+	for _, r := range replies {
+		reply, err := client.PlaceBid(r.req)
+		if err != nil {
+			t.Fatalf("Bid %+v failed: %v", r.req, err)
+		}
+		if reply.Reason != r.want || reply.Accepted != (r.want == models.ReasonAccepted) {
+			t.Errorf("Bid %+v: expected %s, got %+v", r.req, r.want, reply)
+		}
+		if reply.Accepted {
+			accepted++
+		}
+		// An accepted bid has been stored by the time the reply arrives
+		if stored := len(auc.GetAllBids()); stored != accepted {
+			t.Errorf("Bid %+v: expected %d stored bids, got %d", r.req, accepted, stored)
+		}
+	}
+	if _, err := client.PlaceBid(BidRequest{AuctionID: 99, BidderID: 1, Amount: 10}); err == nil {
+		t.Error("Expected an error for an unknown auction")
+	}
+
+	cancel()
+	result := <-done
+
+	if result.TotalBids != 3 {
+		t.Fatalf("Expected the 3 accepted bids in the result, got %d", result.TotalBids)
+	}
+	if result.WinningBid == nil || result.WinningBid.BidderID != 2 || result.WinningBid.Amount != 75.5 {
+		t.Errorf("Expected bidder 2 to win with 75.50, got %+v", result.WinningBid)
+	}
+
+	reply, err := client.PlaceBid(BidRequest{AuctionID: 7, BidderID: 4, Amount: 100})
+	if err != nil || reply.Accepted || reply.Reason != models.ReasonClosed {
+		t.Errorf("Expected a bid after close to be turned away as closed, got %+v (%v)", reply, err)
+	}
+}
+
+func TestBidWaitsForAuctionStart(t *testing.T) {
+	auc := auction.NewAuction(1, models.AuctionItem{ID: 1, BasePrice: 50}, time.Minute)
+	service, err := NewBidService([]*auction.Auction{auc})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replies := make(chan BidReply)
+	go func() {
+		reply, _ := service.PlaceBid(context.Background(), BidRequest{AuctionID: 1, BidderID: 1, Amount: 60})
+		replies <- reply
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan models.AuctionResult)
+	go func() {
+		done <- auc.Run(ctx)
+	}()
+	if reply := <-replies; !reply.Accepted {
+		t.Errorf("Expected the bid to be taken once the auction started, got %+v", reply)
+	}
+	cancel()
+	if result := <-done; result.TotalBids != 1 {
+		t.Errorf("Expected the early bid in the result, got %d bids", result.TotalBids)
+	}
+}
+
+func TestBidForUnstartedAuctionTimesOut(t *testing.T) {
+	fake := clock.NewFake(time.Unix(0, 0))
+	auc := auction.NewAuction(1, models.AuctionItem{ID: 1, BasePrice: 50}, time.Minute)
+	auc.Clock = fake
+	service, err := NewBidService([]*auction.Auction{auc})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replies := make(chan BidReply)
+	go func() {
+		reply, _ := service.PlaceBid(context.Background(), BidRequest{AuctionID: 1, BidderID: 1, Amount: 60})
+		replies <- reply
+	}()
+	for fake.Pending() < 1 {
+		time.Sleep(time.Millisecond)
+	}
+	fake.Advance(auc.Timeout)
+
+	if reply := <-replies; reply.Accepted || reply.Reason != models.ReasonNotStarted {
+		t.Errorf("Expected the bid to give up on the unstarted auction, got %+v", reply)
+	}
+}
@@ -0,0 +1,55 @@
+package simulation
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/vineetjain1712/auction-simulator/internal/auction"
+	"github.com/vineetjain1712/auction-simulator/internal/clock"
+)
+
+// reportProgress prints the auctions finished so far, with an estimated
+// time remaining, every System.ProgressInterval (wall-clock) until the
+// returned stop function is called
+// Elapsed time is measured on clk, so simulated runs estimate in simulated
+// time. Without an interval it reports nothing.
+func (s *Simulator) reportProgress(clk clock.Clock, manager *auction.Manager) (stop func()) {
+	interval := s.Config.System.ProgressInterval
+	if interval <= 0 {
+		return func() {}
+	}
+
+	done := make(chan struct{})
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				total := len(manager.Auctions)
+				elapsed := clock.Since(clk, manager.StartTime)
+				fmt.Fprintln(s.Output, formatProgress(manager.Progress(), total, elapsed))
+			case <-done:
+				return
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		wg.Wait()
+	}
+}
+
+// formatProgress describes progress of total auctions after elapsed
+func formatProgress(progress auction.Progress, total int, elapsed time.Duration) string {
+	eta := "estimating..."
+	if left, ok := progress.ETA(total, elapsed); ok {
+		eta = left.Round(100 * time.Millisecond).String()
+	}
+	return fmt.Sprintf("📈 Progress: %d/%d auctions (%.1f%%), ETA %s",
+		progress.Completed, total, progress.Percent(total), eta)
+}
@@ -0,0 +1,21 @@
+package simulation
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vineetjain1712/auction-simulator/internal/auction"
+)
+
+func TestFormatProgress(t *testing.T) {
+	early := formatProgress(auction.Progress{Completed: 1}, 40, time.Second)
+	if !strings.Contains(early, "1/40") || !strings.Contains(early, "estimating") {
+		t.Errorf("Expected no estimate from one auction, got %q", early)
+	}
+
+	later := formatProgress(auction.Progress{Completed: 20}, 40, 2*time.Second)
+	if !strings.Contains(later, "(50.0%)") || !strings.Contains(later, "ETA 2s") {
+		t.Errorf("Expected half done with 2s left, got %q", later)
+	}
+}
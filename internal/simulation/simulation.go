@@ -0,0 +1,340 @@
+// Package simulation runs a complete auction simulation: it creates the
+// auctions and bidder pool, runs them concurrently under resource monitoring,
+// and aggregates the outcome into a SimulationResult.
+//
+// It is the library entry point used by the CLI and by programs embedding the
+// simulator.
+package simulation
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log/slog"
+	"net"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/vineetjain1712/auction-simulator/config"
+	"github.com/vineetjain1712/auction-simulator/internal/auction"
+	"github.com/vineetjain1712/auction-simulator/internal/bidder"
+	"github.com/vineetjain1712/auction-simulator/internal/clock"
+	"github.com/vineetjain1712/auction-simulator/internal/logging"
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+	"github.com/vineetjain1712/auction-simulator/internal/monitor"
+	"github.com/vineetjain1712/auction-simulator/internal/remote"
+)
+
+// Simulator runs simulations for a configuration
+type Simulator struct {
+	Config *config.Config
+
+	// Output receives console progress lines
+	// Defaults to io.Discard, so library runs print nothing
+	Output io.Writer
+	// Logger receives structured logs at the configured level
+	Logger *slog.Logger
+
+	// Monitor samples resource usage during the next Run
+	// Defaults to a new RuntimeMonitor for each run
+	Monitor monitor.ResourceMonitor
+
+	// OnEvent, if set, receives the events of every auction as the run
+	// progresses, e.g. a monitor.Leaderboard's Record for a live leaderboard
+	OnEvent func(models.AuctionEvent)
+}
+
+// NewSimulator creates a simulator for the given configuration
+func NewSimulator(cfg *config.Config) *Simulator {
+	return &Simulator{
+		Config: cfg,
+		Output: io.Discard,
+		Logger: logging.New(os.Stderr, cfg.System.LogLevel),
+	}
+}
+
+// RunSimulation runs a full simulation for cfg with default settings
+func RunSimulation(ctx context.Context, cfg *config.Config) models.SimulationResult {
+	return NewSimulator(cfg).Run(ctx)
+}
+
+// RunSimulationWithMonitor is RunSimulation with resource usage sampled
+// by resourceMonitor
+func RunSimulationWithMonitor(ctx context.Context, cfg *config.Config, resourceMonitor monitor.ResourceMonitor) models.SimulationResult {
+	simulator := NewSimulator(cfg)
+	simulator.Monitor = resourceMonitor
+	return simulator.Run(ctx)
+}
+
+// simulatedTimeStep is how long (wall-clock) simulated time waits for the
+// simulation to go quiet before jumping to the next timer
+const simulatedTimeStep = 500 * time.Microsecond
+
+// Run orchestrates the entire auction simulation with monitoring
+// If ctx carries no run ID (see logging.WithRunID), a new one is assigned so
+// every log line of this run can be correlated
+func (s *Simulator) Run(ctx context.Context) models.SimulationResult {
+	r, stop := s.run(ctx, false)
+	defer stop()
+	return s.finish(r)
+}
+
+// RunCompact is Run keeping only a compact result per auction, and
+// releasing each auction's bids once it closes, to bound memory on very
+// large runs
+// Compact runs keep no bids to reassign, so bidder budgets are not
+// reconciled, and resource metrics are not included in the result.
+func (s *Simulator) RunCompact(ctx context.Context) models.CompactSimulationResult {
+	r, stop := s.run(ctx, true)
+	defer stop()
+
+	s.complete(r)
+	result := r.manager.AggregateCompact()
+	s.Logger.InfoContext(r.ctx, "simulation complete",
+		"duration", result.TotalDuration, "bids", result.TotalBids,
+		"successful", result.SuccessfulAuctions, "dropouts", r.bidders.Dropouts())
+	return result
+}
+
+// completedRun is a simulation whose auctions have all finished
+type completedRun struct {
+	ctx     context.Context
+	clk     clock.Clock
+	seed    int64
+	manager *auction.Manager
+	bidders *bidder.Pool
+	monitor monitor.ResourceMonitor
+}
+
+// run runs every auction of the simulation to completion, keeping compact
+// results if compact is set
+// The returned stop function releases the run's simulated clock, if any,
+// once the result is built.
+func (s *Simulator) run(ctx context.Context, compact bool) (r completedRun, stop func()) {
+	cfg := s.Config
+	stop = func() {}
+
+	if _, ok := logging.RunID(ctx); !ok {
+		ctx = logging.WithRunID(ctx, newRunID())
+	}
+
+	fmt.Fprintln(s.Output, "🎬 Starting Simulation")
+	fmt.Fprintln(s.Output, "════════════════════════════════════════════════════════")
+	// A time-based run still gets one explicit seed, reported in the
+	// result, so it can be reproduced
+	seed := cfg.System.Seed
+	if seed == 0 {
+		seed = newSeed()
+	}
+	fmt.Fprintf(s.Output, "🎲 Seed: %d\n", seed)
+	if cfg.Bidder.MinBidMultiplier < 1 && !cfg.Bidder.FloorAtBasePrice {
+		s.Logger.WarnContext(ctx, "min bid multiplier below 1: bids may fall below base price",
+			"min_bid_multiplier", cfg.Bidder.MinBidMultiplier)
+	}
+	s.Logger.InfoContext(ctx, "starting simulation",
+		"auctions", cfg.Auction.TotalAuctions, "bidders", cfg.Bidder.TotalBidders, "seed", seed)
+
+	// Start resource monitoring
+	resourceMonitor := s.Monitor
+	if resourceMonitor == nil {
+		resourceMonitor = monitor.NewRuntimeMonitor(500 * time.Millisecond)
+	}
+	resourceMonitor.Start()
+
+	// Create manager and bidder pool
+	manager := auction.NewManager(cfg)
+	manager.Compact = compact
+	manager.Generator = auction.NewItemGeneratorWithSeed(seed)
+	manager.Generator.HotFraction = cfg.Auction.HotItemFraction
+	manager.Generator.CategoryWeights = cfg.Auction.CategoryWeights
+	manager.OnEvent = s.OnEvent
+	clk := clock.Real()
+	if cfg.System.SimulatedTime {
+		// Auctions and bidders share a clock that jumps from timer to timer
+		fake := clock.NewFake(time.Now())
+		clk = fake
+		manager.Clock = fake
+		driveCtx, cancel := context.WithCancel(ctx)
+		stop = cancel
+		go fake.Run(driveCtx, simulatedTimeStep)
+	}
+	if cfg.System.MemoryCeilingMB > 0 {
+		// Launch auctions as the live memory readings allow
+		manager.Throttle = auction.NewMemoryThrottle(cfg.System.MemoryCeilingMB, func() float64 {
+			return resourceMonitor.Latest().MemoryAllocMB
+		})
+	}
+	bidderPool := bidder.NewPool(&cfg.Bidder,
+		bidder.WithSeed(seed), bidder.WithWorkers(runtime.GOMAXPROCS(0)))
+	bidderPool.Output = s.Output
+	bidderPool.Logger = s.Logger
+	r = completedRun{ctx: ctx, clk: clk, seed: seed, manager: manager, bidders: bidderPool, monitor: resourceMonitor}
+
+	// Pre-create all auctions, of the configured items if any
+	items := cfg.Auction.Items
+	if items == nil {
+		items = manager.Generator.GenerateItems(cfg.Auction.TotalAuctions)
+	}
+	for i, item := range items {
+		auc := manager.NewAuction(i+1, item)
+		auc.Output = s.Output
+		auc.Logger = s.Logger
+		manager.Auctions = append(manager.Auctions, auc)
+	}
+
+	fmt.Fprintf(s.Output, "📦 Pre-generated %d auctions\n", len(manager.Auctions))
+	if addr := cfg.System.RemoteBidAddr; addr != "" {
+		stopBids := s.serveBids(ctx, addr, manager.Auctions)
+		defer stopBids()
+	}
+
+	var wg sync.WaitGroup
+
+	// Record start time
+	manager.StartTime = clk.Now()
+	fmt.Fprintf(s.Output, "⏱️  Start Time: %s\n\n", manager.StartTime.Format("15:04:05.000"))
+
+	if cfg.System.Deterministic || cfg.Auction.Type == config.English {
+		// Each auction collects its bidders' bids in pool order on its own
+		// goroutine, so a seeded run is exactly repeatable
+		bids := bidderPool.SequentialBids
+		if cfg.Auction.Type == config.English {
+			// Outbid bidders raise in turn, in simulated time
+			bids = func(auc *auction.Auction) []models.Bid {
+				return bidderPool.EnglishBids(auc, cfg.Auction.MinimumBidIncrement)
+			}
+		}
+		fmt.Fprintln(s.Output, "🔨 Running all auctions deterministically...")
+		manager.StartAuctionsWith(ctx, func(auc *auction.Auction, ctx context.Context) models.AuctionResult {
+			return auc.RunSequential(ctx, bids(auc))
+		})
+		stopProgress := s.reportProgress(clk, manager)
+		manager.Wait()
+		stopProgress()
+		return r, stop
+	}
+
+	// Simulated time stands still until every bidder has been launched
+	release := clock.Hold(clk)
+	bidderPool.Launched = release
+
+	// Start all auctions
+	fmt.Fprintln(s.Output, "🔨 Starting all auctions...")
+	manager.StartAuctions(ctx)
+
+	// Small delay to ensure auctions are running
+	time.Sleep(50 * time.Millisecond)
+
+	// Activate bidders
+	fmt.Fprintln(s.Output, "👥 Activating bidders...")
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer release()
+		if err := bidderPool.ParticipateInAllAuctions(ctx, manager.Auctions); err != nil {
+			s.Logger.ErrorContext(ctx, "bidders could not participate", "error", err)
+		}
+	}()
+
+	// Wait for completion
+	fmt.Fprintln(s.Output, "⏳ Waiting for completion...")
+	stopProgress := s.reportProgress(clk, manager)
+	manager.Wait()
+	stopProgress()
+	wg.Wait()
+
+	return r, stop
+}
+
+// serveBids serves bids from external bidders on addr for auctions until
+// the returned function is called
+// A service that cannot listen is logged, and the run goes on without it.
+func (s *Simulator) serveBids(ctx context.Context, addr string, auctions []*auction.Auction) (stop func()) {
+	service, err := remote.NewBidService(auctions)
+	var listener net.Listener
+	if err == nil {
+		listener, err = net.Listen("tcp", addr)
+	}
+	if err != nil {
+		s.Logger.ErrorContext(ctx, "cannot serve remote bids", "addr", addr, "error", err)
+		return func() {}
+	}
+
+	fmt.Fprintf(s.Output, "📡 Serving remote bids on %s\n", listener.Addr())
+	go service.Serve(listener)
+	return func() { listener.Close() }
+}
+
+// complete records the end of a finished run and stops monitoring it,
+// returning the resource usage over the run
+func (s *Simulator) complete(r completedRun) monitor.ResourceStats {
+	r.manager.EndTime = r.clk.Now()
+	fmt.Fprintf(s.Output, "\n⏱️  End Time: %s\n", r.manager.EndTime.Format("15:04:05.000"))
+
+	// Stop monitoring ONCE
+	r.monitor.Stop()
+	resourceStats := r.monitor.GetStats()
+
+	fmt.Fprintln(s.Output, "\n✅ Simulation Complete!")
+	return resourceStats
+}
+
+// finish stops monitoring and builds the result of a completed run
+func (s *Simulator) finish(r completedRun) models.SimulationResult {
+	ctx, manager, bidderPool := r.ctx, r.manager, r.bidders
+	resourceStats := s.complete(r)
+
+	// Wins were decided per auction; take back those over bidders' budgets
+	if dropped := manager.ReconcileBudgets(); dropped > 0 {
+		s.Logger.InfoContext(ctx, "reassigned over-budget wins", "wins", dropped)
+	}
+
+	// Build result with resource metrics
+	result := manager.AggregateResults()
+	result.Seed = r.seed
+	// Within a container, only its CPU quota is available
+	result.CPUCount = resourceStats.NumCPU
+	if resourceStats.EffectiveCPUs > 0 {
+		result.CPUCount = resourceStats.EffectiveCPUs
+	}
+	result.MemoryLimitMB = resourceStats.MemoryLimitMB
+	result.CPUUsed = resourceStats.GOMAXPROCS
+	result.InitialMemoryMB = resourceStats.InitialMemoryMB
+	result.FinalMemoryMB = resourceStats.FinalMemoryMB
+	result.PeakMemoryMB = resourceStats.PeakMemoryMB
+	result.AverageMemoryMB = resourceStats.AverageMemoryMB
+	result.PeakGoroutines = resourceStats.PeakGoroutines
+	result.NumGC = resourceStats.NumGC
+	result.TotalGCPause = resourceStats.TotalGCPause
+	result.LastGCPause = resourceStats.LastGCPause
+	result.BidderDropouts = bidderPool.Dropouts()
+	if s.Config.Report.BidderSeeds {
+		result.BidderSeeds = bidderPool.Seeds()
+	}
+
+	s.Logger.InfoContext(ctx, "simulation complete",
+		"duration", result.TotalDuration, "bids", result.TotalBids,
+		"successful", result.SuccessfulAuctions, "dropouts", result.BidderDropouts)
+
+	return result
+}
+
+// newRunID returns a random run ID for correlating log lines
+func newRunID() string {
+	var id [8]byte
+	rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
+
+// newSeed returns a time-derived seed for a run configured without one
+func newSeed() int64 {
+	if seed := time.Now().UnixNano(); seed != 0 {
+		return seed
+	}
+	// 0 means "no seed"; avoid it on the off chance
+	return 1
+}
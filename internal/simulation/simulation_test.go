@@ -0,0 +1,536 @@
+package simulation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vineetjain1712/auction-simulator/config"
+	"github.com/vineetjain1712/auction-simulator/internal/logging"
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+	"github.com/vineetjain1712/auction-simulator/internal/monitor"
+	"github.com/vineetjain1712/auction-simulator/internal/remote"
+)
+
+// smallConfig returns a configuration that runs quickly in tests
+func smallConfig() *config.Config {
+	cfg := config.DefaultConfig()
+	cfg.Auction.TotalAuctions = 3
+	cfg.Auction.AuctionTimeout = 200 * time.Millisecond
+	cfg.Bidder.TotalBidders = 10
+	cfg.Bidder.BidDelayMinMs = 10
+	cfg.Bidder.BidDelayMaxMs = 100
+	return cfg
+}
+
+func TestQuietRunWritesNothingToStdout(t *testing.T) {
+	cfg := smallConfig()
+	cfg.System.Quiet = true
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = writer
+
+	captured := make(chan []byte)
+	go func() {
+		var buf bytes.Buffer
+		io.Copy(&buf, reader)
+		captured <- buf.Bytes()
+	}()
+
+	simulator := NewSimulator(cfg)
+	var logs bytes.Buffer
+	simulator.Logger = logging.New(&logs, "info")
+	result := simulator.Run(context.Background())
+
+	os.Stdout = stdout
+	writer.Close()
+	output := <-captured
+
+	if len(output) != 0 {
+		t.Errorf("Expected no stdout output in quiet mode, got %q", output)
+	}
+	if logs.Len() == 0 {
+		t.Error("Expected progress to be routed through the logger")
+	}
+	if len(result.AuctionResults) != cfg.Auction.TotalAuctions {
+		t.Errorf("Expected %d auction results, got %d",
+			cfg.Auction.TotalAuctions, len(result.AuctionResults))
+	}
+}
+
+func TestRunSimulationWritesNothingToStdoutByDefault(t *testing.T) {
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = writer
+
+	captured := make(chan []byte)
+	go func() {
+		var buf bytes.Buffer
+		io.Copy(&buf, reader)
+		captured <- buf.Bytes()
+	}()
+
+	RunSimulation(context.Background(), smallConfig())
+
+	os.Stdout = stdout
+	writer.Close()
+	if output := <-captured; len(output) != 0 {
+		t.Errorf("Expected no stdout output from a library run, got %q", output)
+	}
+}
+
+func TestWarmupRunsCountAndReturnsMeasured(t *testing.T) {
+	calls := 0
+	run := func() models.SimulationResult {
+		calls++
+		// Tag each run so the returned one can be identified
+		return models.SimulationResult{TotalAuctions: calls}
+	}
+
+	result := Warmup(logging.Discard(), 3, 1, 0, run)
+
+	if calls != 4 {
+		t.Errorf("Expected 3 warmup runs plus 1 measured run, got %d runs", calls)
+	}
+	if result.TotalAuctions != 4 {
+		t.Errorf("Expected the final measured result, got run #%d", result.TotalAuctions)
+	}
+
+	// No warmup means a single measured run
+	calls = 0
+	Warmup(logging.Discard(), 0, 1, 0, run)
+	if calls != 1 {
+		t.Errorf("Expected a single run without warmup, got %d", calls)
+	}
+}
+
+func TestRunLogsCarryRunID(t *testing.T) {
+	cfg := smallConfig()
+	cfg.Bidder.BidProbability = 1.0 // Make sure bids are logged too
+
+	simulator := NewSimulator(cfg)
+	simulator.Output = io.Discard
+	var logs bytes.Buffer
+	simulator.Logger = logging.New(&logs, "debug")
+
+	ctx := logging.WithRunID(context.Background(), "run-42")
+	simulator.Run(ctx)
+
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	for _, msg := range []string{"auction started", "bid received", "auction closed"} {
+		if !strings.Contains(logs.String(), msg) {
+			t.Errorf("Expected a %q log line", msg)
+		}
+	}
+	for _, line := range lines {
+		if !strings.Contains(line, "run_id=run-42") {
+			t.Errorf("Log line without run ID: %s", line)
+		}
+	}
+}
+
+// outcome encodes the auction outcomes of a run without wall-clock times,
+// which differ between otherwise identical runs
+func outcome(t *testing.T, result models.SimulationResult) []byte {
+	t.Helper()
+
+	auctions := make([]models.AuctionResult, len(result.AuctionResults))
+	for i, r := range result.AuctionResults {
+		r.StartTime, r.EndTime, r.Duration = time.Time{}, time.Time{}, 0
+		r.CollectionDuration, r.DeterminationDuration = 0, 0
+		r.Bids = append([]models.Bid(nil), r.Bids...)
+		for j := range r.Bids {
+			r.Bids[j].Timestamp, r.Bids[j].ReceivedAt = time.Time{}, time.Time{}
+		}
+		if r.WinningBid != nil {
+			winner := *r.WinningBid
+			winner.Timestamp, winner.ReceivedAt = time.Time{}, time.Time{}
+			r.WinningBid = &winner
+		}
+		if r.RunnerUp != nil {
+			runnerUp := *r.RunnerUp
+			runnerUp.Timestamp, runnerUp.ReceivedAt = time.Time{}, time.Time{}
+			r.RunnerUp = &runnerUp
+		}
+		auctions[i] = r
+	}
+
+	data, err := json.Marshal(struct {
+		TotalBids, SuccessfulAuctions, FailedAuctions int
+		AuctionResults                                []models.AuctionResult
+	}{result.TotalBids, result.SuccessfulAuctions, result.FailedAuctions, auctions})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestDeterministicRunsAreIdentical(t *testing.T) {
+	cfg := smallConfig()
+	cfg.Auction.TotalAuctions = 10
+	cfg.Bidder.TotalBidders = 50
+	cfg.System.Seed = 42
+	cfg.System.Deterministic = true
+	cfg.System.Quiet = true
+
+	run := func() []byte {
+		simulator := NewSimulator(cfg)
+		simulator.Logger = logging.Discard()
+		return outcome(t, simulator.Run(context.Background()))
+	}
+
+	first, second := run(), run()
+	if !bytes.Equal(first, second) {
+		t.Errorf("Expected identical deterministic runs:\n%s\n%s", first, second)
+	}
+	if !bytes.Contains(first, []byte(`"WinningBid":{`)) {
+		t.Error("Expected the deterministic run to produce winners")
+	}
+}
+
+func TestRunCompactMatchesRun(t *testing.T) {
+	cfg := smallConfig()
+	cfg.Auction.TotalAuctions = 10
+	cfg.Bidder.TotalBidders = 50
+	cfg.System.Seed = 42
+	cfg.System.Deterministic = true
+
+	simulator := NewSimulator(cfg)
+	simulator.Logger = logging.Discard()
+	full := simulator.Run(context.Background())
+	compact := simulator.RunCompact(context.Background())
+
+	if len(compact.AuctionResults) != cfg.Auction.TotalAuctions {
+		t.Fatalf("Expected %d compact results, got %d", cfg.Auction.TotalAuctions, len(compact.AuctionResults))
+	}
+	if compact.TotalBids != full.TotalBids || compact.SuccessfulAuctions != full.SuccessfulAuctions {
+		t.Errorf("Expected the compact run to match the full one: %d bids and %d successful, got %d and %d",
+			full.TotalBids, full.SuccessfulAuctions, compact.TotalBids, compact.SuccessfulAuctions)
+	}
+	for i, result := range compact.AuctionResults {
+		if want := models.NewCompactResult(full.AuctionResults[i]); result.WinningAmount != want.WinningAmount {
+			t.Errorf("Auction #%d: expected winning amount %.2f, got %.2f",
+				result.AuctionID, want.WinningAmount, result.WinningAmount)
+		}
+	}
+}
+
+// lineWriter sends each write to its channel, dropping writes once it is
+// full
+type lineWriter chan string
+
+func (w lineWriter) Write(p []byte) (int, error) {
+	select {
+	case w <- string(p):
+	default:
+	}
+	return len(p), nil
+}
+
+func TestRunServesRemoteBids(t *testing.T) {
+	cfg := smallConfig()
+	cfg.Auction.TotalAuctions = 1
+	cfg.Bidder.BidProbability = 0 // Only the remote bidder bids
+	cfg.System.RemoteBidAddr = "127.0.0.1:0"
+
+	output := make(lineWriter, 100)
+	simulator := NewSimulator(cfg)
+	simulator.Output = output
+	simulator.Logger = logging.Discard()
+	done := make(chan models.SimulationResult)
+	go func() {
+		done <- simulator.Run(context.Background())
+	}()
+
+	const serving = "📡 Serving remote bids on "
+	line := <-output
+	for !strings.HasPrefix(line, serving) {
+		line = <-output
+	}
+	conn, err := net.Dial("tcp", strings.TrimSpace(strings.TrimPrefix(line, serving)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := remote.NewClient(conn)
+	defer client.Close()
+	reply, err := client.PlaceBid(remote.BidRequest{AuctionID: 1, BidderID: 1000, Amount: 1e6})
+	if err != nil || !reply.Accepted {
+		t.Fatalf("Expected the remote bid to be accepted, got %+v (%v)", reply, err)
+	}
+
+	result := <-done
+	winner := result.AuctionResults[0].WinningBid
+	if winner == nil || winner.BidderID != 1000 {
+		t.Errorf("Expected the remote bidder to win, got %+v", winner)
+	}
+}
+
+func TestEnglishAuctionsRaiseBids(t *testing.T) {
+	cfg := smallConfig()
+	cfg.Auction.TotalAuctions = 5
+	cfg.Auction.Type = config.English
+	cfg.Auction.MinimumBidIncrement = 2
+	cfg.Bidder.TotalBidders = 20
+	cfg.Bidder.BidProbability = 1.0
+	cfg.System.Seed = 7
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	simulator := NewSimulator(cfg)
+	simulator.Logger = logging.Discard()
+	result := simulator.Run(context.Background())
+
+	raised := false
+	for _, auction := range result.AuctionResults {
+		for i := 1; i < len(auction.Bids); i++ {
+			raised = true
+			if auction.Bids[i].Amount < auction.Bids[i-1].Amount+cfg.Auction.MinimumBidIncrement {
+				t.Errorf("Auction #%d: bid %.2f does not raise %.2f by the increment",
+					auction.AuctionID, auction.Bids[i].Amount, auction.Bids[i-1].Amount)
+			}
+		}
+		if n := len(auction.Bids); n > 0 && (auction.WinningBid == nil || *auction.WinningBid != auction.Bids[n-1]) {
+			t.Errorf("Auction #%d: expected the last raise to win, got %+v", auction.AuctionID, auction.WinningBid)
+		}
+	}
+	if !raised {
+		t.Error("Expected bidders to raise one another")
+	}
+}
+
+func TestMinBidMultiplierWarning(t *testing.T) {
+	for _, floor := range []bool{false, true} {
+		cfg := smallConfig()
+		cfg.Auction.TotalAuctions = 1
+		cfg.Bidder.MinBidMultiplier = 0.5
+		cfg.Bidder.FloorAtBasePrice = floor
+
+		simulator := NewSimulator(cfg)
+		simulator.Output = io.Discard
+		var logs bytes.Buffer
+		simulator.Logger = logging.New(&logs, "warn")
+		simulator.Run(context.Background())
+
+		if warned := strings.Contains(logs.String(), "min bid multiplier below 1"); warned == floor {
+			t.Errorf("With floor at base price %v: expected warning %v, got logs %q", floor, !floor, logs.String())
+		}
+	}
+}
+
+func TestRunAuctionsConfiguredItems(t *testing.T) {
+	cfg := smallConfig()
+	cfg.Auction.Items = []models.AuctionItem{
+		{ID: 7, Name: "Lamp", Category: models.CategoryFurniture, BasePrice: 50},
+		{ID: 9, Name: "Ring", Category: models.CategoryJewelry, BasePrice: 900},
+	}
+	cfg.Auction.TotalAuctions = len(cfg.Auction.Items)
+
+	simulator := NewSimulator(cfg)
+	simulator.Logger = logging.Discard()
+	result := simulator.Run(context.Background())
+
+	if len(result.AuctionResults) != len(cfg.Auction.Items) {
+		t.Fatalf("Expected %d auctions, got %d", len(cfg.Auction.Items), len(result.AuctionResults))
+	}
+	for _, r := range result.AuctionResults {
+		if want := cfg.Auction.Items[r.AuctionID-1].Name; r.Item.Name != want {
+			t.Errorf("Auction #%d: expected item %q, got %q", r.AuctionID, want, r.Item.Name)
+		}
+	}
+}
+
+func TestPublicReserveFailsLessThanHidden(t *testing.T) {
+	run := func(public bool) models.SimulationResult {
+		cfg := smallConfig()
+		cfg.Auction.TotalAuctions = 40
+		cfg.Bidder.TotalBidders = 20
+		cfg.Auction.ReserveMultiplier = 1.8
+		cfg.Auction.PublicReserve = public
+		cfg.System.Seed = 7
+		cfg.System.Deterministic = true
+		cfg.System.Quiet = true
+
+		simulator := NewSimulator(cfg)
+		simulator.Logger = logging.Discard()
+		return simulator.Run(context.Background())
+	}
+
+	hidden, public := run(false), run(true)
+
+	if public.FailedAuctions >= hidden.FailedAuctions {
+		t.Errorf("Expected fewer failed auctions with a public reserve: public=%d hidden=%d",
+			public.FailedAuctions, hidden.FailedAuctions)
+	}
+	for _, result := range public.AuctionResults {
+		for _, bid := range result.Bids {
+			if bid.Amount < result.Item.BasePrice*1.8 {
+				t.Fatalf("Auction #%d: bid %.2f below public reserve %.2f",
+					result.AuctionID, bid.Amount, result.Item.BasePrice*1.8)
+			}
+		}
+	}
+}
+
+func TestHotItemsAttractMoreBids(t *testing.T) {
+	cfg := smallConfig()
+	cfg.Auction.TotalAuctions = 50
+	cfg.Auction.HotItemFraction = 0.2
+	cfg.Bidder.TotalBidders = 50
+	cfg.Bidder.BidProbability = 0.1
+	cfg.Bidder.HotBidMultiplier = 5
+	cfg.System.Seed = 11
+	cfg.System.Deterministic = true
+	cfg.System.Quiet = true
+
+	simulator := NewSimulator(cfg)
+	simulator.Logger = logging.Discard()
+	result := simulator.Run(context.Background())
+
+	var hotBids, hotItems, normalBids, normalItems int
+	for _, r := range result.AuctionResults {
+		if r.Item.Hot {
+			hotBids += r.TotalBids
+			hotItems++
+		} else {
+			normalBids += r.TotalBids
+			normalItems++
+		}
+	}
+	if hotItems == 0 || normalItems == 0 {
+		t.Fatalf("Expected both hot and normal items, got %d hot and %d normal", hotItems, normalItems)
+	}
+
+	hotAvg := float64(hotBids) / float64(hotItems)
+	normalAvg := float64(normalBids) / float64(normalItems)
+	if hotAvg < 2*normalAvg {
+		t.Errorf("Expected hot items to average at least twice the bids of normal items, got %.1f vs %.1f",
+			hotAvg, normalAvg)
+	}
+}
+
+func TestSimulatedTimeRunsHourLongAuctionsQuickly(t *testing.T) {
+	cfg := smallConfig()
+	cfg.Auction.TotalAuctions = 5
+	cfg.Auction.AuctionTimeout = time.Hour
+	cfg.Bidder.BidDelayMinMs = int(time.Minute / time.Millisecond)
+	cfg.Bidder.BidDelayMaxMs = int(50 * time.Minute / time.Millisecond)
+	cfg.Bidder.BidProbability = 1
+	cfg.System.SimulatedTime = true
+	cfg.System.Quiet = true
+
+	simulator := NewSimulator(cfg)
+	simulator.Logger = logging.Discard()
+	start := time.Now()
+	result := simulator.Run(context.Background())
+
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("Expected hour-long auctions to run quickly on simulated time, took %v", elapsed)
+	}
+	// Every bidder bids on every auction, well before its deadline
+	if expected := cfg.Auction.TotalAuctions * cfg.Bidder.TotalBidders; result.TotalBids != expected {
+		t.Fatalf("Expected %d bids on simulated time, got %d", expected, result.TotalBids)
+	}
+	for _, r := range result.AuctionResults {
+		// Every auction times out at exactly its simulated deadline, after
+		// all bids (sent within 50 minutes) have arrived
+		if r.Duration != time.Hour {
+			t.Errorf("Auction #%d: expected a simulated duration of 1h, got %v", r.AuctionID, r.Duration)
+		}
+		if r.LateBids != 0 {
+			t.Errorf("Auction #%d: expected no late bids, got %d", r.AuctionID, r.LateBids)
+		}
+	}
+	if result.TotalDuration < time.Hour {
+		t.Errorf("Expected a simulated total duration of at least 1h, got %v", result.TotalDuration)
+	}
+}
+
+func TestTimeBasedSeedReproducesRun(t *testing.T) {
+	run := func(seed int64) models.SimulationResult {
+		cfg := smallConfig()
+		cfg.Auction.TotalAuctions = 10
+		cfg.Bidder.TotalBidders = 50
+		cfg.System.Seed = seed
+		cfg.System.Deterministic = true
+		cfg.System.Quiet = true
+
+		simulator := NewSimulator(cfg)
+		simulator.Logger = logging.Discard()
+		return simulator.Run(context.Background())
+	}
+
+	first := run(0)
+	if first.Seed == 0 {
+		t.Fatal("Expected a time-based run to report the seed it used")
+	}
+
+	rerun := run(first.Seed)
+	if rerun.Seed != first.Seed {
+		t.Errorf("Expected the rerun to use seed %d, got %d", first.Seed, rerun.Seed)
+	}
+	if a, b := outcome(t, first), outcome(t, rerun); !bytes.Equal(a, b) {
+		t.Errorf("Expected rerunning with the reported seed to reproduce the run:\n%s\n%s", a, b)
+	}
+}
+
+func TestInjectedMonitorStatsReachResult(t *testing.T) {
+	cfg := smallConfig()
+	cfg.System.Quiet = true
+	fake := &monitor.Fake{Stats: monitor.ResourceStats{
+		NumCPU:          64,
+		EffectiveCPUs:   16,
+		GOMAXPROCS:      8,
+		MemoryLimitMB:   2048,
+		InitialMemoryMB: 10,
+		FinalMemoryMB:   20,
+		PeakMemoryMB:    123.5,
+		AverageMemoryMB: 15,
+		PeakGoroutines:  4242,
+		NumGC:           7,
+		TotalGCPause:    3 * time.Millisecond,
+		LastGCPause:     time.Millisecond,
+	}}
+
+	simulator := NewSimulator(cfg)
+	simulator.Logger = logging.Discard()
+	simulator.Monitor = fake
+	result := simulator.Run(context.Background())
+
+	if !fake.Started || !fake.Stopped {
+		t.Errorf("Expected the monitor to be started and stopped, got started=%v stopped=%v", fake.Started, fake.Stopped)
+	}
+
+	stats := fake.Stats
+	fields := map[string][2]any{
+		"CPUCount":        {result.CPUCount, stats.EffectiveCPUs},
+		"MemoryLimitMB":   {result.MemoryLimitMB, stats.MemoryLimitMB},
+		"CPUUsed":         {result.CPUUsed, stats.GOMAXPROCS},
+		"InitialMemoryMB": {result.InitialMemoryMB, stats.InitialMemoryMB},
+		"FinalMemoryMB":   {result.FinalMemoryMB, stats.FinalMemoryMB},
+		"PeakMemoryMB":    {result.PeakMemoryMB, stats.PeakMemoryMB},
+		"AverageMemoryMB": {result.AverageMemoryMB, stats.AverageMemoryMB},
+		"PeakGoroutines":  {result.PeakGoroutines, stats.PeakGoroutines},
+		"NumGC":           {result.NumGC, stats.NumGC},
+		"TotalGCPause":    {result.TotalGCPause, stats.TotalGCPause},
+		"LastGCPause":     {result.LastGCPause, stats.LastGCPause},
+	}
+	for name, pair := range fields {
+		if pair[0] != pair[1] {
+			t.Errorf("%s: expected %v from the monitor, got %v", name, pair[1], pair[0])
+		}
+	}
+}
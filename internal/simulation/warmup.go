@@ -0,0 +1,62 @@
+package simulation
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/vineetjain1712/auction-simulator/config"
+	"github.com/vineetjain1712/auction-simulator/internal/logging"
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+	"github.com/vineetjain1712/auction-simulator/internal/monitor"
+)
+
+// Warmup calls run warmups times and discards the results, re-standardizes
+// resources so the GC starts clean, logging to logger, then returns the
+// result of one final measured run
+func Warmup[R any](logger *slog.Logger, warmups, maxCPUs, gcPercent int, run func() R) R {
+	if warmups <= 0 {
+		return run()
+	}
+
+	for i := 0; i < warmups; i++ {
+		run()
+	}
+
+	// Start the measured run from a clean, steady state
+	monitor.StandardizeResources(logger, maxCPUs, gcPercent)
+
+	return run()
+}
+
+// RunWithWarmup runs warmups quiet throwaway simulations of cfg before the
+// measured one, so resource statistics reflect a steady state
+// Only the measured run reports its progress, to output.
+func RunWithWarmup(ctx context.Context, cfg *config.Config, warmups int, output io.Writer) models.SimulationResult {
+	runs := 0
+	logger := logging.New(os.Stderr, cfg.System.LogLevel)
+	return Warmup(logger, warmups, cfg.System.MaxCPUCores, cfg.System.GCPercent, func() models.SimulationResult {
+		runs++
+		simulator := NewSimulator(cfg)
+		if runs > warmups {
+			simulator.Output = output
+		}
+		return simulator.Run(ctx)
+	})
+}
+
+// RunCompactWithWarmup is RunWithWarmup for a compact run (see
+// Simulator.RunCompact)
+func RunCompactWithWarmup(ctx context.Context, cfg *config.Config, warmups int, output io.Writer) models.CompactSimulationResult {
+	runs := 0
+	logger := logging.New(os.Stderr, cfg.System.LogLevel)
+	return Warmup(logger, warmups, cfg.System.MaxCPUCores, cfg.System.GCPercent, func() models.CompactSimulationResult {
+		runs++
+		simulator := NewSimulator(cfg)
+		if runs > warmups {
+			simulator.Output = output
+		}
+		return simulator.RunCompact(ctx)
+	})
+}
@@ -6,7 +6,10 @@ import (
 	"fmt"
 	"math"
 	"sort"
+	"time"
 
+	"github.com/vineetjain1712/auction-simulator/config"
+	"github.com/vineetjain1712/auction-simulator/internal/ascii"
 	"github.com/vineetjain1712/auction-simulator/internal/models"
 )
 
@@ -19,6 +22,8 @@ type Statistics struct {
 	MaxBids     int
 	MedianBids  float64
 	StdDevBids  float64
+	// Auctions by bid count (batch analysis only)
+	BidCountHistogram []HistogramBucket
 
 	// Amount Statistics
 	TotalRevenue     float64
@@ -26,47 +31,141 @@ type Statistics struct {
 	MinWinAmount     float64
 	MaxWinAmount     float64
 	MedianWinAmount  float64
+	// Won auctions by winning amount (batch analysis only)
+	WinAmountHistogram []HistogramBucket
+
+	// Premium Statistics
+	// How far above base price winners paid, in percent:
+	// (WinningBid.Amount/Item.BasePrice - 1) * 100 over won auctions
+	AverageWinningPremiumPct float64
+	MinWinningPremiumPct     float64
+	MaxWinningPremiumPct     float64
+
+	// Competition Statistics
+	// Won auctions with a runner-up, split by the winning margin over the
+	// runner-up's bid: close below the analyzer's CloseMarginPct, runaway
+	// at or above it
+	CloseAuctions   int
+	RunawayAuctions int
+
+	// Duration Statistics
+	MinDuration    time.Duration
+	MedianDuration time.Duration
+	P95Duration    time.Duration
+	MaxDuration    time.Duration
 
 	// Bidder Statistics
-	UniqueBidders        int
-	UniqueWinners        int
-	MostActiveBidder     int
-	MostSuccessfulBidder int
+	UniqueBidders int
+	// Mean number of distinct bidders per auction; below AverageBids when
+	// bidders place several bids on the same auction
+	AverageBiddersPerAuction float64
+	UniqueWinners            int
+	MostActiveBidder         int
+	MostSuccessfulBidder     int
+	TopBiddersByBids         []BidderRank   // Top bidders by bids placed
+	TopBiddersByWins         []BidderRank   // Top bidders by auctions won
+	Segments                 []SegmentStats // Per-segment activity, by segment name (empty if no segments)
+
+	// Attribute Statistics
+	// Average winning amount per value of Category, Condition and Rarity,
+	// and the correlation of Rating and YearMade with the winning amount
+	// (0 without enough won auctions to tell)
+	WinAmountByAttribute []AttributeGroup
+	RatingCorrelation    float64
+	YearMadeCorrelation  float64
+
+	// Allocation Statistics
+	// Fraction of won auctions where the winner valued the item highest
+	// among the bidders (0 when no bids carry valuations)
+	AllocativeEfficiency float64
 
 	// Performance Statistics
 	BidsPerSecond     float64
 	AuctionsPerSecond float64
 
 	// Success Metrics
+	TotalAuctions   int // Auctions analyzed; 0 for an empty run
 	SuccessRate     float64
 	AuctionsFailed  int
 	AuctionsSuccess int
+
+	// SkippedNonFinite counts winning and runner-up amounts left out of
+	// every statistic because they were NaN or infinite
+	SkippedNonFinite int
+}
+
+// BidderRank is a bidder's position in a top-bidders ranking
+type BidderRank struct {
+	BidderID int
+	Count    int // Bids placed or auctions won, depending on the ranking
+}
+
+// SegmentStats aggregates the activity of one bidder segment
+type SegmentStats struct {
+	Segment     string
+	BidsPlaced  int
+	AuctionsWon int
+	TotalSpent  float64 // Sum of the segment's winning amounts
 }
 
+// topBiddersCount is how many bidders each ranking keeps
+const topBiddersCount = 5
+
 // Analyzer analyzes simulation results
-type Analyzer struct{}
+type Analyzer struct {
+	// Currency formats amounts in reports
+	Currency config.Currency
+	// CloseMarginPct separates close from runaway auctions (see
+	// Statistics.CloseAuctions)
+	CloseMarginPct float64
+	// ASCII renders reports in plain ASCII (see package ascii)
+	ASCII bool
+}
 
-// NewAnalyzer creates a new statistics analyzer
+// NewAnalyzer creates a new statistics analyzer reporting in the default currency
 func NewAnalyzer() *Analyzer {
-	return &Analyzer{}
+	return &Analyzer{
+		Currency:       config.DefaultCurrency,
+		CloseMarginPct: config.DefaultCloseMarginPct,
+	}
 }
 
 // Analyze performs comprehensive analysis on simulation results
 func (a *Analyzer) Analyze(result models.SimulationResult) Statistics {
 	stats := Statistics{
 		TotalBids:       result.TotalBids,
+		TotalAuctions:   max(result.TotalAuctions, len(result.AuctionResults)),
 		AuctionsSuccess: result.SuccessfulAuctions,
 		AuctionsFailed:  result.FailedAuctions,
 	}
 
+	// Leave out NaN and infinite amounts, so they can't poison the rest
+	results, skipped := finiteResults(result.AuctionResults)
+	stats.SkippedNonFinite = skipped
+
 	// Calculate bid statistics
-	a.analyzeBidCounts(result.AuctionResults, &stats)
+	a.analyzeBidCounts(results, &stats)
 
 	// Calculate amount statistics
-	a.analyzeWinningAmounts(result.AuctionResults, &stats)
+	a.analyzeWinningAmounts(results, &stats)
+
+	// Calculate duration statistics
+	a.analyzeDurations(results, &stats)
 
 	// Calculate bidder statistics
-	a.analyzeBidders(result.AuctionResults, &stats)
+	a.analyzeBidders(results, &stats)
+
+	// Calculate winning premiums over base price
+	a.analyzePremiums(results, &stats)
+
+	// Calculate how contested the auctions were
+	a.analyzeMargins(results, &stats)
+
+	// Calculate how item attributes relate to winning amounts
+	a.analyzeAttributes(results, &stats)
+
+	// Calculate allocative efficiency
+	a.analyzeAllocation(results, &stats)
 
 	// Calculate performance metrics
 	a.analyzePerformance(result, &stats)
@@ -79,6 +178,35 @@ func (a *Analyzer) Analyze(result models.SimulationResult) Statistics {
 	return stats
 }
 
+// AnalyzeCompact analyzes a compact run: bid count, winning amount,
+// duration and performance statistics, without the per-bid statistics
+// that need full results (see StreamingAnalyzer.AddCompact)
+func (a *Analyzer) AnalyzeCompact(result models.CompactSimulationResult) Statistics {
+	streaming := NewStreamingAnalyzer()
+	for _, auctionResult := range result.AuctionResults {
+		streaming.AddCompact(auctionResult)
+	}
+
+	stats := streaming.Result()
+	stats.TotalBids = result.TotalBids
+	stats.TotalAuctions = max(result.TotalAuctions, len(result.AuctionResults))
+	stats.AuctionsSuccess = result.SuccessfulAuctions
+	stats.AuctionsFailed = result.FailedAuctions
+
+	a.analyzePerformance(models.SimulationResult{
+		TotalAuctions: result.TotalAuctions,
+		TotalDuration: result.TotalDuration,
+		TotalBids:     result.TotalBids,
+	}, &stats)
+
+	stats.SuccessRate = 0
+	if result.TotalAuctions > 0 {
+		stats.SuccessRate = float64(stats.AuctionsSuccess) / float64(result.TotalAuctions) * 100
+	}
+
+	return stats
+}
+
 // analyzeBidCounts calculates statistics about bid counts
 func (a *Analyzer) analyzeBidCounts(results []models.AuctionResult, stats *Statistics) {
 	if len(results) == 0 {
@@ -113,6 +241,7 @@ func (a *Analyzer) analyzeBidCounts(results []models.AuctionResult, stats *Stati
 	} else {
 		stats.MedianBids = float64(bidCounts[mid])
 	}
+	stats.BidCountHistogram = bidCountHistogram(bidCounts)
 
 	// Standard Deviation
 	variance := 0.0
@@ -125,13 +254,16 @@ func (a *Analyzer) analyzeBidCounts(results []models.AuctionResult, stats *Stati
 }
 
 // analyzeWinningAmounts calculates statistics about winning bid amounts
+// A proportional-share auction's amount is what all its share holders pay
+// (see models.Revenue).
 func (a *Analyzer) analyzeWinningAmounts(results []models.AuctionResult, stats *Statistics) {
 	amounts := make([]float64, 0)
 
 	for _, result := range results {
 		if result.WinningBid != nil {
-			amounts = append(amounts, result.WinningBid.Amount)
-			stats.TotalRevenue += result.WinningBid.Amount
+			amount := models.Revenue(result)
+			amounts = append(amounts, amount)
+			stats.TotalRevenue += amount
 		}
 	}
 
@@ -163,32 +295,266 @@ func (a *Analyzer) analyzeWinningAmounts(results []models.AuctionResult, stats *
 	} else {
 		stats.MedianWinAmount = amounts[mid]
 	}
+	stats.WinAmountHistogram = amountHistogram(amounts)
+}
+
+// analyzeDurations calculates statistics about auction durations
+// Durations cluster near the timeout; the tail shows scheduling overruns
+func (a *Analyzer) analyzeDurations(results []models.AuctionResult, stats *Statistics) {
+	if len(results) == 0 {
+		return
+	}
+
+	durations := make([]time.Duration, len(results))
+	for i, result := range results {
+		durations[i] = result.Duration
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	stats.MinDuration = durations[0]
+	stats.MaxDuration = durations[len(durations)-1]
+	stats.P95Duration = percentile(durations, 95)
+
+	// Median
+	mid := len(durations) / 2
+	if len(durations)%2 == 0 {
+		stats.MedianDuration = (durations[mid-1] + durations[mid]) / 2
+	} else {
+		stats.MedianDuration = durations[mid]
+	}
+}
+
+// percentile returns the p-th percentile of sorted durations using the
+// nearest-rank method
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100 // ceil(p/100 * n)
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
 }
 
 // analyzeBidders calculates statistics about bidder activity
 func (a *Analyzer) analyzeBidders(results []models.AuctionResult, stats *Statistics) {
-	// bidderBids := make(map[int]int) // bidderID -> total bids
-	bidderWins := make(map[int]int) // bidderID -> total wins
-
+	tally := newBidderTally()
 	for _, result := range results {
-		// Count wins
-		if result.WinningBid != nil {
-			bidderWins[result.WinningBid.BidderID]++
+		tally.add(result)
+	}
+	tally.apply(stats)
+}
+
+// bidderTally accumulates per-bidder and per-segment activity one auction
+// at a time; its size is bounded by the number of bidders, not auctions
+type bidderTally struct {
+	bids     map[int]int // bidderID -> total bids
+	wins     map[int]int // bidderID -> total wins
+	segments map[string]*SegmentStats
+
+	auctions       int // Auctions added
+	auctionBidders int // Sum over auctions of their distinct bidders
+}
+
+func newBidderTally() *bidderTally {
+	return &bidderTally{
+		bids:     make(map[int]int),
+		wins:     make(map[int]int),
+		segments: make(map[string]*SegmentStats),
+	}
+}
+
+// segment returns the stats of the named segment, creating them if needed
+func (t *bidderTally) segment(name string) *SegmentStats {
+	if t.segments[name] == nil {
+		t.segments[name] = &SegmentStats{Segment: name}
+	}
+	return t.segments[name]
+}
+
+// add counts the bids and win of one auction
+func (t *bidderTally) add(result models.AuctionResult) {
+	// Count bids, and the distinct bidders behind them
+	distinct := make(map[int]struct{}, len(result.Bids))
+	for _, bid := range result.Bids {
+		t.bids[bid.BidderID]++
+		distinct[bid.BidderID] = struct{}{}
+		if bid.Segment != "" {
+			t.segment(bid.Segment).BidsPlaced++
 		}
+	}
+	t.auctions++
+	t.auctionBidders += len(distinct)
+
+	// Count wins
+	if result.WinningBid != nil {
+		t.wins[result.WinningBid.BidderID]++
+		if result.WinningBid.Segment != "" {
+			s := t.segment(result.WinningBid.Segment)
+			s.AuctionsWon++
+			s.TotalSpent += result.WinningBid.Amount
+		}
+	}
+}
 
-		// Count bids (we'd need to track this in auction, for now approximate)
-		// This is a simplified version
+// apply sets the bidder statistics from the tally
+func (t *bidderTally) apply(stats *Statistics) {
+	stats.UniqueBidders = len(t.bids)
+	stats.UniqueWinners = len(t.wins)
+	if t.auctions > 0 {
+		stats.AverageBiddersPerAuction = float64(t.auctionBidders) / float64(t.auctions)
 	}
 
-	stats.UniqueWinners = len(bidderWins)
+	stats.TopBiddersByBids = rankBidders(t.bids, topBiddersCount)
+	stats.TopBiddersByWins = rankBidders(t.wins, topBiddersCount)
 
-	// Find most successful bidder
-	maxWins := 0
-	for bidderID, wins := range bidderWins {
-		if wins > maxWins {
-			maxWins = wins
-			stats.MostSuccessfulBidder = bidderID
+	if len(stats.TopBiddersByBids) > 0 {
+		stats.MostActiveBidder = stats.TopBiddersByBids[0].BidderID
+	}
+	if len(stats.TopBiddersByWins) > 0 {
+		stats.MostSuccessfulBidder = stats.TopBiddersByWins[0].BidderID
+	}
+
+	stats.Segments = nil
+	for _, s := range t.segments {
+		stats.Segments = append(stats.Segments, *s)
+	}
+	sort.Slice(stats.Segments, func(i, j int) bool {
+		return stats.Segments[i].Segment < stats.Segments[j].Segment
+	})
+}
+
+// rankBidders returns the top n bidders by count, highest first
+// Ties are broken by lower bidder ID so the ranking is deterministic
+func rankBidders(counts map[int]int, n int) []BidderRank {
+	ranking := make([]BidderRank, 0, len(counts))
+	for bidderID, count := range counts {
+		ranking = append(ranking, BidderRank{BidderID: bidderID, Count: count})
+	}
+
+	sort.Slice(ranking, func(i, j int) bool {
+		if ranking[i].Count == ranking[j].Count {
+			return ranking[i].BidderID < ranking[j].BidderID
 		}
+		return ranking[i].Count > ranking[j].Count
+	})
+
+	if len(ranking) > n {
+		ranking = ranking[:n]
+	}
+	return ranking
+}
+
+// analyzePremiums calculates how far above base price winners paid
+func (a *Analyzer) analyzePremiums(results []models.AuctionResult, stats *Statistics) {
+	var tally premiumTally
+	for _, result := range results {
+		tally.add(result)
+	}
+	tally.apply(stats)
+}
+
+// premiumTally accumulates winning premiums one auction at a time
+// Only won auctions with a positive base price are considered
+type premiumTally struct {
+	premiums runningStats
+}
+
+// add counts one auction
+func (t *premiumTally) add(result models.AuctionResult) {
+	if result.WinningBid == nil || result.Item.BasePrice <= 0 {
+		return
+	}
+	t.premiums.add((result.WinningBid.Amount/result.Item.BasePrice - 1) * 100)
+}
+
+// apply sets the premium statistics from the tally
+func (t *premiumTally) apply(stats *Statistics) {
+	if t.premiums.n > 0 {
+		stats.AverageWinningPremiumPct = t.premiums.mean
+		stats.MinWinningPremiumPct = t.premiums.min
+		stats.MaxWinningPremiumPct = t.premiums.max
+	}
+}
+
+// analyzeMargins counts close and runaway auctions
+func (a *Analyzer) analyzeMargins(results []models.AuctionResult, stats *Statistics) {
+	tally := marginTally{closePct: a.CloseMarginPct}
+	for _, result := range results {
+		tally.add(result)
+	}
+	tally.apply(stats)
+}
+
+// marginTally accumulates close and runaway auctions, for both the batch
+// and the streaming analyzer
+type marginTally struct {
+	closePct float64
+	closed   int
+	runaway  int
+}
+
+// add counts one auction by its winning margin, in percent over the
+// runner-up's bid
+func (t *marginTally) add(result models.AuctionResult) {
+	if result.WinningBid == nil || result.RunnerUp == nil || result.RunnerUp.Amount <= 0 {
+		return
+	}
+	margin := (result.WinningBid.Amount/result.RunnerUp.Amount - 1) * 100
+	if margin < t.closePct {
+		t.closed++
+	} else {
+		t.runaway++
+	}
+}
+
+// apply sets the close and runaway counts from the tally
+func (t *marginTally) apply(stats *Statistics) {
+	stats.CloseAuctions = t.closed
+	stats.RunawayAuctions = t.runaway
+}
+
+// analyzeAllocation calculates how often the item went to the bidder who
+// valued it most
+func (a *Analyzer) analyzeAllocation(results []models.AuctionResult, stats *Statistics) {
+	var tally allocationTally
+	for _, result := range results {
+		tally.add(result)
+	}
+	tally.apply(stats)
+}
+
+// allocationTally counts efficient allocations one auction at a time
+// Only won auctions whose bids carry valuations are considered
+type allocationTally struct {
+	considered int
+	efficient  int
+}
+
+// add counts one auction
+func (t *allocationTally) add(result models.AuctionResult) {
+	if result.WinningBid == nil {
+		return
+	}
+
+	highest := 0.0
+	for _, bid := range result.Bids {
+		if bid.Valuation > highest {
+			highest = bid.Valuation
+		}
+	}
+	if highest == 0 {
+		return
+	}
+
+	t.considered++
+	if result.WinningBid.Valuation >= highest {
+		t.efficient++
+	}
+}
+
+// apply sets the allocative efficiency from the tally
+func (t *allocationTally) apply(stats *Statistics) {
+	if t.considered > 0 {
+		stats.AllocativeEfficiency = float64(t.efficient) / float64(t.considered)
 	}
 }
 
@@ -202,10 +568,34 @@ func (a *Analyzer) analyzePerformance(result models.SimulationResult, stats *Sta
 	}
 }
 
+// empty reports whether stats cover no auctions at all
+// Statistics assembled by hand may leave TotalAuctions unset, so bids and
+// outcomes count too.
+func (s Statistics) empty() bool {
+	return s.TotalAuctions == 0 && s.TotalBids == 0 && s.AuctionsSuccess+s.AuctionsFailed == 0
+}
+
 // FormatReport generates a formatted text report
+// With ASCII set it is rendered in plain ASCII.
 func (a *Analyzer) FormatReport(stats Statistics) string {
+	report := a.formatReport(stats)
+	if a.ASCII {
+		return ascii.String(report)
+	}
+	return report
+}
+
+// formatReport generates the report of FormatReport in Unicode
+func (a *Analyzer) formatReport(stats Statistics) string {
 	report := "\n📈 DETAILED STATISTICS\n"
 	report += "════════════════════════════════════════════════════════\n\n"
+	if stats.SkippedNonFinite > 0 {
+		report += fmt.Sprintf("⚠️  Skipped %d NaN or infinite amount(s)\n\n", stats.SkippedNonFinite)
+	}
+	if stats.empty() {
+		report += "   No auctions to analyze\n\n"
+		return report
+	}
 
 	// Bid Statistics
 	report += "💰 Bid Statistics:\n"
@@ -218,26 +608,90 @@ func (a *Analyzer) FormatReport(stats Statistics) string {
 	// Amount Statistics
 	if stats.TotalRevenue > 0 {
 		report += "💵 Revenue Statistics:\n"
-		report += fmt.Sprintf("   ├─ Total Revenue: $%.2f\n", stats.TotalRevenue)
-		report += fmt.Sprintf("   ├─ Average Win: $%.2f\n", stats.AverageWinAmount)
-		report += fmt.Sprintf("   ├─ Median Win: $%.2f\n", stats.MedianWinAmount)
-		report += fmt.Sprintf("   └─ Min/Max: $%.2f / $%.2f\n\n", stats.MinWinAmount, stats.MaxWinAmount)
+		money := a.Currency.Format
+		report += fmt.Sprintf("   ├─ Total Revenue: %s\n", money(stats.TotalRevenue))
+		report += fmt.Sprintf("   ├─ Average Win: %s\n", money(stats.AverageWinAmount))
+		report += fmt.Sprintf("   ├─ Median Win: %s\n", money(stats.MedianWinAmount))
+		report += fmt.Sprintf("   ├─ Min/Max: %s / %s\n", money(stats.MinWinAmount), money(stats.MaxWinAmount))
+		report += fmt.Sprintf("   ├─ Premium over Base: %.1f%% avg (%.1f%% / %.1f%%)\n",
+			stats.AverageWinningPremiumPct, stats.MinWinningPremiumPct, stats.MaxWinningPremiumPct)
+		report += fmt.Sprintf("   └─ Competition: %d close / %d runaway (margin < %.1f%%)\n\n",
+			stats.CloseAuctions, stats.RunawayAuctions, a.CloseMarginPct)
+	}
+
+	// Duration Statistics
+	if stats.MaxDuration > 0 {
+		report += "⏱️  Duration Statistics:\n"
+		report += fmt.Sprintf("   ├─ Min: %v\n", stats.MinDuration.Round(time.Millisecond))
+		report += fmt.Sprintf("   ├─ Median: %v\n", stats.MedianDuration.Round(time.Millisecond))
+		report += fmt.Sprintf("   ├─ P95: %v\n", stats.P95Duration.Round(time.Millisecond))
+		report += fmt.Sprintf("   └─ Max: %v\n\n", stats.MaxDuration.Round(time.Millisecond))
 	}
 
 	// Bidder Statistics
 	report += "👥 Bidder Statistics:\n"
+	report += fmt.Sprintf("   ├─ Unique Bidders: %d\n", stats.UniqueBidders)
 	report += fmt.Sprintf("   ├─ Unique Winners: %d\n", stats.UniqueWinners)
+	report += fmt.Sprintf("   ├─ Average Bidders per Auction: %.1f\n", stats.AverageBiddersPerAuction)
+	if stats.MostActiveBidder > 0 {
+		report += fmt.Sprintf("   ├─ Most Active: #%d\n", stats.MostActiveBidder)
+	}
 	if stats.MostSuccessfulBidder > 0 {
 		report += fmt.Sprintf("   └─ Top Bidder: #%d\n\n", stats.MostSuccessfulBidder)
 	} else {
 		report += "   └─ No winners\n\n"
 	}
 
+	// Top bidders, by bids placed and by auctions won, side by side
+	if len(stats.TopBiddersByBids) > 0 {
+		report += "🏅 Top Bidders:\n"
+		report += fmt.Sprintf("   %-22s %s\n", "By Bids Placed", "By Auctions Won")
+		for i := 0; i < len(stats.TopBiddersByBids) || i < len(stats.TopBiddersByWins); i++ {
+			left, right := "", ""
+			if i < len(stats.TopBiddersByBids) {
+				rank := stats.TopBiddersByBids[i]
+				left = fmt.Sprintf("%d. #%-4d %4d bids", i+1, rank.BidderID, rank.Count)
+			}
+			if i < len(stats.TopBiddersByWins) {
+				rank := stats.TopBiddersByWins[i]
+				right = fmt.Sprintf("%d. #%-4d %4d wins", i+1, rank.BidderID, rank.Count)
+			}
+			report += fmt.Sprintf("   %-22s %s\n", left, right)
+		}
+		report += "\n"
+	}
+
+	// Attribute Statistics
+	if len(stats.WinAmountByAttribute) > 0 {
+		report += "🔗 Winning Amount by Attribute:\n"
+		for _, g := range stats.WinAmountByAttribute {
+			report += fmt.Sprintf("   ├─ %-10s %-14s %4d wins, %s avg\n",
+				g.Attribute+":", g.Value, g.Auctions, a.Currency.Format(g.AverageWinAmount))
+		}
+		report += fmt.Sprintf("   ├─ Rating correlation: %+.2f\n", stats.RatingCorrelation)
+		report += fmt.Sprintf("   └─ Year made correlation: %+.2f\n\n", stats.YearMadeCorrelation)
+	}
+
+	// Segment Statistics
+	if len(stats.Segments) > 0 {
+		report += "🏷️  Segment Statistics:\n"
+		for i, s := range stats.Segments {
+			branch := "├─"
+			if i == len(stats.Segments)-1 {
+				branch = "└─"
+			}
+			report += fmt.Sprintf("   %s %-12s %5d bids, %4d wins, %s spent\n",
+				branch, s.Segment+":", s.BidsPlaced, s.AuctionsWon, a.Currency.Format(s.TotalSpent))
+		}
+		report += "\n"
+	}
+
 	// Performance Metrics
 	report += "⚡ Performance Metrics:\n"
 	report += fmt.Sprintf("   ├─ Bids/Second: %.1f\n", stats.BidsPerSecond)
 	report += fmt.Sprintf("   ├─ Auctions/Second: %.2f\n", stats.AuctionsPerSecond)
-	report += fmt.Sprintf("   └─ Success Rate: %.1f%%\n\n", stats.SuccessRate)
+	report += fmt.Sprintf("   ├─ Success Rate: %.1f%%\n", stats.SuccessRate)
+	report += fmt.Sprintf("   └─ Allocative Efficiency: %.1f%%\n\n", stats.AllocativeEfficiency*100)
 
 	return report
 }
@@ -0,0 +1,461 @@
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vineetjain1712/auction-simulator/config"
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+// auctionWithBids builds an auction result from the given bids
+// The highest amount wins
+func auctionWithBids(id int, bids ...models.Bid) models.AuctionResult {
+	result := models.AuctionResult{
+		AuctionID: id,
+		Item:      models.AuctionItem{ID: id, BasePrice: 100.0},
+		Bids:      bids,
+		TotalBids: len(bids),
+		Status:    "no_bids",
+	}
+
+	for i := range bids {
+		bids[i].AuctionID = id
+		if result.WinningBid == nil || bids[i].Amount > result.WinningBid.Amount {
+			winner := bids[i]
+			result.WinningBid = &winner
+			result.Status = "completed"
+		}
+	}
+	return result
+}
+
+// runnerUp returns the highest bid of result from another bidder than the
+// winner, or nil
+func runnerUp(result models.AuctionResult) *models.Bid {
+	var best *models.Bid
+	for i, b := range result.Bids {
+		if result.WinningBid != nil && b.BidderID != result.WinningBid.BidderID &&
+			(best == nil || b.Amount > best.Amount) {
+			best = &result.Bids[i]
+		}
+	}
+	return best
+}
+
+func bid(bidderID int, amount float64) models.Bid {
+	return models.Bid{BidderID: bidderID, Amount: amount}
+}
+
+func TestTopBidders(t *testing.T) {
+	// Bids placed: #1=6, #2=5, #3=4, #4=3, #7=3, #5=2, #6=2
+	// Wins:        #7=3, #2=2, #5=1
+	results := []models.AuctionResult{
+		auctionWithBids(1, bid(1, 100), bid(2, 110), bid(7, 300)),
+		auctionWithBids(2, bid(1, 100), bid(2, 120), bid(3, 105), bid(7, 310)),
+		auctionWithBids(3, bid(1, 100), bid(2, 130), bid(3, 101), bid(4, 102), bid(7, 320)),
+		auctionWithBids(4, bid(1, 100), bid(2, 140), bid(3, 102), bid(4, 103)),
+		auctionWithBids(5, bid(1, 100), bid(2, 150), bid(3, 103), bid(4, 104), bid(5, 90), bid(6, 95)),
+		auctionWithBids(6, bid(1, 100), bid(5, 200), bid(6, 150)),
+	}
+
+	result := models.SimulationResult{
+		TotalAuctions:      len(results),
+		AuctionResults:     results,
+		SuccessfulAuctions: len(results),
+	}
+
+	stats := NewAnalyzer().Analyze(result)
+
+	// #4 and #7 tie on 3 bids; the lower ID ranks first
+	expectedByBids := []BidderRank{{1, 6}, {2, 5}, {3, 4}, {4, 3}, {7, 3}}
+	assertRanking(t, "bids", stats.TopBiddersByBids, expectedByBids)
+
+	expectedByWins := []BidderRank{{7, 3}, {2, 2}, {5, 1}}
+	assertRanking(t, "wins", stats.TopBiddersByWins, expectedByWins)
+
+	if stats.UniqueBidders != 7 {
+		t.Errorf("Expected 7 unique bidders, got %d", stats.UniqueBidders)
+	}
+	if stats.MostActiveBidder != 1 {
+		t.Errorf("Expected most active bidder #1, got #%d", stats.MostActiveBidder)
+	}
+	if stats.MostSuccessfulBidder != 7 {
+		t.Errorf("Expected most successful bidder #7, got #%d", stats.MostSuccessfulBidder)
+	}
+
+	report := NewAnalyzer().FormatReport(stats)
+	if !strings.Contains(report, "By Bids Placed") || !strings.Contains(report, "By Auctions Won") {
+		t.Error("Expected report to contain both top-bidder tables")
+	}
+}
+
+func assertRanking(t *testing.T, name string, got, expected []BidderRank) {
+	t.Helper()
+
+	if len(got) != len(expected) {
+		t.Fatalf("Expected %d bidders ranked by %s, got %d: %v", len(expected), name, len(got), got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Errorf("Ranking by %s position %d: expected %v, got %v", name, i+1, expected[i], got[i])
+		}
+	}
+}
+
+func TestAllocativeEfficiency(t *testing.T) {
+	valued := func(bidderID int, amount, valuation float64) models.Bid {
+		b := bid(bidderID, amount)
+		b.Valuation = valuation
+		return b
+	}
+
+	results := []models.AuctionResult{
+		// Efficient: the winner valued the item highest
+		auctionWithBids(1, valued(1, 120, 150), valued(2, 110, 130)),
+		// Inefficient: bidder 2 valued it more but bid less
+		auctionWithBids(2, valued(1, 140, 145), valued(2, 130, 190)),
+		// Efficient
+		auctionWithBids(3, valued(3, 160, 170)),
+		// Ignored: no valuations recorded
+		auctionWithBids(4, bid(1, 100), bid(2, 200)),
+		// Ignored: no winner
+		auctionWithBids(5),
+	}
+
+	stats := NewAnalyzer().Analyze(models.SimulationResult{
+		TotalAuctions:  len(results),
+		AuctionResults: results,
+	})
+
+	if math.Abs(stats.AllocativeEfficiency-2.0/3.0) > 1e-9 {
+		t.Errorf("Expected allocative efficiency 0.667, got %.3f", stats.AllocativeEfficiency)
+	}
+}
+
+func TestDurationPercentiles(t *testing.T) {
+	// 20 auctions lasting 1s..20s, shuffled
+	results := make([]models.AuctionResult, 20)
+	for i := range results {
+		results[i] = auctionWithBids(i + 1)
+		results[i].Duration = time.Duration((i*7)%20+1) * time.Second
+	}
+
+	stats := NewAnalyzer().Analyze(models.SimulationResult{
+		TotalAuctions:  len(results),
+		AuctionResults: results,
+	})
+
+	expected := map[string][2]time.Duration{
+		"min":    {stats.MinDuration, 1 * time.Second},
+		"median": {stats.MedianDuration, 10500 * time.Millisecond},
+		"p95":    {stats.P95Duration, 19 * time.Second},
+		"max":    {stats.MaxDuration, 20 * time.Second},
+	}
+	for name, pair := range expected {
+		if pair[0] != pair[1] {
+			t.Errorf("Expected %s duration %v, got %v", name, pair[1], pair[0])
+		}
+	}
+
+	report := NewAnalyzer().FormatReport(stats)
+	if !strings.Contains(report, "P95: 19s") {
+		t.Error("Expected report to contain the p95 duration")
+	}
+}
+
+func TestSegmentStats(t *testing.T) {
+	segmented := func(bidderID int, amount float64, segment string) models.Bid {
+		b := bid(bidderID, amount)
+		b.Segment = segment
+		return b
+	}
+
+	results := []models.AuctionResult{
+		auctionWithBids(1, segmented(1, 100, "retail"), segmented(2, 150, "dealer")),
+		auctionWithBids(2, segmented(1, 300, "retail"), segmented(2, 250, "dealer"), segmented(3, 120, "retail")),
+		auctionWithBids(3, segmented(2, 200, "dealer")),
+		auctionWithBids(4, segmented(3, 80, "retail")),
+	}
+
+	stats := NewAnalyzer().Analyze(models.SimulationResult{
+		TotalAuctions:  len(results),
+		AuctionResults: results,
+	})
+
+	expected := []SegmentStats{
+		{Segment: "dealer", BidsPlaced: 3, AuctionsWon: 2, TotalSpent: 350},
+		{Segment: "retail", BidsPlaced: 4, AuctionsWon: 2, TotalSpent: 380},
+	}
+	if len(stats.Segments) != len(expected) {
+		t.Fatalf("Expected %d segments, got %v", len(expected), stats.Segments)
+	}
+	for i := range expected {
+		if stats.Segments[i] != expected[i] {
+			t.Errorf("Segment %d: expected %+v, got %+v", i, expected[i], stats.Segments[i])
+		}
+	}
+
+	report := NewAnalyzer().FormatReport(stats)
+	if !strings.Contains(report, "dealer:") || !strings.Contains(report, "retail:") {
+		t.Error("Expected report to list both segments")
+	}
+}
+
+func TestAverageBiddersPerAuction(t *testing.T) {
+	results := []models.AuctionResult{
+		// 3 bids from 2 bidders
+		auctionWithBids(1, bid(1, 100), bid(2, 110), bid(1, 120)),
+		// 4 bids from 1 bidder
+		auctionWithBids(2, bid(3, 100), bid(3, 105), bid(3, 110), bid(3, 115)),
+		// 3 bids from 3 bidders
+		auctionWithBids(3, bid(1, 90), bid(2, 95), bid(3, 100)),
+		// No bids
+		{AuctionID: 4, Status: "no_bids"},
+	}
+
+	stats := NewAnalyzer().Analyze(models.SimulationResult{
+		TotalAuctions:  len(results),
+		AuctionResults: results,
+	})
+
+	// (2 + 1 + 3 + 0) / 4
+	if stats.AverageBiddersPerAuction != 1.5 {
+		t.Errorf("Expected 1.5 bidders per auction, got %v", stats.AverageBiddersPerAuction)
+	}
+	if stats.AverageBiddersPerAuction >= stats.AverageBids {
+		t.Errorf("Expected fewer bidders than bids per auction, got %v bidders and %v bids",
+			stats.AverageBiddersPerAuction, stats.AverageBids)
+	}
+	if report := NewAnalyzer().FormatReport(stats); !strings.Contains(report, "Average Bidders per Auction: 1.5") {
+		t.Error("Expected report to show average bidders per auction")
+	}
+}
+
+func TestWinningPremium(t *testing.T) {
+	withBase := func(result models.AuctionResult, basePrice float64) models.AuctionResult {
+		result.Item.BasePrice = basePrice
+		return result
+	}
+
+	results := []models.AuctionResult{
+		withBase(auctionWithBids(1, bid(1, 150)), 100), // +50%
+		withBase(auctionWithBids(2, bid(2, 220)), 200), // +10%
+		withBase(auctionWithBids(3, bid(3, 90)), 100),  // -10%
+		{AuctionID: 4, Item: models.AuctionItem{ID: 4, BasePrice: 100}, Status: "no_bids"},
+	}
+
+	stats := NewAnalyzer().Analyze(models.SimulationResult{
+		TotalAuctions:  len(results),
+		AuctionResults: results,
+	})
+
+	expected := map[string][2]float64{
+		"Average": {stats.AverageWinningPremiumPct, 50.0 / 3},
+		"Min":     {stats.MinWinningPremiumPct, -10},
+		"Max":     {stats.MaxWinningPremiumPct, 50},
+	}
+	for name, pair := range expected {
+		if math.Abs(pair[0]-pair[1]) > 1e-9 {
+			t.Errorf("%s premium: expected %.4f%%, got %.4f%%", name, pair[1], pair[0])
+		}
+	}
+}
+
+func TestReportCurrency(t *testing.T) {
+	results := []models.AuctionResult{
+		auctionWithBids(1, bid(1, 1234.5678)),
+		auctionWithBids(2, bid(2, 99.5)),
+	}
+
+	analyzer := NewAnalyzer()
+	analyzer.Currency = config.Currency{Symbol: "€", Code: "EUR", Decimals: 3}
+	report := analyzer.FormatReport(analyzer.Analyze(models.SimulationResult{
+		TotalAuctions:  len(results),
+		AuctionResults: results,
+	}))
+
+	for _, expected := range []string{"Total Revenue: €1334.068", "Min/Max: €99.500 / €1234.568"} {
+		if !strings.Contains(report, expected) {
+			t.Errorf("Expected report to contain %q, got:\n%s", expected, report)
+		}
+	}
+	if strings.Contains(report, "$") {
+		t.Error("Expected no dollar amounts in a euro report")
+	}
+}
+
+// BenchmarkAnalyze benchmarks the batch analyzer on a large seeded result
+func BenchmarkAnalyze(b *testing.B) {
+	r := rand.New(rand.NewSource(42))
+
+	results := make([]models.AuctionResult, 10000)
+	for i := range results {
+		bids := make([]models.Bid, r.Intn(50))
+		for j := range bids {
+			bids[j] = bid(r.Intn(1000)+1, float64(100+r.Intn(1000)))
+		}
+		results[i] = auctionWithBids(i+1, bids...)
+		results[i].Duration = time.Duration(900+r.Intn(200)) * time.Millisecond
+	}
+	result := models.SimulationResult{
+		TotalAuctions:  len(results),
+		TotalDuration:  10 * time.Second,
+		AuctionResults: results,
+		TotalBids:      sumBids(results),
+	}
+
+	analyzer := NewAnalyzer()
+	b.ReportAllocs()
+	for b.Loop() {
+		analyzer.Analyze(result)
+	}
+}
+
+func TestAnalyzeCompactMatchesFull(t *testing.T) {
+	results := []models.AuctionResult{
+		auctionWithBids(1, bid(1, 150), bid(2, 180)),
+		auctionWithBids(2),
+		auctionWithBids(3, bid(3, 120)),
+		auctionWithBids(4, bid(1, 200), bid(2, 90), bid(3, 110)),
+	}
+	full := models.SimulationResult{
+		TotalAuctions:      len(results),
+		TotalDuration:      2 * time.Second,
+		AuctionResults:     results,
+		SuccessfulAuctions: 3,
+		FailedAuctions:     1,
+		TotalBids:          sumBids(results),
+	}
+	compact := models.CompactSimulationResult{
+		TotalAuctions:      full.TotalAuctions,
+		TotalDuration:      full.TotalDuration,
+		SuccessfulAuctions: full.SuccessfulAuctions,
+		FailedAuctions:     full.FailedAuctions,
+		TotalBids:          full.TotalBids,
+	}
+	for _, result := range results {
+		compact.AuctionResults = append(compact.AuctionResults, models.NewCompactResult(result))
+	}
+
+	analyzer := NewAnalyzer()
+	want, got := analyzer.Analyze(full), analyzer.AnalyzeCompact(compact)
+
+	if got.TotalBids != want.TotalBids || got.MinBids != want.MinBids || got.MaxBids != want.MaxBids ||
+		got.MedianBids != want.MedianBids || got.AverageBids != want.AverageBids {
+		t.Errorf("Bid statistics differ: compact=%+v full=%+v", got, want)
+	}
+	if got.TotalRevenue != want.TotalRevenue || got.MedianWinAmount != want.MedianWinAmount ||
+		got.MinWinAmount != want.MinWinAmount || got.MaxWinAmount != want.MaxWinAmount {
+		t.Errorf("Revenue statistics differ: compact=%+v full=%+v", got, want)
+	}
+	if got.SuccessRate != want.SuccessRate || got.BidsPerSecond != want.BidsPerSecond {
+		t.Errorf("Expected success rate %.1f and %.1f bids/s, got %.1f and %.1f",
+			want.SuccessRate, want.BidsPerSecond, got.SuccessRate, got.BidsPerSecond)
+	}
+}
+
+func TestShareAuctionRevenue(t *testing.T) {
+	// Both share holders pay their bids: 120 + 80
+	shared := auctionWithBids(1, bid(1, 120), bid(2, 80))
+	shared.Shares = map[int]float64{1: 0.6, 2: 0.4}
+	results := []models.AuctionResult{shared, auctionWithBids(2, bid(3, 150))}
+	full := models.SimulationResult{TotalAuctions: len(results), AuctionResults: results, TotalBids: sumBids(results)}
+
+	stats := NewAnalyzer().Analyze(full)
+	if math.Abs(stats.TotalRevenue-350) > 1e-9 || math.Abs(stats.AverageWinAmount-175) > 1e-9 {
+		t.Errorf("Expected revenue 350 and an average of 175, got %.2f and %.2f",
+			stats.TotalRevenue, stats.AverageWinAmount)
+	}
+
+	compact := models.CompactSimulationResult{TotalAuctions: full.TotalAuctions, TotalBids: full.TotalBids}
+	for _, result := range results {
+		compact.AuctionResults = append(compact.AuctionResults, models.NewCompactResult(result))
+	}
+	if got := NewAnalyzer().AnalyzeCompact(compact).TotalRevenue; math.Abs(got-350) > 1e-9 {
+		t.Errorf("Expected compact revenue 350, got %.2f", got)
+	}
+}
+
+func TestCloseAndRunawayAuctions(t *testing.T) {
+	withRunnerUp := func(result models.AuctionResult) models.AuctionResult {
+		result.RunnerUp = runnerUp(result)
+		return result
+	}
+
+	results := []models.AuctionResult{
+		withRunnerUp(auctionWithBids(1, bid(1, 102), bid(2, 100))), // 2% margin: close
+		withRunnerUp(auctionWithBids(2, bid(1, 104), bid(2, 100))), // 4%: close
+		withRunnerUp(auctionWithBids(3, bid(1, 105), bid(2, 100))), // 5%: runaway
+		withRunnerUp(auctionWithBids(4, bid(1, 200), bid(2, 100))), // 100%: runaway
+		withRunnerUp(auctionWithBids(5, bid(1, 300))),              // No runner-up
+		withRunnerUp(auctionWithBids(6)),                           // No winner
+	}
+	result := models.SimulationResult{TotalAuctions: len(results), AuctionResults: results}
+
+	analyzer := NewAnalyzer()
+	analyzer.CloseMarginPct = 5
+	stats := analyzer.Analyze(result)
+	if stats.CloseAuctions != 2 || stats.RunawayAuctions != 2 {
+		t.Errorf("Expected 2 close and 2 runaway auctions at 5%%, got %d and %d",
+			stats.CloseAuctions, stats.RunawayAuctions)
+	}
+	if report := analyzer.FormatReport(stats); !strings.Contains(report, "2 close / 2 runaway (margin < 5.0%)") {
+		t.Errorf("Expected the report to show the competition counts, got:\n%s", report)
+	}
+
+	analyzer.CloseMarginPct = 50
+	stats = analyzer.Analyze(result)
+	if stats.CloseAuctions != 3 || stats.RunawayAuctions != 1 {
+		t.Errorf("Expected 3 close and 1 runaway auctions at 50%%, got %d and %d",
+			stats.CloseAuctions, stats.RunawayAuctions)
+	}
+}
+
+func TestASCIIReport(t *testing.T) {
+	results := []models.AuctionResult{
+		auctionWithBids(1, bid(1, 120), bid(2, 150)),
+		auctionWithBids(2, bid(3, 90)),
+		auctionWithBids(3),
+	}
+	for i := range results {
+		results[i].Item.Category = models.CategoryArt
+		results[i].Item.Rarity = "Rare"
+		results[i].Duration = time.Duration(i+1) * time.Second
+	}
+	result := models.SimulationResult{TotalAuctions: len(results), AuctionResults: results, TotalDuration: time.Second}
+
+	analyzer := NewAnalyzer()
+	analyzer.Currency = config.Currency{Symbol: "€", Decimals: 2}
+	analyzer.ASCII = true
+	report := analyzer.FormatReport(analyzer.Analyze(result))
+
+	for i := 0; i < len(report); i++ {
+		if report[i] >= 0x80 {
+			t.Fatalf("Expected only ASCII, got byte %#x at %d in:\n%s", report[i], i, report)
+		}
+	}
+	if !strings.Contains(report, "DETAILED STATISTICS") || !strings.Contains(report, "EUR") {
+		t.Errorf("Expected the report content kept in ASCII:\n%s", report)
+	}
+}
+
+func TestEmptyResult(t *testing.T) {
+	analyzer := NewAnalyzer()
+	for name, stats := range map[string]Statistics{
+		"batch":     analyzer.Analyze(models.SimulationResult{}),
+		"compact":   analyzer.AnalyzeCompact(models.CompactSimulationResult{}),
+		"streaming": NewStreamingAnalyzer().Result(),
+	} {
+		if stats.TotalAuctions != 0 || stats.SuccessRate != 0 || stats.AverageBids != 0 {
+			t.Errorf("%s: expected zero statistics, got %+v", name, stats)
+		}
+		report := analyzer.FormatReport(stats)
+		if !strings.Contains(report, "No auctions") || strings.Contains(report, "NaN") {
+			t.Errorf("%s: expected a no-auctions report, got:\n%s", name, report)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package stats
+
+import (
+	"math"
+	"sort"
+
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+// AttributeGroup is the average winning amount of the won auctions whose
+// items share one value of a categorical attribute
+type AttributeGroup struct {
+	Attribute        string // "Category", "Condition" or "Rarity"
+	Value            string
+	Auctions         int // Won auctions in the group
+	AverageWinAmount float64
+}
+
+// groupedAttributes are the categorical item attributes winning amounts
+// are grouped by, in report order
+var groupedAttributes = []struct {
+	name  string
+	value func(models.AuctionItem) string
+}{
+	{"Category", func(item models.AuctionItem) string { return string(item.Category) }},
+	{"Condition", func(item models.AuctionItem) string { return item.Condition }},
+	{"Rarity", func(item models.AuctionItem) string { return item.Rarity }},
+}
+
+// analyzeAttributes relates item attributes to winning amounts
+func (a *Analyzer) analyzeAttributes(results []models.AuctionResult, stats *Statistics) {
+	tally := newAttributeTally()
+	for _, result := range results {
+		tally.add(result)
+	}
+	tally.apply(stats)
+}
+
+// attributeKey identifies one value of one grouped attribute
+type attributeKey struct {
+	attribute, value string
+}
+
+// attributeTally accumulates winning amounts by item attribute one auction
+// at a time
+// Only won auctions are considered; empty attribute values are skipped.
+type attributeTally struct {
+	groups map[attributeKey]*runningStats
+	rating pearson
+	year   pearson
+}
+
+func newAttributeTally() *attributeTally {
+	return &attributeTally{groups: make(map[attributeKey]*runningStats)}
+}
+
+// add counts one auction
+func (t *attributeTally) add(result models.AuctionResult) {
+	if result.WinningBid == nil {
+		return
+	}
+	item, amount := result.Item, result.WinningBid.Amount
+
+	for _, attr := range groupedAttributes {
+		value := attr.value(item)
+		if value == "" {
+			continue
+		}
+		key := attributeKey{attr.name, value}
+		group := t.groups[key]
+		if group == nil {
+			group = &runningStats{}
+			t.groups[key] = group
+		}
+		group.add(amount)
+	}
+
+	if item.Rating > 0 {
+		t.rating.add(item.Rating, amount)
+	}
+	if item.YearMade > 0 {
+		t.year.add(float64(item.YearMade), amount)
+	}
+}
+
+// apply sets the attribute statistics from the tally
+func (t *attributeTally) apply(stats *Statistics) {
+	stats.WinAmountByAttribute = stats.WinAmountByAttribute[:0]
+	for key, group := range t.groups {
+		stats.WinAmountByAttribute = append(stats.WinAmountByAttribute, AttributeGroup{
+			Attribute:        key.attribute,
+			Value:            key.value,
+			Auctions:         group.n,
+			AverageWinAmount: group.mean,
+		})
+	}
+
+	// Attributes in report order, then by value
+	order := make(map[string]int, len(groupedAttributes))
+	for i, attr := range groupedAttributes {
+		order[attr.name] = i
+	}
+	sort.Slice(stats.WinAmountByAttribute, func(i, j int) bool {
+		x, y := stats.WinAmountByAttribute[i], stats.WinAmountByAttribute[j]
+		if x.Attribute != y.Attribute {
+			return order[x.Attribute] < order[y.Attribute]
+		}
+		return x.Value < y.Value
+	})
+
+	stats.RatingCorrelation = t.rating.correlation()
+	stats.YearMadeCorrelation = t.year.correlation()
+}
+
+// pearson accumulates the Pearson correlation of pairs (x, y) from running
+// sums
+type pearson struct {
+	n                   int
+	sumX, sumY          float64
+	sumXX, sumYY, sumXY float64
+}
+
+func (p *pearson) add(x, y float64) {
+	p.n++
+	p.sumX += x
+	p.sumY += y
+	p.sumXX += x * x
+	p.sumYY += y * y
+	p.sumXY += x * y
+}
+
+// correlation returns the correlation coefficient in [-1, 1], or 0 with
+// fewer than two pairs or when either variable is constant
+func (p *pearson) correlation() float64 {
+	if p.n < 2 {
+		return 0
+	}
+	n := float64(p.n)
+	cov := n*p.sumXY - p.sumX*p.sumY
+	varX := n*p.sumXX - p.sumX*p.sumX
+	varY := n*p.sumYY - p.sumY*p.sumY
+	if varX <= 0 || varY <= 0 {
+		return 0
+	}
+	return max(-1, min(1, cov/math.Sqrt(varX*varY)))
+}
@@ -0,0 +1,69 @@
+package stats
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+func TestWinAmountByAttribute(t *testing.T) {
+	won := func(id int, amount float64, rarity, condition string, rating float64, year int) models.AuctionResult {
+		result := auctionWithBids(id, bid(1, amount))
+		result.Item.Category = models.CategoryArt
+		result.Item.Rarity = rarity
+		result.Item.Condition = condition
+		result.Item.Rating = rating
+		result.Item.YearMade = year
+		return result
+	}
+
+	// Amounts rise with rating; years are unrelated to them
+	results := []models.AuctionResult{
+		won(1, 100, "Common", "Used", 2, 2001),
+		won(2, 200, "Common", "New", 4, 2010),
+		won(3, 300, "Rare", "New", 6, 2003),
+		won(4, 500, "Rare", "New", 10, 2008),
+		auctionWithBids(5), // Unsold items don't count
+	}
+
+	stats := NewAnalyzer().Analyze(models.SimulationResult{TotalAuctions: len(results), AuctionResults: results})
+
+	expected := []AttributeGroup{
+		{"Category", "Art", 4, 275},
+		{"Condition", "New", 3, 1000.0 / 3},
+		{"Condition", "Used", 1, 100},
+		{"Rarity", "Common", 2, 150},
+		{"Rarity", "Rare", 2, 400},
+	}
+	if len(stats.WinAmountByAttribute) != len(expected) {
+		t.Fatalf("Expected %d attribute groups, got %+v", len(expected), stats.WinAmountByAttribute)
+	}
+	for i, want := range expected {
+		got := stats.WinAmountByAttribute[i]
+		if got.Attribute != want.Attribute || got.Value != want.Value || got.Auctions != want.Auctions ||
+			math.Abs(got.AverageWinAmount-want.AverageWinAmount) > 1e-9 {
+			t.Errorf("Group %d: expected %+v, got %+v", i, want, got)
+		}
+	}
+
+	if stats.RatingCorrelation < 0.95 {
+		t.Errorf("Expected a strong rating correlation, got %.3f", stats.RatingCorrelation)
+	}
+	if math.Abs(stats.YearMadeCorrelation) > 0.5 {
+		t.Errorf("Expected a weak year correlation, got %.3f", stats.YearMadeCorrelation)
+	}
+
+	streaming := NewStreamingAnalyzer()
+	for _, result := range results {
+		streaming.Add(result)
+	}
+	if got := streaming.Result().RatingCorrelation; got != stats.RatingCorrelation {
+		t.Errorf("Streaming rating correlation %.3f differs from batch %.3f", got, stats.RatingCorrelation)
+	}
+
+	if report := NewAnalyzer().FormatReport(stats); !strings.Contains(report, "Rarity:") {
+		t.Errorf("Expected the report to group by rarity:\n%s", report)
+	}
+}
@@ -0,0 +1,52 @@
+package stats
+
+import (
+	"math"
+
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+// finite reports whether amount is neither NaN nor infinite
+func finite(amount float64) bool {
+	return !math.IsNaN(amount) && !math.IsInf(amount, 0)
+}
+
+// finiteResult returns result without a non-finite winning or runner-up
+// bid, and how many such bids were dropped
+// An auction whose winning amount is dropped counts as unsold in the amount,
+// bidder win and attribute statistics, so one bad value can't turn sums and
+// averages into NaN or Inf.
+func finiteResult(result models.AuctionResult) (models.AuctionResult, int) {
+	skipped := 0
+	if result.WinningBid != nil && !finite(result.WinningBid.Amount) {
+		result.WinningBid = nil
+		skipped++
+	}
+	if result.RunnerUp != nil && !finite(result.RunnerUp.Amount) {
+		result.RunnerUp = nil
+		skipped++
+	}
+	return result, skipped
+}
+
+// finiteResults applies finiteResult to every result, copying results only
+// if anything is dropped
+func finiteResults(results []models.AuctionResult) ([]models.AuctionResult, int) {
+	total := 0
+	var cleaned []models.AuctionResult
+	for i, result := range results {
+		fixed, skipped := finiteResult(result)
+		if skipped == 0 {
+			continue
+		}
+		if cleaned == nil {
+			cleaned = append([]models.AuctionResult(nil), results...)
+		}
+		cleaned[i] = fixed
+		total += skipped
+	}
+	if cleaned == nil {
+		return results, 0
+	}
+	return cleaned, total
+}
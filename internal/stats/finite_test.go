@@ -0,0 +1,57 @@
+package stats
+
+import (
+	"math"
+	"testing"
+
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+func TestAnalyzeSkipsNonFiniteAmounts(t *testing.T) {
+	results := []models.AuctionResult{
+		auctionWithBids(1, bid(1, 100)),
+		auctionWithBids(2, bid(2, 200)),
+		auctionWithBids(3, bid(3, 300)),
+	}
+	// A NaN sneaks into a winning amount, and an Inf into a runner-up
+	results[1].WinningBid.Amount = math.NaN()
+	results[2].RunnerUp = &models.Bid{BidderID: 4, Amount: math.Inf(1)}
+
+	stats := NewAnalyzer().Analyze(models.SimulationResult{TotalAuctions: len(results), AuctionResults: results})
+
+	if stats.SkippedNonFinite != 2 {
+		t.Errorf("Expected 2 skipped amounts, got %d", stats.SkippedNonFinite)
+	}
+	for name, value := range map[string]float64{
+		"TotalRevenue":             stats.TotalRevenue,
+		"AverageWinAmount":         stats.AverageWinAmount,
+		"MedianWinAmount":          stats.MedianWinAmount,
+		"MinWinAmount":             stats.MinWinAmount,
+		"MaxWinAmount":             stats.MaxWinAmount,
+		"AverageWinningPremiumPct": stats.AverageWinningPremiumPct,
+		"StdDevBids":               stats.StdDevBids,
+	} {
+		if !finite(value) {
+			t.Errorf("%s is %v", name, value)
+		}
+	}
+	if stats.TotalRevenue != 400 || stats.AverageWinAmount != 200 {
+		t.Errorf("Expected the two finite wins to total 400 (average 200), got %.2f (%.2f)",
+			stats.TotalRevenue, stats.AverageWinAmount)
+	}
+	if stats.UniqueWinners != 2 {
+		t.Errorf("Expected 2 winners, got %d", stats.UniqueWinners)
+	}
+	if !math.IsNaN(results[1].WinningBid.Amount) {
+		t.Error("Expected the caller's results to be left unchanged")
+	}
+
+	streaming := NewStreamingAnalyzer()
+	for _, result := range results {
+		streaming.Add(result)
+	}
+	if got := streaming.Result(); got.SkippedNonFinite != 2 || got.TotalRevenue != 400 {
+		t.Errorf("Expected streaming to skip 2 amounts and total 400, got %d and %.2f",
+			got.SkippedNonFinite, got.TotalRevenue)
+	}
+}
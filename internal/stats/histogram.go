@@ -0,0 +1,66 @@
+package stats
+
+import "math"
+
+// HistogramBucket counts the values in [Lower, Upper)
+// The last bucket of a histogram also holds values equal to its Upper.
+type HistogramBucket struct {
+	Lower float64
+	Upper float64
+	Count int
+}
+
+// maxBidCountBuckets bounds the bid-count histogram; wider ranges of bid
+// counts share buckets
+const maxBidCountBuckets = 20
+
+// winAmountBuckets is the number of winning-amount buckets
+const winAmountBuckets = 10
+
+// bidCountHistogram buckets sorted bid counts into whole-number buckets:
+// one per count, or wider ones if there would be more than
+// maxBidCountBuckets
+func bidCountHistogram(sorted []int) []HistogramBucket {
+	if len(sorted) == 0 {
+		return nil
+	}
+
+	lo, hi := sorted[0], sorted[len(sorted)-1]
+	width := (hi - lo + maxBidCountBuckets) / maxBidCountBuckets // Ceiling of span/max
+	buckets := make([]HistogramBucket, (hi-lo)/width+1)
+	for i := range buckets {
+		buckets[i].Lower = float64(lo + i*width)
+		buckets[i].Upper = float64(lo + (i+1)*width)
+	}
+	for _, count := range sorted {
+		buckets[(count-lo)/width].Count++
+	}
+	return buckets
+}
+
+// amountHistogram buckets sorted amounts into winAmountBuckets equal-width
+// buckets from the lowest to the highest amount
+func amountHistogram(sorted []float64) []HistogramBucket {
+	if len(sorted) == 0 {
+		return nil
+	}
+
+	lo, hi := sorted[0], sorted[len(sorted)-1]
+	if lo == hi {
+		// All amounts equal: a single bucket holds them
+		return []HistogramBucket{{Lower: lo, Upper: hi, Count: len(sorted)}}
+	}
+
+	width := (hi - lo) / winAmountBuckets
+	buckets := make([]HistogramBucket, winAmountBuckets)
+	for i := range buckets {
+		buckets[i].Lower = lo + float64(i)*width
+		buckets[i].Upper = lo + float64(i+1)*width
+	}
+	buckets[len(buckets)-1].Upper = hi
+	for _, amount := range sorted {
+		i := min(int(math.Floor((amount-lo)/width)), len(buckets)-1)
+		buckets[i].Count++
+	}
+	return buckets
+}
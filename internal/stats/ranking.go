@@ -0,0 +1,85 @@
+package stats
+
+import (
+	"container/heap"
+	"sort"
+
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+// TopAuctionsByBids returns up to n of results with the most bids, most
+// first, in O(len(results) log n)
+// Ties keep the order of results. results itself is not reordered.
+func TopAuctionsByBids(results []models.AuctionResult, n int) []models.AuctionResult {
+	return rankAuctions(results, n, func(a, b models.AuctionResult) bool {
+		return a.TotalBids > b.TotalBids
+	})
+}
+
+// BottomAuctionsByBids returns up to n of results with the fewest bids,
+// fewest first, in O(len(results) log n)
+// Ties keep the order of results. results itself is not reordered.
+func BottomAuctionsByBids(results []models.AuctionResult, n int) []models.AuctionResult {
+	return rankAuctions(results, n, func(a, b models.AuctionResult) bool {
+		return a.TotalBids < b.TotalBids
+	})
+}
+
+// ranked is an auction result with its position in the input, which breaks
+// ties so the ranking is stable
+type ranked struct {
+	result models.AuctionResult
+	index  int
+}
+
+// rankHeap keeps the best n results seen so far, worst at the root
+type rankHeap struct {
+	items  []ranked
+	before func(a, b models.AuctionResult) bool
+}
+
+// better reports whether a ranks ahead of b
+func (h *rankHeap) better(a, b ranked) bool {
+	if h.before(a.result, b.result) {
+		return true
+	}
+	return !h.before(b.result, a.result) && a.index < b.index
+}
+
+func (h *rankHeap) Len() int           { return len(h.items) }
+func (h *rankHeap) Less(i, j int) bool { return h.better(h.items[j], h.items[i]) }
+func (h *rankHeap) Swap(i, j int)      { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *rankHeap) Push(x any)         { h.items = append(h.items, x.(ranked)) }
+func (h *rankHeap) Pop() any {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
+
+// rankAuctions returns the n results ranked first by before, in rank order,
+// selecting them with a bounded heap rather than sorting all of results
+func rankAuctions(results []models.AuctionResult, n int, before func(a, b models.AuctionResult) bool) []models.AuctionResult {
+	n = max(0, min(n, len(results)))
+	if n == 0 {
+		return []models.AuctionResult{}
+	}
+
+	h := &rankHeap{items: make([]ranked, 0, n), before: before}
+	for i, result := range results {
+		candidate := ranked{result: result, index: i}
+		if h.Len() < n {
+			heap.Push(h, candidate)
+		} else if h.better(candidate, h.items[0]) {
+			// Displace the worst of the best so far
+			h.items[0] = candidate
+			heap.Fix(h, 0)
+		}
+	}
+
+	sort.Slice(h.items, func(i, j int) bool { return h.better(h.items[i], h.items[j]) })
+	top := make([]models.AuctionResult, n)
+	for i, item := range h.items {
+		top[i] = item.result
+	}
+	return top
+}
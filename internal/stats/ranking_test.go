@@ -0,0 +1,45 @@
+package stats
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+func TestTopAuctionsByBids(t *testing.T) {
+	r := rand.New(rand.NewSource(3))
+	results := make([]models.AuctionResult, 10000)
+	for i := range results {
+		results[i] = models.AuctionResult{AuctionID: i + 1, TotalBids: r.Intn(500)}
+	}
+
+	// A full stable sort gives the expected ranking
+	expected := make([]models.AuctionResult, len(results))
+	copy(expected, results)
+	sort.SliceStable(expected, func(i, j int) bool { return expected[i].TotalBids > expected[j].TotalBids })
+
+	for _, n := range []int{0, 1, 25, len(results) + 5} {
+		got := TopAuctionsByBids(results, n)
+		if want := min(n, len(results)); len(got) != want {
+			t.Fatalf("n=%d: expected %d auctions, got %d", n, want, len(got))
+		}
+		for i := range got {
+			if got[i].AuctionID != expected[i].AuctionID {
+				t.Fatalf("n=%d, rank %d: expected auction #%d (%d bids), got #%d (%d bids)",
+					n, i+1, expected[i].AuctionID, expected[i].TotalBids, got[i].AuctionID, got[i].TotalBids)
+			}
+		}
+	}
+
+	bottom := BottomAuctionsByBids(results, 10)
+	for i := 1; i < len(bottom); i++ {
+		if bottom[i].TotalBids < bottom[i-1].TotalBids {
+			t.Fatalf("Expected bottom auctions fewest bids first, got %d after %d", bottom[i].TotalBids, bottom[i-1].TotalBids)
+		}
+	}
+	if results[0].AuctionID != 1 {
+		t.Error("Expected the input not to be reordered")
+	}
+}
@@ -0,0 +1,246 @@
+package stats
+
+import (
+	"container/heap"
+	"math"
+	"time"
+
+	"github.com/vineetjain1712/auction-simulator/config"
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+// StreamingAnalyzer updates statistics incrementally as auction results
+// arrive, without holding the results themselves
+//
+// Bid counts and winning amounts keep running aggregates (Welford's
+// mean/variance and a two-heap running median). Bidder statistics are
+// bounded by the number of bidders. P95Duration and the performance
+// metrics need the full run and are left zero.
+type StreamingAnalyzer struct {
+	// IsSuccess decides which results count as successful auctions
+	// Defaults to models.HasWinner
+	IsSuccess models.SuccessPredicate
+	// CloseMarginPct separates close from runaway auctions (see
+	// Statistics.CloseAuctions); set it before adding results
+	CloseMarginPct float64
+
+	count      int
+	successful int
+	skipped    int // Non-finite amounts left out
+
+	bids      runningStats
+	bidMedian runningMedian
+
+	wins      runningStats
+	winMedian runningMedian
+
+	durations      runningStats
+	durationMedian runningMedian
+
+	bidders    *bidderTally
+	allocation allocationTally
+	premiums   premiumTally
+	margins    marginTally
+	attributes *attributeTally
+}
+
+// NewStreamingAnalyzer creates an empty streaming analyzer
+func NewStreamingAnalyzer() *StreamingAnalyzer {
+	return &StreamingAnalyzer{
+		IsSuccess:      models.HasWinner,
+		CloseMarginPct: config.DefaultCloseMarginPct,
+		bidders:        newBidderTally(),
+		attributes:     newAttributeTally(),
+	}
+}
+
+// Add updates the running statistics with one auction result
+// Like Analyze, it leaves out NaN and infinite winning and runner-up
+// amounts, counting them in SkippedNonFinite.
+func (s *StreamingAnalyzer) Add(result models.AuctionResult) {
+	s.count++
+
+	result, skipped := finiteResult(result)
+	s.skipped += skipped
+
+	isSuccess := s.IsSuccess
+	if isSuccess == nil {
+		isSuccess = models.HasWinner
+	}
+	if isSuccess(result) {
+		s.successful++
+	}
+
+	s.addOutcome(result.TotalBids, result.WinningBid != nil, models.Revenue(result), result.Duration)
+
+	s.bidders.add(result)
+	s.allocation.add(result)
+	s.premiums.add(result)
+	s.margins.closePct = s.CloseMarginPct
+	s.margins.add(result)
+	s.attributes.add(result)
+}
+
+// AddCompact updates the running statistics with one compact auction result
+// An auction counts as successful if it has a winner. Compact results carry
+// no bids or items, so bidder, premium, allocation and attribute statistics only
+// reflect results passed to Add.
+func (s *StreamingAnalyzer) AddCompact(result models.CompactResult) {
+	s.count++
+	if result.Won {
+		s.successful++
+	}
+	won := result.Won
+	if won && !finite(result.Revenue) {
+		won = false
+		s.skipped++
+	}
+	s.addOutcome(result.TotalBids, won, result.Revenue, result.Duration)
+}
+
+// addOutcome updates the bid count, winning amount and duration aggregates
+func (s *StreamingAnalyzer) addOutcome(totalBids int, won bool, winningAmount float64, duration time.Duration) {
+	s.bids.add(float64(totalBids))
+	s.bidMedian.add(float64(totalBids))
+
+	if won {
+		s.wins.add(winningAmount)
+		s.winMedian.add(winningAmount)
+	}
+
+	s.durations.add(float64(duration))
+	s.durationMedian.add(float64(duration))
+}
+
+// Result returns the statistics of all results added so far
+func (s *StreamingAnalyzer) Result() Statistics {
+	stats := Statistics{
+		TotalAuctions:    s.count,
+		AuctionsSuccess:  s.successful,
+		AuctionsFailed:   s.count - s.successful,
+		SkippedNonFinite: s.skipped,
+	}
+	if s.count == 0 {
+		return stats
+	}
+
+	// Bid statistics
+	stats.TotalBids = int(s.bids.sum)
+	stats.AverageBids = s.bids.mean
+	stats.MinBids = int(s.bids.min)
+	stats.MaxBids = int(s.bids.max)
+	stats.MedianBids = s.bidMedian.median()
+	stats.StdDevBids = s.bids.stdDev()
+
+	// Amount statistics
+	if s.wins.n > 0 {
+		stats.TotalRevenue = s.wins.sum
+		stats.AverageWinAmount = s.wins.mean
+		stats.MinWinAmount = s.wins.min
+		stats.MaxWinAmount = s.wins.max
+		stats.MedianWinAmount = s.winMedian.median()
+	}
+
+	// Duration statistics
+	stats.MinDuration = time.Duration(s.durations.min)
+	stats.MedianDuration = time.Duration(s.durationMedian.median())
+	stats.MaxDuration = time.Duration(s.durations.max)
+
+	s.bidders.apply(&stats)
+	s.allocation.apply(&stats)
+	s.premiums.apply(&stats)
+	s.margins.apply(&stats)
+	s.attributes.apply(&stats)
+
+	stats.SuccessRate = float64(s.successful) / float64(s.count) * 100
+	return stats
+}
+
+// runningStats keeps count, sum, min, max and Welford's running mean and
+// variance of a stream of values
+type runningStats struct {
+	n        int
+	sum      float64
+	min, max float64
+	mean     float64
+	m2       float64 // Sum of squared differences from the mean
+}
+
+func (r *runningStats) add(x float64) {
+	r.n++
+	r.sum += x
+	if r.n == 1 || x < r.min {
+		r.min = x
+	}
+	if r.n == 1 || x > r.max {
+		r.max = x
+	}
+
+	delta := x - r.mean
+	r.mean += delta / float64(r.n)
+	r.m2 += delta * (x - r.mean)
+}
+
+// stdDev returns the population standard deviation
+func (r *runningStats) stdDev() float64 {
+	if r.n == 0 {
+		return 0
+	}
+	return math.Sqrt(r.m2 / float64(r.n))
+}
+
+// runningMedian keeps the median of a stream of values in two heaps: the
+// lower half in a max-heap and the upper half in a min-heap
+type runningMedian struct {
+	lower maxHeap
+	upper minHeap
+}
+
+func (r *runningMedian) add(x float64) {
+	if r.lower.Len() == 0 || x <= r.lower.floats[0] {
+		heap.Push(&r.lower, x)
+	} else {
+		heap.Push(&r.upper, x)
+	}
+
+	// Rebalance so lower holds the extra value when the count is odd
+	if r.lower.Len() > r.upper.Len()+1 {
+		heap.Push(&r.upper, heap.Pop(&r.lower))
+	} else if r.upper.Len() > r.lower.Len() {
+		heap.Push(&r.lower, heap.Pop(&r.upper))
+	}
+}
+
+func (r *runningMedian) median() float64 {
+	switch {
+	case r.lower.Len() == 0:
+		return 0
+	case r.lower.Len() > r.upper.Len():
+		return r.lower.floats[0]
+	default:
+		return (r.lower.floats[0] + r.upper.floats[0]) / 2
+	}
+}
+
+// floats implements the shared parts of heap.Interface over float64s
+type floats []float64
+
+func (h floats) Len() int      { return len(h) }
+func (h floats) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+func (h *floats) Push(x any)   { *h = append(*h, x.(float64)) }
+func (h *floats) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// minHeap is a heap of float64s with the smallest on top
+type minHeap struct{ floats }
+
+func (h minHeap) Less(i, j int) bool { return h.floats[i] < h.floats[j] }
+
+// maxHeap is a heap of float64s with the largest on top
+type maxHeap struct{ floats }
+
+func (h maxHeap) Less(i, j int) bool { return h.floats[i] > h.floats[j] }
@@ -0,0 +1,119 @@
+package stats
+
+import (
+	"math"
+	"math/rand"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+func TestStreamingMatchesBatch(t *testing.T) {
+	r := rand.New(rand.NewSource(7))
+
+	var results []models.AuctionResult
+	successful := 0
+	for id := 1; id <= 101; id++ {
+		var bids []models.Bid
+		for range r.Intn(8) {
+			b := bid(r.Intn(20)+1, float64(100+r.Intn(400)))
+			b.Valuation = b.Amount + float64(r.Intn(100))
+			bids = append(bids, b)
+		}
+		result := auctionWithBids(id, bids...)
+		result.RunnerUp = runnerUp(result)
+		result.Duration = time.Duration(900+r.Intn(200)) * time.Millisecond
+		if result.WinningBid != nil {
+			successful++
+		}
+		results = append(results, result)
+	}
+
+	batch := NewAnalyzer().Analyze(models.SimulationResult{
+		TotalAuctions:      len(results),
+		AuctionResults:     results,
+		SuccessfulAuctions: successful,
+		FailedAuctions:     len(results) - successful,
+		TotalBids:          sumBids(results),
+	})
+
+	streaming := NewStreamingAnalyzer()
+	for i, result := range results {
+		streaming.Add(result)
+
+		// Intermediate results stay consistent with what has been added
+		if got := streaming.Result().AuctionsSuccess + streaming.Result().AuctionsFailed; got != i+1 {
+			t.Fatalf("After %d results, expected %d auctions counted, got %d", i+1, i+1, got)
+		}
+	}
+	got := streaming.Result()
+
+	floats := map[string][2]float64{
+		"AverageBids":              {got.AverageBids, batch.AverageBids},
+		"MedianBids":               {got.MedianBids, batch.MedianBids},
+		"StdDevBids":               {got.StdDevBids, batch.StdDevBids},
+		"TotalRevenue":             {got.TotalRevenue, batch.TotalRevenue},
+		"AverageWinAmount":         {got.AverageWinAmount, batch.AverageWinAmount},
+		"MinWinAmount":             {got.MinWinAmount, batch.MinWinAmount},
+		"MaxWinAmount":             {got.MaxWinAmount, batch.MaxWinAmount},
+		"MedianWinAmount":          {got.MedianWinAmount, batch.MedianWinAmount},
+		"AllocativeEfficiency":     {got.AllocativeEfficiency, batch.AllocativeEfficiency},
+		"AverageWinningPremiumPct": {got.AverageWinningPremiumPct, batch.AverageWinningPremiumPct},
+		"MinWinningPremiumPct":     {got.MinWinningPremiumPct, batch.MinWinningPremiumPct},
+		"MaxWinningPremiumPct":     {got.MaxWinningPremiumPct, batch.MaxWinningPremiumPct},
+		"AverageBiddersPerAuction": {got.AverageBiddersPerAuction, batch.AverageBiddersPerAuction},
+		"SuccessRate":              {got.SuccessRate, batch.SuccessRate},
+	}
+	for name, pair := range floats {
+		if math.Abs(pair[0]-pair[1]) > 1e-9 {
+			t.Errorf("%s: streaming %v, batch %v", name, pair[0], pair[1])
+		}
+	}
+
+	ints := map[string][2]int{
+		"TotalBids":       {got.TotalBids, batch.TotalBids},
+		"MinBids":         {got.MinBids, batch.MinBids},
+		"MaxBids":         {got.MaxBids, batch.MaxBids},
+		"UniqueBidders":   {got.UniqueBidders, batch.UniqueBidders},
+		"UniqueWinners":   {got.UniqueWinners, batch.UniqueWinners},
+		"CloseAuctions":   {got.CloseAuctions, batch.CloseAuctions},
+		"RunawayAuctions": {got.RunawayAuctions, batch.RunawayAuctions},
+		"AuctionsSuccess": {got.AuctionsSuccess, batch.AuctionsSuccess},
+		"AuctionsFailed":  {got.AuctionsFailed, batch.AuctionsFailed},
+		"MinDuration":     {int(got.MinDuration), int(batch.MinDuration)},
+		"MedianDuration":  {int(got.MedianDuration), int(batch.MedianDuration)},
+		"MaxDuration":     {int(got.MaxDuration), int(batch.MaxDuration)},
+	}
+	for name, pair := range ints {
+		if pair[0] != pair[1] {
+			t.Errorf("%s: streaming %d, batch %d", name, pair[0], pair[1])
+		}
+	}
+
+	if !reflect.DeepEqual(got.TopBiddersByBids, batch.TopBiddersByBids) ||
+		!reflect.DeepEqual(got.TopBiddersByWins, batch.TopBiddersByWins) {
+		t.Errorf("Top bidders differ:\nstreaming %v %v\nbatch     %v %v",
+			got.TopBiddersByBids, got.TopBiddersByWins, batch.TopBiddersByBids, batch.TopBiddersByWins)
+	}
+}
+
+func TestRunningMedian(t *testing.T) {
+	var m runningMedian
+	expected := []float64{5, 3.5, 2, 3.5, 5}
+	for i, x := range []float64{5, 2, 1, 8, 9} {
+		m.add(x)
+		if got := m.median(); got != expected[i] {
+			t.Errorf("After %d values: expected median %v, got %v", i+1, expected[i], got)
+		}
+	}
+}
+
+func sumBids(results []models.AuctionResult) int {
+	total := 0
+	for _, result := range results {
+		total += result.TotalBids
+	}
+	return total
+}
@@ -0,0 +1,58 @@
+package stats
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/vineetjain1712/auction-simulator/config"
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+// SummaryHeader names the tab-separated fields of SummaryLine, in order
+var SummaryHeader = strings.Join([]string{
+	"auctions", "bidders", "bid_probability", "seed",
+	"total_bids", "success_rate_pct", "revenue", "duration_s", "peak_memory_mb", "peak_goroutines",
+}, "\t")
+
+// SummaryLine describes one run on a single tab-separated line, without a
+// trailing newline: its key parameters from cfg, then its outcome from
+// stats and result, in the fields of SummaryHeader
+// The seed is the one the run actually used, so the line can reproduce it.
+func SummaryLine(cfg *config.Config, stats Statistics, result models.SimulationResult) string {
+	return strings.Join([]string{
+		fmt.Sprintf("%d", cfg.Auction.TotalAuctions),
+		fmt.Sprintf("%d", cfg.Bidder.TotalBidders),
+		fmt.Sprintf("%g", cfg.Bidder.BidProbability),
+		fmt.Sprintf("%d", result.Seed),
+		fmt.Sprintf("%d", stats.TotalBids),
+		fmt.Sprintf("%.2f", stats.SuccessRate),
+		fmt.Sprintf("%.2f", stats.TotalRevenue),
+		fmt.Sprintf("%.3f", result.TotalDuration.Seconds()),
+		fmt.Sprintf("%.2f", result.PeakMemoryMB),
+		fmt.Sprintf("%d", result.PeakGoroutines),
+	}, "\t")
+}
+
+// AppendSummaryLine appends line to the file at path, creating it with
+// SummaryHeader as its first line if it is new or empty, so repeated runs
+// accumulate into one .tsv
+func AppendSummaryLine(path, line string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open summary log: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return fmt.Errorf("failed to open summary log: %w", err)
+	}
+	if info.Size() == 0 {
+		line = SummaryHeader + "\n" + line
+	}
+	if _, err := f.WriteString(line + "\n"); err != nil {
+		return fmt.Errorf("failed to append to summary log: %w", err)
+	}
+	return f.Close()
+}
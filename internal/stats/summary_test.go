@@ -0,0 +1,57 @@
+package stats
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/vineetjain1712/auction-simulator/config"
+	"github.com/vineetjain1712/auction-simulator/internal/models"
+)
+
+func TestSummaryLine(t *testing.T) {
+	cfg := config.DefaultConfig()
+	cfg.Auction.TotalAuctions = 40
+	cfg.Bidder.TotalBidders = 100
+	cfg.Bidder.BidProbability = 0.3
+	result := models.SimulationResult{
+		Seed:           42,
+		TotalDuration:  1500 * time.Millisecond,
+		PeakMemoryMB:   12.5,
+		PeakGoroutines: 140,
+	}
+	stats := Statistics{TotalBids: 321, SuccessRate: 87.5, TotalRevenue: 1234.5}
+
+	line := SummaryLine(cfg, stats, result)
+	expected := []string{"40", "100", "0.3", "42", "321", "87.50", "1234.50", "1.500", "12.50", "140"}
+	if got := strings.Split(line, "\t"); strings.Join(got, "|") != strings.Join(expected, "|") {
+		t.Errorf("Expected fields %v, got %v", expected, got)
+	}
+	if fields := strings.Split(SummaryHeader, "\t"); len(fields) != len(expected) {
+		t.Errorf("Expected a header field per value, got %v", fields)
+	}
+
+	// Each run appends a line under a single header
+	path := filepath.Join(t.TempDir(), "sweep.tsv")
+	for seed := int64(1); seed <= 3; seed++ {
+		result.Seed = seed
+		if err := AppendSummaryLine(path, SummaryLine(cfg, stats, result)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) != 4 || lines[0] != SummaryHeader {
+		t.Fatalf("Expected a header and 3 lines, got %q", lines)
+	}
+	for i, line := range lines[1:] {
+		if seed := strings.Split(line, "\t")[3]; seed != []string{"1", "2", "3"}[i] {
+			t.Errorf("Line %d: expected seed %d, got %s", i+1, i+1, seed)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package stats
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Thresholds are minimum acceptable results for a run, used to gate
+// regressions in CI
+// A zero threshold is not checked
+type Thresholds struct {
+	MinSuccessRate   float64 // Minimum success rate in percent (0-100)
+	MinBidsPerSecond float64 // Minimum bid throughput
+	MinRevenue       float64 // Minimum total revenue
+}
+
+// Check compares stats against the thresholds
+// Returns nil if all of them are met, otherwise an error describing every
+// threshold that failed
+func (t Thresholds) Check(stats Statistics) error {
+	var failures []error
+
+	if t.MinSuccessRate > 0 && stats.SuccessRate < t.MinSuccessRate {
+		failures = append(failures, fmt.Errorf("success rate %.1f%% is below the minimum of %.1f%%",
+			stats.SuccessRate, t.MinSuccessRate))
+	}
+	if t.MinBidsPerSecond > 0 && stats.BidsPerSecond < t.MinBidsPerSecond {
+		failures = append(failures, fmt.Errorf("bids/second %.1f is below the minimum of %.1f",
+			stats.BidsPerSecond, t.MinBidsPerSecond))
+	}
+	if t.MinRevenue > 0 && stats.TotalRevenue < t.MinRevenue {
+		failures = append(failures, fmt.Errorf("revenue %.2f is below the minimum of %.2f",
+			stats.TotalRevenue, t.MinRevenue))
+	}
+
+	return errors.Join(failures...)
+}
@@ -0,0 +1,50 @@
+package stats
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestThresholdsCheck(t *testing.T) {
+	stats := Statistics{SuccessRate: 90, BidsPerSecond: 500, TotalRevenue: 10000}
+
+	tests := []struct {
+		name       string
+		thresholds Thresholds
+		failures   []string // Substrings expected in the error, none if passing
+	}{
+		{"no thresholds", Thresholds{}, nil},
+		{"all met", Thresholds{MinSuccessRate: 90, MinBidsPerSecond: 400, MinRevenue: 5000}, nil},
+		{"success rate", Thresholds{MinSuccessRate: 95}, []string{"success rate"}},
+		{"bids per second", Thresholds{MinBidsPerSecond: 600}, []string{"bids/second"}},
+		{
+			"several",
+			Thresholds{MinSuccessRate: 99, MinBidsPerSecond: 100, MinRevenue: 20000},
+			[]string{"success rate", "revenue"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.thresholds.Check(stats)
+
+			if len(tt.failures) == 0 {
+				if err != nil {
+					t.Fatalf("Expected thresholds to pass, got %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Expected thresholds to fail")
+			}
+			for _, failure := range tt.failures {
+				if !strings.Contains(err.Error(), failure) {
+					t.Errorf("Expected error to mention %q, got %q", failure, err)
+				}
+			}
+			if lines := strings.Count(err.Error(), "\n") + 1; lines != len(tt.failures) {
+				t.Errorf("Expected %d failures, got %d: %q", len(tt.failures), lines, err)
+			}
+		})
+	}
+}
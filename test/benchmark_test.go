@@ -0,0 +1,47 @@
+package test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/vineetjain1712/auction-simulator/config"
+	"github.com/vineetjain1712/auction-simulator/internal/logging"
+	"github.com/vineetjain1712/auction-simulator/internal/simulation"
+)
+
+// benchmarkSeed keeps benchmark runs comparable
+const benchmarkSeed = 42
+
+// BenchmarkSimulation benchmarks the full simulation at several scales
+func BenchmarkSimulation(b *testing.B) {
+	scales := []struct {
+		auctions int
+		bidders  int
+	}{
+		{10, 50},
+		{40, 100},
+		{100, 500},
+	}
+
+	for _, scale := range scales {
+		b.Run(fmt.Sprintf("auctions=%d/bidders=%d", scale.auctions, scale.bidders), func(b *testing.B) {
+			cfg := config.DefaultConfig()
+			cfg.Auction.TotalAuctions = scale.auctions
+			cfg.Bidder.TotalBidders = scale.bidders
+			cfg.Auction.AuctionTimeout = 200 * time.Millisecond
+			cfg.Bidder.BidDelayMinMs = 0
+			cfg.Bidder.BidDelayMaxMs = 100
+			cfg.System.Seed = benchmarkSeed
+			cfg.System.Quiet = true
+
+			b.ReportAllocs()
+			for b.Loop() {
+				simulator := simulation.NewSimulator(cfg)
+				simulator.Logger = logging.Discard()
+				simulator.Run(context.Background())
+			}
+		})
+	}
+}